@@ -685,6 +685,16 @@ func (r *RegionInfo) GetCPUUsage() uint64 {
 	return r.cpuUsage
 }
 
+// GetStoreReadCPU returns the read CPU usage of the peer on the given store
+// accumulated over the interval. Since only the leader reports the CPU usage,
+// it's always 0 for the other peers.
+func (r *RegionInfo) GetStoreReadCPU(storeID, interval uint64) uint64 {
+	if r.GetLeader().GetStoreId() != storeID {
+		return 0
+	}
+	return r.cpuUsage * interval
+}
+
 // GetBytesRead returns the read bytes of the region.
 func (r *RegionInfo) GetBytesRead() uint64 {
 	return r.readBytes
@@ -1959,6 +1969,7 @@ func (r *RegionInfo) GetLoads() []float64 {
 		float64(r.GetBytesWritten()),
 		float64(r.GetKeysWritten()),
 		float64(r.GetWriteQueryNum()),
+		float64(r.cpuUsage * (r.interval.GetEndTimestamp() - r.interval.GetStartTimestamp())),
 	}
 }
 
@@ -1971,6 +1982,7 @@ func (r *RegionInfo) GetWriteLoads() []float64 {
 		float64(r.GetBytesWritten()),
 		float64(r.GetKeysWritten()),
 		float64(r.GetWriteQueryNum()),
+		0,
 	}
 }
 
@@ -48,6 +48,23 @@ func TestStoreLimit(t *testing.T) {
 	re.True(limit.Take(influence, AddPeer, constant.Low))
 }
 
+func TestSourceRateLimit(t *testing.T) {
+	re := require.New(t)
+	limit := NewSourceRateLimit()
+	re.True(limit.Take("a", 3, influence*3, AddPeer))
+	re.False(limit.Available("a", 3, influence, AddPeer))
+	re.False(limit.Take("a", 3, influence, AddPeer))
+	re.Equal(int64(influence*3), limit.Consumed("a", AddPeer))
+	// The quotas of the sources are independent.
+	re.True(limit.Available("b", 3, influence, AddPeer))
+	re.True(limit.Available("a", 3, influence, RemovePeer))
+	re.Zero(limit.Consumed("b", AddPeer))
+	// The quota is reset when the rate is changed.
+	re.True(limit.Available("a", 5, influence, AddPeer))
+	// The send snapshot type is not limited.
+	re.True(limit.Take("a", 3, influence*10, SendSnapshot))
+}
+
 func TestSlidingWindow(t *testing.T) {
 	re := require.New(t)
 	capacity := int64(defaultWindowSize)
@@ -0,0 +1,82 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storelimit
+
+import (
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// SourceRateLimit tracks the tokens taken by each operator source, like a
+// scheduler, from the rate limit of a store, and limits every source with its
+// own quota so that one source cannot use up the store limit and starve others.
+// Like StoreRateLimit, the send snapshot type is not limited.
+type SourceRateLimit struct {
+	mu       syncutil.RWMutex
+	limits   map[string]*StoreRateLimit
+	consumed map[string][]int64
+}
+
+// NewSourceRateLimit creates a SourceRateLimit.
+func NewSourceRateLimit() *SourceRateLimit {
+	return &SourceRateLimit{
+		limits:   make(map[string]*StoreRateLimit),
+		consumed: make(map[string][]int64),
+	}
+}
+
+// Available returns true if the source can take the cost within the quota rate.
+func (l *SourceRateLimit) Available(source string, ratePerSec float64, cost int64, typ Type) bool {
+	return l.getOrCreateLimit(source, ratePerSec, typ).Available(cost, typ, constant.Medium)
+}
+
+// Take takes the cost from the quota of the source and records the consumption.
+func (l *SourceRateLimit) Take(source string, ratePerSec float64, cost int64, typ Type) bool {
+	if !l.getOrCreateLimit(source, ratePerSec, typ).Take(cost, typ, constant.Medium) {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	consumed, exist := l.consumed[source]
+	if !exist {
+		consumed = make([]int64, storeLimitTypeLen)
+		l.consumed[source] = consumed
+	}
+	consumed[typ] += cost
+	return true
+}
+
+// Consumed returns the total tokens taken by the source.
+func (l *SourceRateLimit) Consumed(source string, typ Type) int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if consumed, ok := l.consumed[source]; ok {
+		return consumed[typ]
+	}
+	return 0
+}
+
+func (l *SourceRateLimit) getOrCreateLimit(source string, ratePerSec float64, typ Type) *StoreRateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	limit, ok := l.limits[source]
+	if !ok {
+		limit = NewStoreRateLimit(ratePerSec).(*StoreRateLimit)
+		l.limits[source] = limit
+	} else if limit.Rate(typ) != ratePerSec {
+		limit.Reset(ratePerSec, typ)
+	}
+	return limit
+}
@@ -142,6 +142,8 @@ func (s *Service) RegisterSchedulersRouter() {
 	router.GET("/diagnostic/:name", getDiagnosticResult)
 	router.GET("/config", getSchedulerConfig)
 	router.GET("/config/:name/list", getSchedulerConfigByName)
+	router.GET("/shadow/:name", getSchedulerShadowResults)
+	router.GET("/time-windows", getScheduleTimeWindows)
 	// TODO: in the future, we should split pauseOrResumeScheduler to two different APIs.
 	// And we need to do one-to-two forwarding in the API middleware.
 	router.POST("/:name", pauseOrResumeScheduler)
@@ -173,6 +175,11 @@ func (s *Service) RegisterOperatorsRouter() {
 	router.GET("/:id", getOperatorByRegion)
 	router.DELETE("/:id", deleteOperatorByRegion)
 	router.GET("/records", getOperatorRecords)
+	router.GET("/history", getOperatorHistory)
+	router.GET("/batches", getOperatorBatches)
+	router.POST("/batches", createOperatorBatch)
+	router.GET("/batches/:id", getOperatorBatch)
+	router.DELETE("/batches/:id", deleteOperatorBatch)
 }
 
 // RegisterStoresRouter registers the router of the stores handler.
@@ -187,6 +194,10 @@ func (s *Service) RegisterRegionsRouter() {
 	router := s.root.Group("regions")
 	router.GET("", getAllRegions)
 	router.GET("/:id", getRegionByID)
+	router.GET("/:id/explain", explainRegion)
+	router.GET("/split-advices", getSplitAdvices)
+	router.GET("/repair-progress", getRepairProgress)
+	router.GET("/merge-backlog", getMergeBacklog)
 	router.GET("/count", getRegionCount)
 	router.POST("/accelerate-schedule", accelerateRegionsScheduleInRange)
 	router.POST("/accelerate-schedule/batch", accelerateRegionsScheduleInRanges)
@@ -206,6 +217,7 @@ func (s *Service) RegisterConfigRouter() {
 	rules.GET("/region/:region", getRulesByRegion)
 	rules.GET("/region/:region/detail", checkRegionPlacementRule)
 	rules.GET("/key/:key", getRulesByKey)
+	rules.GET("/compliance", getRuleComplianceReport)
 
 	// We cannot merge `/rule` and `/rules`, because we allow `group_id` to be "group",
 	// which is the same as the prefix of `/rules/group/:group`.
@@ -442,6 +454,29 @@ func getOperatorRecords(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, records)
 }
 
+// @Tags     operator
+// @Summary  List the operators finished since the given time, which are pulled and persisted by PD.
+// @Param    from  query  integer  false  "From Unix timestamp"
+// @Produce  json
+// @Success  200  {array}   storage.HistoryOperator
+// @Failure  400  {string}  string  "The request is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/history [get]
+func getOperatorHistory(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	from, err := apiutil.ParseTime(c.Query("from"))
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	ops, err := handler.GetHistoryOperators(from)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, ops)
+}
+
 // FIXME: details of input json body params
 // @Tags     operator
 // @Summary  Create an operator.
@@ -471,6 +506,90 @@ func createOperator(c *gin.Context) {
 	c.IndentedJSON(statusCode, result)
 }
 
+// @Tags     operator
+// @Summary  List operator batches.
+// @Produce  json
+// @Success  200  {array}   operator.BatchObject
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches [get]
+func getOperatorBatches(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	batches, err := handler.GetOperatorBatches()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, batches)
+}
+
+// @Tags     operator
+// @Summary  Create an operator batch.
+// @Accept   json
+// @Param    body  body  object  true  "json params"
+// @Produce  json
+// @Success  200  {integer}  int     "The id of the created batch."
+// @Failure  400  {string}   string  "The input is invalid."
+// @Router   /operators/batches [post]
+func createOperatorBatch(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	var input map[string]any
+	if err := c.BindJSON(&input); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	id, err := handler.AddOperatorBatch(input)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, id)
+}
+
+// @Tags     operator
+// @Summary  Get an operator batch.
+// @Param    id  path  int  true  "The id of the batch"
+// @Produce  json
+// @Success  200  {object}  operator.BatchObject
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches/{id} [get]
+func getOperatorBatch(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	batch, err := handler.GetOperatorBatch(id)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, batch)
+}
+
+// @Tags     operator
+// @Summary  Cancel an operator batch.
+// @Param    id  path  int  true  "The id of the batch"
+// @Produce  json
+// @Success  200  {string}  string  "The operator batch is canceled."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches/{id} [delete]
+func deleteOperatorBatch(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err = handler.CancelOperatorBatch(id); err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.String(http.StatusOK, "The operator batch is canceled.")
+}
+
 // @Tags     checkers
 // @Summary  Get checker by name
 // @Param    name  path  string  true  "The name of the checker."
@@ -618,6 +737,40 @@ func getDiagnosticResult(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, result)
 }
 
+// @Tags     schedulers
+// @Summary  List the operators recorded by a scheduler in shadow mode.
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Produce  json
+// @Success  200  {array}   schedulers.ShadowResult
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/shadow/{name} [get]
+func getSchedulerShadowResults(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	name := c.Param("name")
+	results, err := handler.GetSchedulerShadowResults(name)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, results)
+}
+
+// @Tags     schedulers
+// @Summary  List the scheduling time windows of all the schedulers and checkers.
+// @Produce  json
+// @Success  200  {object}  map[string][]config.TimeWindow
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/time-windows [get]
+func getScheduleTimeWindows(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	windows, err := handler.GetScheduleTimeWindows()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, windows)
+}
+
 // FIXME: details of input json body params
 // @Tags     scheduler
 // @Summary  Pause or resume a scheduler.
@@ -870,6 +1023,27 @@ func checkRegionPlacementRule(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, regionFit)
 }
 
+// @Tags     rule
+// @Summary  Get the number of the satisfied, under-replicated and orphan peer regions of each rule.
+// @Produce  json
+// @Success  200  {object}  checker.RuleComplianceReport
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/compliance [get]
+func getRuleComplianceReport(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	report, err := handler.GetRuleComplianceReport()
+	if err == errs.ErrPlacementDisabled {
+		c.String(http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, report)
+}
+
 // @Tags     rule
 // @Summary  List all rules of cluster by key.
 // @Param    key  path  string  true  "The name of key"
@@ -1377,6 +1551,78 @@ func checkRegionsReplicated(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, state)
 }
 
+// @Tags     region
+// @Summary  Run all checkers and schedulers against the region, and list the filters, rules and limits which affect it.
+// @Param    id  path  integer  true  "Region Id"
+// @Produce  json
+// @Success  200  {object}  handler.RegionExplanation
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The region does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/{id}/explain [get]
+func explainRegion(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	region, code, err := handler.PreCheckForRegion(c.Param("id"))
+	if err != nil {
+		c.String(code, err.Error())
+		return
+	}
+	explanation, err := handler.ExplainRegion(region)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, explanation)
+}
+
+// @Tags     region
+// @Summary  List the regions whose load is concentrated in a few buckets and the recommended split keys.
+// @Produce  json
+// @Success  200  {array}   checker.SplitAdvice
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/split-advices [get]
+func getSplitAdvices(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	advices, err := handler.GetSplitAdvices()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, advices)
+}
+
+// @Tags     region
+// @Summary  Get the cross location repair budget and the regions waiting for it, grouped by the healthy replica count.
+// @Produce  json
+// @Success  200  {object}  checker.RepairProgress
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/repair-progress [get]
+func getRepairProgress(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	progress, err := handler.GetRepairProgress()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, progress)
+}
+
+// @Tags     region
+// @Summary  Get the pending merges of the long runs of small regions.
+// @Produce  json
+// @Success  200  {object}  checker.MergeBacklog
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/merge-backlog [get]
+func getMergeBacklog(c *gin.Context) {
+	handler := c.MustGet(handlerKey).(*handler.Handler)
+	backlog, err := handler.GetMergeBacklog()
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.IndentedJSON(http.StatusOK, backlog)
+}
+
 // @Tags        store
 // @Summary     Get a store's information.
 // @Param       id path integer true "Store Id"
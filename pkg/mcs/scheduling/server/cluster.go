@@ -474,6 +474,7 @@ func (c *Cluster) HandleStoreHeartbeat(heartbeat *schedulingpb.StoreHeartbeatReq
 			utils.RegionWriteBytes:    0,
 			utils.RegionWriteKeys:     0,
 			utils.RegionWriteQueryNum: 0,
+			utils.RegionReadCPU:       float64(region.GetStoreReadCPU(storeID, interval)),
 		}
 		checkReadPeerTask := func(cache *statistics.HotPeerCache) {
 			stats := cache.CheckPeerFlow(region, []*metapb.Peer{peer}, loads, interval)
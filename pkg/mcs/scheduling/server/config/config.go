@@ -431,6 +431,77 @@ func (o *PersistConfig) IsRemoveExtraReplicaEnabled() bool {
 	return o.GetScheduleConfig().EnableRemoveExtraReplica
 }
 
+// GetOperatorRollbackPolicy returns the policy of rolling back the partially applied operators.
+func (o *PersistConfig) GetOperatorRollbackPolicy() string {
+	return o.GetScheduleConfig().OperatorRollbackPolicy
+}
+
+// GetOperatorPreemptionPolicy returns the policy of preempting the lower priority operators.
+func (o *PersistConfig) GetOperatorPreemptionPolicy() string {
+	return o.GetScheduleConfig().OperatorPreemptionPolicy
+}
+
+// GetLeaderZoneLabel returns the store label key of the zone used by the `leader_zone` label policy.
+func (o *PersistConfig) GetLeaderZoneLabel() string {
+	return o.GetScheduleConfig().LeaderZoneLabel
+}
+
+// GetSchedulerStoreLimitQuota returns the max ratio of the store limit that the scheduler can use.
+// It returns 0 if the scheduler has no quota.
+func (o *PersistConfig) GetSchedulerStoreLimitQuota(name string) float64 {
+	return o.GetScheduleConfig().SchedulerStoreLimitQuota[name]
+}
+
+// IsBucketSplitEnabled returns if the regions can be split at the bucket boundaries by load.
+func (o *PersistConfig) IsBucketSplitEnabled() bool {
+	return o.GetScheduleConfig().EnableBucketSplit
+}
+
+// GetBucketSplitMinLoad returns the min byte rate of a region to be split by buckets.
+func (o *PersistConfig) GetBucketSplitMinLoad() float64 {
+	return o.GetScheduleConfig().BucketSplitMinLoad
+}
+
+// GetBucketSplitHotRatio returns the min ratio of the region load in the hottest buckets to split the region.
+func (o *PersistConfig) GetBucketSplitHotRatio() float64 {
+	return o.GetScheduleConfig().BucketSplitHotRatio
+}
+
+// GetBucketSplitMaxHotBuckets returns the max number of the hottest buckets which the region load is concentrated in.
+func (o *PersistConfig) GetBucketSplitMaxHotBuckets() int {
+	return o.GetScheduleConfig().BucketSplitMaxHotBuckets
+}
+
+// GetCrossLocationRepairSizePerMinute returns the max size of the regions which can be repaired across locations per minute.
+func (o *PersistConfig) GetCrossLocationRepairSizePerMinute() uint64 {
+	return o.GetScheduleConfig().CrossLocationRepairSizePerMinute
+}
+
+// IsRangeMergeEnabled returns if the long runs of small regions can be merged in a tree-shaped sequence.
+func (o *PersistConfig) IsRangeMergeEnabled() bool {
+	return o.GetScheduleConfig().EnableRangeMerge
+}
+
+// GetRangeMergeMinRegions returns the min number of the adjacent small regions to be merged as a run.
+func (o *PersistConfig) GetRangeMergeMinRegions() int {
+	return o.GetScheduleConfig().RangeMergeMinRegions
+}
+
+// GetRangeMergeScheduleLimit returns the max number of the region pairs merged by the range merge at the same time.
+func (o *PersistConfig) GetRangeMergeScheduleLimit() uint64 {
+	return o.GetScheduleConfig().RangeMergeScheduleLimit
+}
+
+// GetTiFlashReplicaSizeRatio returns the ratio of the size of a TiFlash columnar replica to the approximate region size.
+func (o *PersistConfig) GetTiFlashReplicaSizeRatio() float64 {
+	return o.GetScheduleConfig().TiFlashReplicaSizeRatio
+}
+
+// GetTiFlashRegionScheduleLimit returns the limit for the balance region operators on the TiFlash stores.
+func (o *PersistConfig) GetTiFlashRegionScheduleLimit() uint64 {
+	return o.GetScheduleConfig().TiFlashRegionScheduleLimit
+}
+
 // IsWitnessAllowed returns if the witness is allowed.
 func (o *PersistConfig) IsWitnessAllowed() bool {
 	return o.GetScheduleConfig().EnableWitness
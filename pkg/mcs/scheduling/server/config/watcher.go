@@ -53,6 +53,8 @@ type Watcher struct {
 	configWatcher          *etcdutil.LoopWatcher
 	ttlConfigWatcher       *etcdutil.LoopWatcher
 	schedulerConfigWatcher *etcdutil.LoopWatcher
+	timeWindowsWatcher     *etcdutil.LoopWatcher
+	shadowSchedulerWatcher *etcdutil.LoopWatcher
 
 	// Some data, like the global schedule config, should be loaded into `PersistConfig`.
 	*PersistConfig
@@ -101,6 +103,14 @@ func NewWatcher(
 	if err != nil {
 		return nil, err
 	}
+	err = cw.initializeTimeWindowsWatcher()
+	if err != nil {
+		return nil, err
+	}
+	err = cw.initializeShadowSchedulerWatcher()
+	if err != nil {
+		return nil, err
+	}
 	return cw, nil
 }
 
@@ -224,6 +234,70 @@ func (cw *Watcher) initializeSchedulerConfigWatcher() error {
 	return cw.schedulerConfigWatcher.WaitLoad()
 }
 
+func (cw *Watcher) initializeTimeWindowsWatcher() error {
+	putFn := func(kv *mvccpb.KeyValue) error {
+		log.Info("update scheduling time windows", zap.String("value", string(kv.Value)))
+		if err := cw.storage.SaveScheduleTimeWindows(kv.Value); err != nil {
+			log.Warn("failed to save scheduling time windows",
+				zap.String("event-kv-key", string(kv.Key)),
+				zap.Error(err))
+			return err
+		}
+		// Ensure the time windows could be updated as soon as possible.
+		if sc := cw.getSchedulersController(); sc != nil {
+			return sc.GetTimeWindows().Reload()
+		}
+		return nil
+	}
+	deleteFn := func(*mvccpb.KeyValue) error {
+		return nil
+	}
+	cw.timeWindowsWatcher = etcdutil.NewLoopWatcher(
+		cw.ctx, &cw.wg,
+		cw.etcdClient,
+		"scheduling-time-windows-watcher",
+		keypath.ScheduleTimeWindowsPath(),
+		func([]*clientv3.Event) error { return nil },
+		putFn, deleteFn,
+		func([]*clientv3.Event) error { return nil },
+		false, /* withPrefix */
+	)
+	cw.timeWindowsWatcher.StartWatchLoop()
+	return cw.timeWindowsWatcher.WaitLoad()
+}
+
+func (cw *Watcher) initializeShadowSchedulerWatcher() error {
+	putFn := func(kv *mvccpb.KeyValue) error {
+		log.Info("update shadow schedulers", zap.String("value", string(kv.Value)))
+		if err := cw.storage.SaveShadowSchedulers(kv.Value); err != nil {
+			log.Warn("failed to save shadow schedulers",
+				zap.String("event-kv-key", string(kv.Key)),
+				zap.Error(err))
+			return err
+		}
+		// Ensure the shadow mode could be updated as soon as possible.
+		if sc := cw.getSchedulersController(); sc != nil {
+			return sc.ReloadShadowSchedulers()
+		}
+		return nil
+	}
+	deleteFn := func(*mvccpb.KeyValue) error {
+		return nil
+	}
+	cw.shadowSchedulerWatcher = etcdutil.NewLoopWatcher(
+		cw.ctx, &cw.wg,
+		cw.etcdClient,
+		"scheduling-shadow-scheduler-watcher",
+		keypath.ShadowSchedulersPath(),
+		func([]*clientv3.Event) error { return nil },
+		putFn, deleteFn,
+		func([]*clientv3.Event) error { return nil },
+		false, /* withPrefix */
+	)
+	cw.shadowSchedulerWatcher.StartWatchLoop()
+	return cw.shadowSchedulerWatcher.WaitLoad()
+}
+
 // Close closes the watcher.
 func (cw *Watcher) Close() {
 	cw.cancel()
@@ -505,6 +505,14 @@ func (s *Server) startCluster(context.Context) error {
 	}
 	// Inject the cluster components into the config watcher after the scheduler controller is created.
 	s.configWatcher.SetSchedulersController(s.cluster.GetCoordinator().GetSchedulersController())
+	// The time windows are loaded by the watcher before the scheduler controller is created,
+	// so they should be loaded into the controller explicitly.
+	if err = s.cluster.GetCoordinator().GetSchedulersController().GetTimeWindows().Reload(); err != nil {
+		log.Error("failed to load the scheduling time windows", errs.ZapError(err))
+	}
+	if err = s.cluster.GetCoordinator().GetSchedulersController().ReloadShadowSchedulers(); err != nil {
+		log.Error("failed to load the shadow schedulers", errs.ZapError(err))
+	}
 	// Start the rule watcher after the cluster is created.
 	err = s.startRuleWatcher()
 	if err != nil {
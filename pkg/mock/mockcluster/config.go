@@ -32,6 +32,36 @@ func (mc *Cluster) SetMaxMergeRegionKeys(v int) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.MaxMergeRegionKeys = uint64(v) })
 }
 
+// SetCrossLocationRepairSizePerMinute updates the CrossLocationRepairSizePerMinute configuration.
+func (mc *Cluster) SetCrossLocationRepairSizePerMinute(v uint64) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.CrossLocationRepairSizePerMinute = v })
+}
+
+// SetEnableRangeMerge updates the EnableRangeMerge configuration.
+func (mc *Cluster) SetEnableRangeMerge(v bool) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.EnableRangeMerge = v })
+}
+
+// SetRangeMergeMinRegions updates the RangeMergeMinRegions configuration.
+func (mc *Cluster) SetRangeMergeMinRegions(v int) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.RangeMergeMinRegions = v })
+}
+
+// SetRangeMergeScheduleLimit updates the RangeMergeScheduleLimit configuration.
+func (mc *Cluster) SetRangeMergeScheduleLimit(v int) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.RangeMergeScheduleLimit = uint64(v) })
+}
+
+// SetTiFlashReplicaSizeRatio updates the TiFlashReplicaSizeRatio configuration.
+func (mc *Cluster) SetTiFlashReplicaSizeRatio(v float64) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.TiFlashReplicaSizeRatio = v })
+}
+
+// SetTiFlashRegionScheduleLimit updates the TiFlashRegionScheduleLimit configuration.
+func (mc *Cluster) SetTiFlashRegionScheduleLimit(v int) {
+	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.TiFlashRegionScheduleLimit = uint64(v) })
+}
+
 // SetSplitMergeInterval updates the SplitMergeInterval configuration.
 func (mc *Cluster) SetSplitMergeInterval(v time.Duration) {
 	mc.updateScheduleConfig(func(s *sc.ScheduleConfig) { s.SplitMergeInterval = typeutil.NewDuration(v) })
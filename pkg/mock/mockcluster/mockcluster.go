@@ -533,6 +533,32 @@ func (mc *Cluster) AddRegionLeaderWithReadInfo(
 	return items
 }
 
+// AddRegionLeaderWithReadCPU add region leader read cpu usage, which is only reported by the leader.
+func (mc *Cluster) AddRegionLeaderWithReadCPU(
+	regionID uint64, leaderStoreID uint64,
+	readBytes, cpuUsage uint64,
+	reportInterval uint64,
+	otherPeerStoreIDs []uint64, filledNums ...int) []*statistics.HotPeerStat {
+	r := mc.newMockRegionInfo(regionID, leaderStoreID, otherPeerStoreIDs...)
+	r = r.Clone(core.SetReadBytes(readBytes))
+	r = r.Clone(core.SetCPUUsage(cpuUsage))
+	r = r.Clone(core.SetReportInterval(0, reportInterval))
+	filledNum := utils.DefaultAotSize
+	if len(filledNums) > 0 {
+		filledNum = filledNums[0]
+	}
+
+	var items []*statistics.HotPeerStat
+	for range filledNum {
+		items = mc.CheckRegionLeaderRead(r)
+		for _, item := range items {
+			mc.Update(item, utils.Read)
+		}
+	}
+	mc.PutRegion(r)
+	return items
+}
+
 // AddLeaderRegionWithWriteInfo adds region with specified leader and peers write info.
 func (mc *Cluster) AddLeaderRegionWithWriteInfo(
 	regionID uint64, leaderStoreID uint64,
@@ -728,6 +754,13 @@ func (mc *Cluster) UpdateStorageWriteQuery(storeID uint64, queryWrite uint64) {
 	})
 }
 
+// UpdateStorageCPUUsage updates store cpu usage.
+func (mc *Cluster) UpdateStorageCPUUsage(storeID uint64, cpuUsage uint64) {
+	mc.updateStorageStatistics(storeID, func(newStats *pdpb.StoreStats) {
+		newStats.CpuUsages = []*pdpb.RecordPair{{Key: "raftstore", Value: cpuUsage}}
+	})
+}
+
 func (mc *Cluster) updateStorageStatistics(storeID uint64, update func(*pdpb.StoreStats)) {
 	store := mc.GetStore(storeID)
 	newStats := typeutil.DeepClone(store.GetStoreStats(), core.StoreStatsFactory)
@@ -33,6 +33,7 @@ const (
 
 	removingAction  Action = "removing"
 	preparingAction Action = "preparing"
+	repairingAction Action = "repairing"
 )
 
 type patrolRegionsDurationGetter interface {
@@ -187,6 +188,12 @@ func (m *Manager) UpdateProgress(
 		action = removingAction
 		m.RLock()
 		p, exist := m.progresses[storeID]
+		if exist && p.Action == repairingAction {
+			// The down store is being removed, its regions are still being repaired,
+			// so the repairing progress continues until the store is removed or back.
+			m.RUnlock()
+			return
+		}
 		if exist && p.Action == removingAction {
 			// currentRegionSize represents the current deleted region size.
 			currentRegionSize = math.Max(0, p.targetRegionSize-currentRegionSize)
@@ -204,6 +211,48 @@ func (m *Manager) UpdateProgress(
 	m.updateStoreProgress(storeID, action, currentRegionSize, targetRegionSize)
 }
 
+// UpdateRepairProgress updates the progress of repairing the regions on the store
+// which is down for longer than maxStoreDownTime. The progress is finished once
+// the store is not down anymore or it has been removed, and it continues if the
+// down store is being removed.
+func (m *Manager) UpdateRepairProgress(
+	store *core.StoreInfo,
+	currentRegionSize float64,
+	maxStoreDownTime time.Duration,
+) {
+	if m == nil || store == nil {
+		return
+	}
+	storeID := store.GetID()
+	isDown := !store.IsRemoved() && !store.IsPreparing() && store.DownTime() >= maxStoreDownTime
+	current := m.GetProgressByStoreID(storeID)
+	if !isDown {
+		if current != nil && current.Action == repairingAction {
+			m.markProgressAsFinished(storeID)
+		}
+		return
+	}
+	// The store was being removed before it was down, keep the removing progress.
+	if current != nil && current.Action == removingAction {
+		return
+	}
+	// targetRegionSize represents the total region size that need to be repaired.
+	// It is set in the first time when the store is down.
+	var targetRegionSize float64
+	m.RLock()
+	p, exist := m.progresses[storeID]
+	if exist && p.Action == repairingAction {
+		// currentRegionSize represents the current repaired region size.
+		currentRegionSize = math.Max(0, p.targetRegionSize-currentRegionSize)
+	} else {
+		targetRegionSize = currentRegionSize
+		currentRegionSize = 0
+	}
+	m.RUnlock()
+
+	m.updateStoreProgress(storeID, repairingAction, currentRegionSize, targetRegionSize)
+}
+
 func (m *Manager) updateStoreProgress(
 	storeID uint64,
 	action Action,
@@ -222,7 +271,7 @@ func (m *Manager) updateStoreProgress(
 	if p.targetRegionSize < targetRegionSize {
 		p.targetRegionSize = targetRegionSize
 	}
-	if action == removingAction {
+	if action == removingAction || action == repairingAction {
 		// If the number of regions is large, each round of PatrolRegion takes a
 		// long time. The regions of the offline store may have not been scanned
 		// during some updateInterval. In this case, if the window is not large
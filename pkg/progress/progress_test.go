@@ -197,6 +197,78 @@ func TestOnlineAndOffline(t *testing.T) {
 	re.Equal(90.0, p.CurrentSpeed)
 }
 
+func TestRepair(t *testing.T) {
+	var (
+		re               = require.New(t)
+		updateInterval   = time.Second
+		maxStoreDownTime = 30 * time.Minute
+		m                = NewManager(&mockPatrolRegionsDurationGetter{10 * time.Second}, updateInterval)
+	)
+	store := core.NewStoreInfo(&metapb.Store{
+		Id:        1,
+		NodeState: metapb.NodeState_Serving,
+	}, core.SetLastHeartbeatTS(time.Now()))
+	m.UpdateRepairProgress(store, 100, maxStoreDownTime)
+	re.Nil(m.GetProgressByStoreID(store.GetID()))
+
+	// the store is down
+	store = store.Clone(core.SetLastHeartbeatTS(time.Now().Add(-time.Hour)))
+	m.UpdateRepairProgress(store, 100, maxStoreDownTime)
+	p := m.GetProgressByStoreID(store.GetID())
+	re.Equal(repairingAction, p.Action)
+	re.Equal(0.0, p.ProgressPercent)
+	re.Equal(math.MaxFloat64, p.LeftSecond)
+
+	m.UpdateRepairProgress(store, 80, maxStoreDownTime)
+	p = m.GetProgressByStoreID(store.GetID())
+	re.Equal(repairingAction, p.Action)
+	re.Equal(0.2, p.ProgressPercent)
+	re.Equal(4*updateInterval.Seconds(), p.LeftSecond)
+	re.Equal(20.0, p.CurrentSpeed)
+	re.Equal(p, m.GetAverageProgressByAction(repairingAction))
+
+	// the store is back
+	store = store.Clone(core.SetLastHeartbeatTS(time.Now()))
+	m.UpdateRepairProgress(store, 80, maxStoreDownTime)
+	re.Nil(m.GetProgressByStoreID(store.GetID()))
+	p = m.completedProgress[store.GetID()].Progress
+	re.Equal(repairingAction, p.Action)
+	re.Equal(1.0, p.ProgressPercent)
+
+	// the down store is being removed, the repairing progress continues.
+	store = store.Clone(core.SetLastHeartbeatTS(time.Now().Add(-time.Hour)))
+	m.UpdateProgress(store, 100, 0)
+	m.UpdateRepairProgress(store, 100, maxStoreDownTime)
+	store = store.Clone(core.SetStoreState(metapb.StoreState_Offline, false))
+	re.True(store.IsRemoving())
+	m.UpdateProgress(store, 60, 0)
+	m.UpdateRepairProgress(store, 60, maxStoreDownTime)
+	p = m.GetProgressByStoreID(store.GetID())
+	re.Equal(repairingAction, p.Action)
+	re.Equal(0.4, p.ProgressPercent)
+
+	// the store is removed
+	store = store.Clone(core.SetStoreState(metapb.StoreState_Tombstone))
+	m.UpdateProgress(store, 0, 0)
+	m.UpdateRepairProgress(store, 0, maxStoreDownTime)
+	re.Nil(m.GetProgressByStoreID(store.GetID()))
+	p = m.completedProgress[store.GetID()].Progress
+	re.Equal(repairingAction, p.Action)
+	re.Equal(1.0, p.ProgressPercent)
+
+	// the store was being removed before it was down, the removing progress is kept.
+	store = core.NewStoreInfo(&metapb.Store{
+		Id:        2,
+		NodeState: metapb.NodeState_Removing,
+	}, core.SetLastHeartbeatTS(time.Now()))
+	m.UpdateProgress(store, 100, 0)
+	m.UpdateRepairProgress(store, 100, maxStoreDownTime)
+	store = store.Clone(core.SetLastHeartbeatTS(time.Now().Add(-time.Hour)))
+	m.UpdateProgress(store, 80, 0)
+	m.UpdateRepairProgress(store, 80, maxStoreDownTime)
+	re.Equal(removingAction, m.GetProgressByStoreID(store.GetID()).Action)
+}
+
 func TestDynamicPatrolRegionsDuration(t *testing.T) {
 	var (
 		re = require.New(t)
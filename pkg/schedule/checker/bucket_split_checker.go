@@ -0,0 +1,222 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/pdpb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/cache"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/config"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+// splitAdviceTTL is the time to keep a split advice if the region is not checked again.
+const splitAdviceTTL = 10 * time.Minute
+
+// SplitAdvice is the recommended load-based split of a region at the bucket boundaries.
+type SplitAdvice struct {
+	RegionID  uint64   `json:"region_id"`
+	StartKey  string   `json:"start_key"`
+	EndKey    string   `json:"end_key"`
+	SplitKeys []string `json:"split_keys"`
+	// Load is the read and write byte rate of the region.
+	Load float64 `json:"load"`
+	// HotRatio is the ratio of the region load in the hottest buckets.
+	HotRatio    float64   `json:"hot_ratio"`
+	HotBuckets  int       `json:"hot_buckets"`
+	BucketCount int       `json:"bucket_count"`
+	UpdateTime  time.Time `json:"update_time"`
+}
+
+// BucketSplitChecker splits the regions whose load is concentrated in a few
+// buckets at the bucket boundaries, so that the hot key ranges can be scheduled
+// separately.
+type BucketSplitChecker struct {
+	PauseController
+	cluster sche.CheckerCluster
+	conf    config.CheckerConfigProvider
+	advices *cache.TTLUint64
+}
+
+// NewBucketSplitChecker creates a bucket split checker.
+func NewBucketSplitChecker(ctx context.Context, cluster sche.CheckerCluster, conf config.CheckerConfigProvider) *BucketSplitChecker {
+	return &BucketSplitChecker{
+		cluster: cluster,
+		conf:    conf,
+		advices: cache.NewIDTTL(ctx, gcInterval, splitAdviceTTL),
+	}
+}
+
+// GetType returns the checker type.
+func (*BucketSplitChecker) GetType() types.CheckerSchedulerType {
+	return types.BucketSplitChecker
+}
+
+// Check checks whether the region load is concentrated in a few buckets and
+// returns the operator to split the region at the bucket boundaries.
+func (c *BucketSplitChecker) Check(region *core.RegionInfo) *operator.Operator {
+	bucketSplitCheckerCounter.Inc()
+
+	if c.IsPaused() {
+		bucketSplitCheckerPausedCounter.Inc()
+		return nil
+	}
+	if !c.conf.IsBucketSplitEnabled() || region.GetBuckets() == nil {
+		return nil
+	}
+
+	stats := c.cluster.BucketsStats(math.MinInt, region.GetID())[region.GetID()]
+	advice, keys := c.adviseSplit(region, stats)
+	if advice == nil {
+		c.advices.Remove(region.GetID())
+		return nil
+	}
+	c.advices.Put(region.GetID(), advice)
+
+	op, err := operator.CreateSplitRegionOperator("bucket-split-region", region, 0, pdpb.CheckPolicy_USEKEY, keys)
+	if err != nil {
+		bucketSplitCheckerFailedCounter.Inc()
+		log.Debug("create bucket split region operator failed", errs.ZapError(err))
+		return nil
+	}
+	bucketSplitCheckerNewOpCounter.Inc()
+	return op
+}
+
+// adviseSplit returns the split advice and the split keys of the region. It
+// returns nil if the region load is not concentrated in the hottest buckets.
+func (c *BucketSplitChecker) adviseSplit(region *core.RegionInfo, stats []*buckets.BucketStat) (*SplitAdvice, [][]byte) {
+	// Only the buckets in the current key range of the region are used, the
+	// stats may be reported before the region is split or merged.
+	startKey, endKey := region.GetStartKey(), region.GetEndKey()
+	inRange := make([]*buckets.BucketStat, 0, len(stats))
+	for _, stat := range stats {
+		if bytes.Compare(stat.StartKey, startKey) < 0 ||
+			(len(endKey) > 0 && (len(stat.EndKey) == 0 || bytes.Compare(stat.EndKey, endKey) > 0)) {
+			continue
+		}
+		inRange = append(inRange, stat)
+	}
+	maxHotBuckets := c.conf.GetBucketSplitMaxHotBuckets()
+	if len(inRange) <= maxHotBuckets {
+		bucketSplitCheckerFewBucketsCounter.Inc()
+		return nil, nil
+	}
+
+	var total float64
+	for _, stat := range inRange {
+		total += bucketLoad(stat)
+	}
+	if total == 0 || total < c.conf.GetBucketSplitMinLoad() {
+		bucketSplitCheckerColdCounter.Inc()
+		return nil, nil
+	}
+	hottest := make([]*buckets.BucketStat, len(inRange))
+	copy(hottest, inRange)
+	sort.SliceStable(hottest, func(i, j int) bool {
+		return bucketLoad(hottest[i]) > bucketLoad(hottest[j])
+	})
+	hottest = hottest[:maxHotBuckets]
+	var hot float64
+	for _, stat := range hottest {
+		hot += bucketLoad(stat)
+	}
+	if hot/total < c.conf.GetBucketSplitHotRatio() {
+		bucketSplitCheckerNotConcentratedCounter.Inc()
+		return nil, nil
+	}
+
+	// Split at the both sides of the hottest buckets, the adjacent hot buckets
+	// are kept in the same region.
+	var keys [][]byte
+	for _, stat := range hottest {
+		for _, key := range [][]byte{stat.StartKey, stat.EndKey} {
+			if len(key) == 0 || bytes.Equal(key, startKey) || bytes.Equal(key, endKey) {
+				continue
+			}
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	keys = dedupKeys(keys)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	splitKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		splitKeys = append(splitKeys, core.HexRegionKeyStr(key))
+	}
+	return &SplitAdvice{
+		RegionID:    region.GetID(),
+		StartKey:    core.HexRegionKeyStr(startKey),
+		EndKey:      core.HexRegionKeyStr(endKey),
+		SplitKeys:   splitKeys,
+		Load:        total,
+		HotRatio:    hot / total,
+		HotBuckets:  len(hottest),
+		BucketCount: len(inRange),
+		UpdateTime:  time.Now(),
+	}, keys
+}
+
+// GetSplitAdvices returns the latest split advices sorted by the region load.
+func (c *BucketSplitChecker) GetSplitAdvices() []*SplitAdvice {
+	advices := make([]*SplitAdvice, 0)
+	for _, id := range c.advices.GetAllID() {
+		if v, ok := c.advices.Get(id); ok {
+			advices = append(advices, v.(*SplitAdvice))
+		}
+	}
+	sort.Slice(advices, func(i, j int) bool {
+		if advices[i].Load != advices[j].Load {
+			return advices[i].Load > advices[j].Load
+		}
+		return advices[i].RegionID < advices[j].RegionID
+	})
+	return advices
+}
+
+func bucketLoad(stat *buckets.BucketStat) float64 {
+	var load float64
+	for _, kind := range []utils.RegionStatKind{utils.RegionReadBytes, utils.RegionWriteBytes} {
+		if int(kind) < len(stat.Loads) {
+			load += float64(stat.Loads[kind])
+		}
+	}
+	return load
+}
+
+func dedupKeys(keys [][]byte) [][]byte {
+	res := keys[:0]
+	for i, key := range keys {
+		if i == 0 || !bytes.Equal(key, keys[i-1]) {
+			res = append(res, key)
+		}
+	}
+	return res
+}
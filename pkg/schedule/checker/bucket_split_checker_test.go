@@ -0,0 +1,105 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/statistics/buckets"
+)
+
+func TestBucketSplit(t *testing.T) {
+	re := require.New(t)
+	cfg := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster := mockcluster.NewCluster(ctx, cfg)
+	cluster.AddLeaderStore(1, 1)
+	cluster.AddLeaderRegionWithRange(1, "a", "f", 1)
+	bc := NewBucketSplitChecker(ctx, cluster, cfg)
+
+	putBuckets := func(readBytes ...uint64) *core.RegionInfo {
+		b := &metapb.Buckets{
+			RegionId:   1,
+			Version:    uint64(time.Now().UnixNano()),
+			PeriodInMs: 1000,
+			Keys:       [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"), []byte("f")},
+			Stats: &metapb.BucketStats{
+				ReadBytes:  readBytes,
+				ReadKeys:   make([]uint64, len(readBytes)),
+				ReadQps:    make([]uint64, len(readBytes)),
+				WriteBytes: make([]uint64, len(readBytes)),
+				WriteKeys:  make([]uint64, len(readBytes)),
+				WriteQps:   make([]uint64, len(readBytes)),
+			},
+		}
+		re.True(cluster.CheckAsync(buckets.NewCheckPeerTask(b)))
+		region := cluster.GetRegion(1).Clone(core.SetBuckets(b))
+		cluster.PutRegion(region)
+		return region
+	}
+
+	// The load of region is concentrated in the bucket [c, d).
+	region := putBuckets(10*units.KiB, 10*units.KiB, 10*units.MiB, 10*units.KiB, 10*units.KiB)
+	re.Nil(bc.Check(region))
+	scheduleCfg := cfg.GetScheduleConfig().Clone()
+	scheduleCfg.EnableBucketSplit = true
+	scheduleCfg.BucketSplitMaxHotBuckets = 1
+	cfg.SetScheduleConfig(scheduleCfg)
+	op := bc.Check(region)
+	re.NotNil(op)
+	re.Equal(operator.OpSplit, op.Kind())
+	re.Equal([][]byte{[]byte("c"), []byte("d")}, op.Step(0).(operator.SplitRegion).SplitKeys)
+	advices := bc.GetSplitAdvices()
+	re.Len(advices, 1)
+	re.Equal(uint64(1), advices[0].RegionID)
+	re.Equal([]string{core.HexRegionKeyStr([]byte("c")), core.HexRegionKeyStr([]byte("d"))}, advices[0].SplitKeys)
+	re.Equal(5, advices[0].BucketCount)
+	re.Greater(advices[0].HotRatio, 0.99)
+
+	// The region is not hot enough.
+	scheduleCfg = cfg.GetScheduleConfig().Clone()
+	scheduleCfg.BucketSplitMinLoad = 100 * units.MiB
+	cfg.SetScheduleConfig(scheduleCfg)
+	re.Nil(bc.Check(region))
+	re.Empty(bc.GetSplitAdvices())
+
+	// The load is not concentrated.
+	scheduleCfg = cfg.GetScheduleConfig().Clone()
+	scheduleCfg.BucketSplitMinLoad = units.MiB
+	cfg.SetScheduleConfig(scheduleCfg)
+	region = putBuckets(10*units.MiB, 10*units.MiB, 10*units.MiB, 10*units.MiB, 10*units.MiB)
+	re.Nil(bc.Check(region))
+
+	// The hottest bucket is at the start of the region.
+	region = putBuckets(10*units.MiB, 10*units.KiB, 10*units.KiB, 10*units.KiB, 10*units.KiB)
+	op = bc.Check(region)
+	re.NotNil(op)
+	re.Equal([][]byte{[]byte("b")}, op.Step(0).(operator.SplitRegion).SplitKeys)
+
+	bc.PauseOrResume(60)
+	re.Nil(bc.Check(region))
+}
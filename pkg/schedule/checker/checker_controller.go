@@ -35,6 +35,7 @@ import (
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/utils/keyutil"
 	"github.com/tikv/pd/pkg/utils/logutil"
 )
@@ -59,6 +60,7 @@ var (
 	// WithLabelValues is a heavy operation, define variable to avoid call it every time.
 	pendingProcessedRegionsGauge = regionListGauge.WithLabelValues("pending_processed_regions")
 	priorityListGauge            = regionListGauge.WithLabelValues("priority_list")
+	ruleChangedRegionsGauge      = regionListGauge.WithLabelValues("rule_changed_regions")
 	denyCheckersByLabelerCounter = labeler.LabelerEventCounter.WithLabelValues("checkers", "deny")
 )
 
@@ -72,12 +74,22 @@ type Controller struct {
 	replicaChecker          *ReplicaChecker
 	ruleChecker             *RuleChecker
 	splitChecker            *SplitChecker
+	bucketSplitChecker      *BucketSplitChecker
 	mergeChecker            *MergeChecker
+	rangeMergePlanner       *RangeMergePlanner
 	jointStateChecker       *JointStateChecker
 	priorityInspector       *PriorityInspector
+	ruleManager             *placement.RuleManager
+	labeler                 *labeler.RegionLabeler
 	pendingProcessedRegions *cache.TTLUint64
 	suspectKeyRanges        *cache.TTLString // suspect key-range regions that may need fix
+	ruleChanges             *ruleChangeQueue // regions affected by the rule changes
 	patrolRegionContext     *PatrolRegionContext
+	// timeWindows restricts the checkers to run only in their scheduling time windows.
+	timeWindows *config.TimeWindows
+	// explainOnce creates the explainers lazily, which are only used to explain the regions.
+	explainOnce sync.Once
+	explainers  *explainers
 
 	// duration is the duration of the last patrol round.
 	// It's exported, so it should be protected by a mutex.
@@ -95,8 +107,9 @@ type Controller struct {
 }
 
 // NewController create a new Controller.
-func NewController(ctx context.Context, cluster sche.CheckerCluster, conf config.CheckerConfigProvider, ruleManager *placement.RuleManager, labeler *labeler.RegionLabeler, opController *operator.Controller) *Controller {
+func NewController(ctx context.Context, cluster sche.CheckerCluster, conf config.CheckerConfigProvider, ruleManager *placement.RuleManager, labeler *labeler.RegionLabeler, opController *operator.Controller, timeWindows *config.TimeWindows) *Controller {
 	pendingProcessedRegions := cache.NewIDTTL(ctx, time.Minute, 3*time.Minute)
+	mergeChecker := NewMergeChecker(ctx, cluster, conf)
 	c := &Controller{
 		ctx:                     ctx,
 		cluster:                 cluster,
@@ -104,18 +117,27 @@ func NewController(ctx context.Context, cluster sche.CheckerCluster, conf config
 		opController:            opController,
 		learnerChecker:          NewLearnerChecker(cluster),
 		replicaChecker:          NewReplicaChecker(cluster, conf, pendingProcessedRegions),
-		ruleChecker:             NewRuleChecker(ctx, cluster, ruleManager, pendingProcessedRegions),
+		ruleChecker:             NewRuleChecker(ctx, cluster, ruleManager, labeler, pendingProcessedRegions),
 		splitChecker:            NewSplitChecker(cluster, ruleManager, labeler),
-		mergeChecker:            NewMergeChecker(ctx, cluster, conf),
+		bucketSplitChecker:      NewBucketSplitChecker(ctx, cluster, conf),
+		mergeChecker:            mergeChecker,
+		rangeMergePlanner:       NewRangeMergePlanner(ctx, cluster, conf, mergeChecker, opController),
 		jointStateChecker:       NewJointStateChecker(cluster),
 		priorityInspector:       NewPriorityInspector(cluster, conf),
+		ruleManager:             ruleManager,
+		labeler:                 labeler,
 		pendingProcessedRegions: pendingProcessedRegions,
 		suspectKeyRanges:        cache.NewStringTTL(ctx, time.Minute, 3*time.Minute),
+		ruleChanges:             newRuleChangeQueue(),
 		patrolRegionContext:     &PatrolRegionContext{},
+		timeWindows:             timeWindows,
 		interval:                cluster.GetCheckerConfig().GetPatrolRegionInterval(),
 		patrolRegionScanLimit:   calculateScanLimit(cluster),
 	}
 	c.duration.Store(time.Duration(0))
+	if ruleManager != nil {
+		ruleManager.SetRuleChangeListener(c.OnRuleChange)
+	}
 	return c
 }
 
@@ -150,6 +172,10 @@ func (c *Controller) PatrolRegions() {
 				continue
 			}
 
+			// Roll back the partially applied operators first.
+			c.checkRollbackOperators()
+			// Check the regions affected by the rule changes first.
+			c.checkRuleChangedRegions()
 			// Check priority regions first.
 			c.checkPriorityRegions()
 			// Check pending processed regions first.
@@ -166,6 +192,9 @@ func (c *Controller) PatrolRegions() {
 				// update the scan limit.
 				c.patrolRegionScanLimit = calculateScanLimit(c.cluster)
 				// update the metrics.
+				if c.conf.IsPlacementRulesEnabled() {
+					c.ruleChecker.updateComplianceMetrics()
+				}
 				dur := time.Since(start)
 				patrolCheckRegionsGauge.Set(dur.Seconds())
 				c.setPatrolRegionsDuration(dur)
@@ -244,6 +273,33 @@ func (c *Controller) checkPendingProcessedRegions() {
 	}
 }
 
+// checkRollbackOperators creates the operators to revert the partially
+// applied operators which are timeout or canceled.
+func (c *Controller) checkRollbackOperators() {
+	for _, origin := range c.opController.TakeRollbackOperators() {
+		region := c.cluster.GetRegion(origin.RegionID())
+		if region == nil {
+			operator.RecordRollback(origin, "no-region")
+			continue
+		}
+		op, err := operator.CreateRollbackOperator(c.cluster, region, origin)
+		if err != nil {
+			log.Debug("fail to create rollback operator", zap.Uint64("region-id", origin.RegionID()), errs.ZapError(err))
+			operator.RecordRollback(origin, "create-failed")
+			continue
+		}
+		if c.opController.AddWaitingOperator(op) > 0 {
+			log.Info("roll back the partially applied operator",
+				zap.Uint64("region-id", origin.RegionID()),
+				zap.String("reason", origin.GetCancelReason()),
+				zap.Stringer("operator", origin))
+			operator.RecordRollback(origin, "create")
+		} else {
+			operator.RecordRollback(origin, "add-failed")
+		}
+	}
+}
+
 // checkPriorityRegions checks priority regions
 func (c *Controller) checkPriorityRegions() {
 	items := c.GetPriorityRegions()
@@ -260,8 +316,8 @@ func (c *Controller) checkPriorityRegions() {
 		if len(ops) == 0 || ops[0].Kind()&operator.OpMerge != 0 {
 			continue
 		}
-		if !c.opController.ExceedStoreLimit(ops...) {
-			c.opController.AddWaitingOperator(ops...)
+		if !c.opController.ExceedStoreLimit(ops...) || c.opController.CanPreempt(ops...) {
+			c.addOperators(ops...)
 		}
 	}
 	for _, v := range removes {
@@ -269,6 +325,58 @@ func (c *Controller) checkPriorityRegions() {
 	}
 }
 
+// OnRuleChange records the key ranges affected by the rule changes, the regions
+// in these ranges will be checked before the other regions.
+func (c *Controller) OnRuleChange(ranges []*keyutil.KeyRange) {
+	c.ruleChanges.push(ranges, time.Now())
+}
+
+// checkRuleChangedRegions checks the regions affected by the rule changes, and
+// records the latency until they comply with the new rules.
+func (c *Controller) checkRuleChangedRegions() {
+	if !c.conf.IsPlacementRulesEnabled() {
+		return
+	}
+	c.ruleChanges.fill(c.cluster)
+	defer func() {
+		ruleChangedRegionsGauge.Set(float64(c.ruleChanges.len()))
+	}()
+	ids, changeTimes := c.ruleChanges.pick(ruleChangedCheckBatch)
+	for i, id := range ids {
+		region := c.cluster.GetRegion(id)
+		if region == nil {
+			c.ruleChanges.remove(id)
+			continue
+		}
+		if time.Since(changeTimes[i]) > ruleChangedRegionTTL {
+			ruleChangedRegionExpiredCounter.Inc()
+			c.ruleChanges.remove(id)
+			continue
+		}
+		// The region is being scheduled, check it again in the next round.
+		if c.opController.GetOperator(id) != nil {
+			continue
+		}
+		if c.ruleChecker.ruleManager.FitRegion(c.cluster, region).IsSatisfied() {
+			ruleChangeComplianceDuration.Observe(time.Since(changeTimes[i]).Seconds())
+			c.ruleChanges.remove(id)
+			continue
+		}
+		ops := c.CheckRegion(region)
+		if len(ops) == 0 {
+			continue
+		}
+		if !c.opController.ExceedStoreLimit(ops...) || c.opController.CanPreempt(ops...) {
+			c.addOperators(ops...)
+		}
+	}
+}
+
+// GetRuleChangedRegionCount returns the number of the queued regions affected by the rule changes.
+func (c *Controller) GetRuleChangedRegionCount() int {
+	return c.ruleChanges.len()
+}
+
 // CheckRegion will check the region and add a new operator if needed.
 // The function is exposed for test purpose.
 func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
@@ -276,12 +384,17 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 	// Don't check isRaftLearnerEnabled cause it maybe disable learner feature but there are still some learners to promote.
 	opController := c.opController
 
-	if op := c.jointStateChecker.Check(region); op != nil {
-		return []*operator.Operator{op}
+	now := time.Now()
+	if c.isInTimeWindow(types.JointStateChecker, now) {
+		if op := c.jointStateChecker.Check(region); op != nil {
+			return []*operator.Operator{op}
+		}
 	}
 
-	if op := c.splitChecker.Check(region); op != nil {
-		return []*operator.Operator{op}
+	if c.isInTimeWindow(types.SplitChecker, now) {
+		if op := c.splitChecker.Check(region); op != nil {
+			return []*operator.Operator{op}
+		}
 	}
 
 	if c.conf.IsPlacementRulesEnabled() {
@@ -293,7 +406,7 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 				panic("cached shouldn't be used")
 			})
 			ruleCheckerGetCacheCounter.Inc()
-		} else {
+		} else if c.isInTimeWindow(types.RuleChecker, now) {
 			failpoint.Inject("assertShouldCache", func() {
 				panic("cached should be used")
 			})
@@ -307,15 +420,19 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 			}
 		}
 	} else {
-		if op := c.learnerChecker.Check(region); op != nil {
-			return []*operator.Operator{op}
-		}
-		if op := c.replicaChecker.Check(region); op != nil {
-			if opController.OperatorCount(operator.OpReplica) < c.conf.GetReplicaScheduleLimit() {
+		if c.isInTimeWindow(types.LearnerChecker, now) {
+			if op := c.learnerChecker.Check(region); op != nil {
 				return []*operator.Operator{op}
 			}
-			operator.IncOperatorLimitCounter(c.replicaChecker.GetType(), operator.OpReplica)
-			c.pendingProcessedRegions.Put(region.GetID(), nil)
+		}
+		if c.isInTimeWindow(types.ReplicaChecker, now) {
+			if op := c.replicaChecker.Check(region); op != nil {
+				if opController.OperatorCount(operator.OpReplica) < c.conf.GetReplicaScheduleLimit() {
+					return []*operator.Operator{op}
+				}
+				operator.IncOperatorLimitCounter(c.replicaChecker.GetType(), operator.OpReplica)
+				c.pendingProcessedRegions.Put(region.GetID(), nil)
+			}
 		}
 	}
 	// skip the joint checker, split checker and rule checker when region label is set to "schedule=deny".
@@ -328,10 +445,18 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 		}
 	}
 
-	if c.mergeChecker != nil {
+	if c.isInTimeWindow(types.BucketSplitChecker, now) {
+		if op := c.bucketSplitChecker.Check(region); op != nil {
+			return []*operator.Operator{op}
+		}
+	}
+
+	if c.mergeChecker != nil && c.isInTimeWindow(types.MergeChecker, now) {
 		allowed := opController.OperatorCount(operator.OpMerge) < c.conf.GetMergeScheduleLimit()
 		if !allowed {
 			operator.IncOperatorLimitCounter(c.mergeChecker.GetType(), operator.OpMerge)
+		} else if ops := c.rangeMergePlanner.Plan(region); len(ops) > 0 {
+			return ops
 		} else if ops := c.mergeChecker.Check(region); ops != nil {
 			// It makes sure that two operators can be added successfully altogether.
 			return ops
@@ -340,6 +465,11 @@ func (c *Controller) CheckRegion(region *core.RegionInfo) []*operator.Operator {
 	return nil
 }
 
+// isInTimeWindow returns whether the checker is in its scheduling time windows.
+func (c *Controller) isInTimeWindow(typ types.CheckerSchedulerType, now time.Time) bool {
+	return c.timeWindows == nil || c.timeWindows.IsAllowed(typ.String(), now)
+}
+
 func (c *Controller) tryAddOperators(region *core.RegionInfo) {
 	if region == nil {
 		// the region could be recent split, continue to wait.
@@ -355,19 +485,37 @@ func (c *Controller) tryAddOperators(region *core.RegionInfo) {
 		return
 	}
 
-	if !c.opController.ExceedStoreLimit(ops...) {
-		c.opController.AddWaitingOperator(ops...)
+	if !c.opController.ExceedStoreLimit(ops...) || c.opController.CanPreempt(ops...) {
+		c.addOperators(ops...)
 		c.RemovePendingProcessedRegion(id)
 	} else {
 		c.AddPendingProcessedRegions(true, id)
 	}
 }
 
+// addOperators adds the operators created by the checkers, and charges the repair
+// budget of the rule checker only if they are added successfully.
+func (c *Controller) addOperators(ops ...*operator.Operator) {
+	if c.opController.AddWaitingOperator(ops...) > 0 {
+		c.ruleChecker.GetRepairPlanner().Charge(ops...)
+	}
+}
+
 // GetMergeChecker returns the merge checker.
 func (c *Controller) GetMergeChecker() *MergeChecker {
 	return c.mergeChecker
 }
 
+// GetRangeMergePlanner returns the range merge planner.
+func (c *Controller) GetRangeMergePlanner() *RangeMergePlanner {
+	return c.rangeMergePlanner
+}
+
+// GetBucketSplitChecker returns the bucket split checker.
+func (c *Controller) GetBucketSplitChecker() *BucketSplitChecker {
+	return c.bucketSplitChecker
+}
+
 // GetRuleChecker returns the rule checker.
 func (c *Controller) GetRuleChecker() *RuleChecker {
 	return c.ruleChecker
@@ -490,6 +638,8 @@ func (c *Controller) GetPauseController(name string) (*PauseController, error) {
 		return &c.ruleChecker.PauseController, nil
 	case "split":
 		return &c.splitChecker.PauseController, nil
+	case "bucket-split":
+		return &c.bucketSplitChecker.PauseController, nil
 	case "merge":
 		return &c.mergeChecker.PauseController, nil
 	case "joint-state":
@@ -0,0 +1,171 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/tikv/pd/pkg/cache"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+)
+
+// The status of a checker checking a region.
+const (
+	// CheckPaused means the checker is paused.
+	CheckPaused = "paused"
+	// CheckOutOfWindow means the checker is out of its scheduling time windows.
+	CheckOutOfWindow = "out-of-window"
+	// CheckInactive means the checker is not used, such as the replica checker
+	// when the placement rules are enabled.
+	CheckInactive = "inactive"
+	// CheckDenied means the region is labeled with `schedule=deny`.
+	CheckDenied = "denied"
+	// CheckLimited means the checker creates operators for the region, but
+	// they cannot be added because the schedule limit is exceeded.
+	CheckLimited = "limited"
+	// CheckScheduling means the checker creates operators for the region.
+	CheckScheduling = "scheduling"
+	// CheckNormal means the checker does not need to create operators for the region.
+	CheckNormal = "normal"
+)
+
+// explainers are the checkers used to explain the regions. They have their own
+// caches, so explaining a region does not affect the checkers of the patrol, but
+// they still count the checker metrics.
+type explainers struct {
+	learnerChecker     *LearnerChecker
+	replicaChecker     *ReplicaChecker
+	ruleChecker        *RuleChecker
+	splitChecker       *SplitChecker
+	bucketSplitChecker *BucketSplitChecker
+	mergeChecker       *MergeChecker
+	jointStateChecker  *JointStateChecker
+}
+
+func (c *Controller) getExplainers() *explainers {
+	c.explainOnce.Do(func() {
+		pendingProcessedRegions := cache.NewIDTTL(c.ctx, time.Minute, 3*time.Minute)
+		e := &explainers{
+			learnerChecker:     NewLearnerChecker(c.cluster),
+			replicaChecker:     NewReplicaChecker(c.cluster, c.conf, pendingProcessedRegions),
+			ruleChecker:        NewRuleChecker(c.ctx, c.cluster, c.ruleManager, c.labeler, pendingProcessedRegions),
+			splitChecker:       NewSplitChecker(c.cluster, c.ruleManager, c.labeler),
+			bucketSplitChecker: NewBucketSplitChecker(c.ctx, c.cluster, c.conf),
+			jointStateChecker:  NewJointStateChecker(c.cluster),
+		}
+		if c.mergeChecker != nil {
+			// The recently split regions and the start time are shared with the
+			// merge checker of the patrol, which are only read when checking.
+			e.mergeChecker = &MergeChecker{
+				cluster:    c.cluster,
+				conf:       c.conf,
+				splitCache: c.mergeChecker.splitCache,
+				startTime:  c.mergeChecker.startTime,
+			}
+		}
+		c.explainers = e
+	})
+	return c.explainers
+}
+
+// CheckResult is the result of a checker checking a region.
+type CheckResult struct {
+	Type      types.CheckerSchedulerType
+	Status    string
+	Operators []*operator.Operator
+}
+
+// ExplainRegion checks the region with the explainer of every checker without
+// adding the operators, and returns the result of each checker in the order they
+// are applied in CheckRegion. The pause and the time windows are still judged by
+// the checkers of the patrol.
+func (c *Controller) ExplainRegion(region *core.RegionInfo) []*CheckResult {
+	now := time.Now()
+	placementRulesEnabled := c.conf.IsPlacementRulesEnabled()
+	denied := false
+	if cl, ok := c.cluster.(interface{ GetRegionLabeler() *labeler.RegionLabeler }); ok {
+		denied = cl.GetRegionLabeler().ScheduleDisabled(region)
+	}
+
+	results := make([]*CheckResult, 0, 7)
+	explain := func(typ types.CheckerSchedulerType, pause *PauseController, inactive bool,
+		check func() []*operator.Operator, limited func() bool) {
+		result := &CheckResult{Type: typ}
+		results = append(results, result)
+		switch {
+		case inactive:
+			result.Status = CheckInactive
+			return
+		case pause.IsPaused():
+			result.Status = CheckPaused
+			return
+		case !c.isInTimeWindow(typ, now):
+			result.Status = CheckOutOfWindow
+			return
+		}
+		result.Operators = check()
+		switch {
+		case len(result.Operators) == 0:
+			result.Status = CheckNormal
+		case limited != nil && limited():
+			result.Status = CheckLimited
+		default:
+			result.Status = CheckScheduling
+		}
+	}
+	single := func(op *operator.Operator) []*operator.Operator {
+		if op == nil {
+			return nil
+		}
+		return []*operator.Operator{op}
+	}
+	e := c.getExplainers()
+	replicaLimited := func() bool {
+		return c.opController.OperatorCount(operator.OpReplica) >= c.conf.GetReplicaScheduleLimit()
+	}
+
+	explain(types.JointStateChecker, &c.jointStateChecker.PauseController, false,
+		func() []*operator.Operator { return single(e.jointStateChecker.Check(region)) }, nil)
+	explain(types.SplitChecker, &c.splitChecker.PauseController, false,
+		func() []*operator.Operator { return single(e.splitChecker.Check(region)) }, nil)
+	explain(types.RuleChecker, &c.ruleChecker.PauseController, !placementRulesEnabled,
+		func() []*operator.Operator { return single(e.ruleChecker.Check(region)) }, replicaLimited)
+	explain(types.LearnerChecker, &c.learnerChecker.PauseController, placementRulesEnabled,
+		func() []*operator.Operator { return single(e.learnerChecker.Check(region)) }, nil)
+	explain(types.ReplicaChecker, &c.replicaChecker.PauseController, placementRulesEnabled,
+		func() []*operator.Operator { return single(e.replicaChecker.Check(region)) }, replicaLimited)
+	if denied {
+		// The bucket split checker and the merge checker are skipped when the
+		// region is labeled with `schedule=deny`.
+		results = append(results, &CheckResult{Type: types.BucketSplitChecker, Status: CheckDenied})
+	} else {
+		explain(types.BucketSplitChecker, &c.bucketSplitChecker.PauseController, !c.conf.IsBucketSplitEnabled(),
+			func() []*operator.Operator { return single(e.bucketSplitChecker.Check(region)) }, nil)
+	}
+	if c.mergeChecker == nil {
+		results = append(results, &CheckResult{Type: types.MergeChecker, Status: CheckInactive})
+	} else if denied {
+		// The merge checker is skipped when the region is labeled with `schedule=deny`.
+		results = append(results, &CheckResult{Type: types.MergeChecker, Status: CheckDenied})
+	} else {
+		explain(types.MergeChecker, &c.mergeChecker.PauseController, false,
+			func() []*operator.Operator { return e.mergeChecker.Check(region) },
+			func() bool { return c.opController.OperatorCount(operator.OpMerge) >= c.conf.GetMergeScheduleLimit() })
+	}
+	return results
+}
@@ -41,13 +41,6 @@ const (
 	maxTargetRegionFactor = 4
 )
 
-// When a region has label `merge_option=deny`, skip merging the region.
-// If label value is `allow` or other value, it will be treated as `allow`.
-const (
-	mergeOptionLabel     = "merge_option"
-	mergeOptionValueDeny = "deny"
-)
-
 var gcInterval = time.Minute
 
 // MergeChecker ensures region to merge with adjacent region when size is small
@@ -83,6 +76,12 @@ func (c *MergeChecker) RecordRegionSplit(regionIDs []uint64) {
 	}
 }
 
+// IsRecentlyStarted returns true if the server started within `split-merge-interval`,
+// the regions are not merged during this time since the split cache is empty.
+func (c *MergeChecker) IsRecentlyStarted() bool {
+	return time.Now().Before(c.startTime.Add(c.conf.GetSplitMergeInterval()))
+}
+
 // Check verifies a region's replicas, creating an Operator if need.
 func (c *MergeChecker) Check(region *core.RegionInfo) []*operator.Operator {
 	mergeCheckerCounter.Inc()
@@ -96,8 +95,7 @@ func (c *MergeChecker) Check(region *core.RegionInfo) []*operator.Operator {
 	// It must be called before the following merge checker logic.
 	c.splitCache.UpdateTTL(c.conf.GetSplitMergeInterval())
 
-	expireTime := c.startTime.Add(c.conf.GetSplitMergeInterval())
-	if time.Now().Before(expireTime) {
+	if c.IsRecentlyStarted() {
 		mergeCheckerRecentlyStartCounter.Inc()
 		return nil
 	}
@@ -258,8 +256,14 @@ func AllowMerge(cluster sche.SharedCluster, region, adjacent *core.RegionInfo) b
 		if len(l.GetSplitKeys(start, end)) > 0 {
 			return false
 		}
-		if l.GetRegionLabel(region, mergeOptionLabel) == mergeOptionValueDeny || l.GetRegionLabel(adjacent, mergeOptionLabel) == mergeOptionValueDeny {
-			return false
+		// When a region has label `merge_option=deny`, skip merging the region.
+		// The merged region should not exceed the `max_region_size` of either side.
+		mergedSize := uint64(region.GetApproximateSize() + adjacent.GetApproximateSize())
+		for _, r := range []*core.RegionInfo{region, adjacent} {
+			policy := l.GetSchedulingPolicy(r)
+			if policy.MergeDisabled || (policy.MaxRegionSize > 0 && mergedSize > policy.MaxRegionSize) {
+				return false
+			}
 		}
 	}
 
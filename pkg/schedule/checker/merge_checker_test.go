@@ -17,6 +17,7 @@ package checker
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
@@ -214,7 +215,7 @@ func (suite *mergeCheckerTestSuite) TestBasic() {
 	//  check 'merge_option' label
 	err = suite.cluster.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
 		ID:       "test",
-		Labels:   []labeler.RegionLabel{{Key: mergeOptionLabel, Value: mergeOptionValueDeny}},
+		Labels:   []labeler.RegionLabel{{Key: labeler.MergeOptionLabel, Value: labeler.PolicyValueDeny}},
 		RuleType: labeler.KeyRange,
 		Data:     makeKeyRanges("", "74"),
 	})
@@ -268,6 +269,109 @@ func (suite *mergeCheckerTestSuite) TestBasic() {
 	re.NotNil(ops)
 }
 
+func (suite *mergeCheckerTestSuite) TestMaxRegionSizePolicy() {
+	re := suite.Require()
+	suite.cluster.SetSplitMergeInterval(0)
+	suite.cluster.PutRegion(suite.regions[3].Clone(core.SetApproximateSize(2)))
+	ops := suite.mc.Check(suite.regions[2])
+	re.NotNil(ops)
+
+	// the merged region would exceed the `max_region_size` of both sides.
+	err := suite.cluster.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "test",
+		Labels:   []labeler.RegionLabel{{Key: labeler.MaxRegionSizeLabel, Value: "1"}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges("74", ""),
+	})
+	re.NoError(err)
+	ops = suite.mc.Check(suite.regions[2])
+	re.Nil(ops)
+
+	re.NoError(suite.cluster.GetRegionLabeler().DeleteLabelRule("test"))
+	ops = suite.mc.Check(suite.regions[2])
+	re.NotNil(ops)
+}
+
+func (suite *mergeCheckerTestSuite) TestRangeMergePlanner() {
+	re := suite.Require()
+	cfg := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(suite.ctx, cfg)
+	tc.SetMaxMergeRegionSize(2)
+	tc.SetMaxMergeRegionKeys(2)
+	tc.SetEnableRangeMerge(true)
+	tc.SetRangeMergeMinRegions(4)
+	tc.SetRangeMergeScheduleLimit(3)
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddLeaderStore(i, 10)
+	}
+	// 10 empty regions [, k01), [k01, k02), ..., [k09, k10) and a large region [k10, ).
+	var regions []*core.RegionInfo
+	for i := uint64(1); i <= 11; i++ {
+		startKey, endKey := fmt.Sprintf("k%02d", i-1), fmt.Sprintf("k%02d", i)
+		size := int64(0)
+		if i == 1 {
+			startKey = ""
+		}
+		if i == 11 {
+			endKey, size = "", 100
+		}
+		peers := [][]uint64{{i*10 + 1, 1}, {i*10 + 2, 2}, {i*10 + 3, 3}}
+		regions = append(regions, newRegionInfo(i, startKey, endKey, size, size, peers[0], peers...))
+		tc.PutRegion(regions[i-1])
+	}
+	mc := NewMergeChecker(suite.ctx, tc, tc.GetCheckerConfig())
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, tc, false /* no need to run */)
+	oc := operator.NewController(suite.ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	planner := NewRangeMergePlanner(suite.ctx, tc, tc.GetCheckerConfig(), mc, oc)
+
+	// the regions are not merged just after PD starts.
+	re.True(mc.IsRecentlyStarted())
+	re.Empty(planner.Plan(regions[0]))
+	tc.SetSplitMergeInterval(0)
+	re.False(mc.IsRecentlyStarted())
+
+	// only the start of the run is planned.
+	re.Empty(planner.Plan(regions[1]))
+	ops := planner.Plan(regions[0])
+	// the disjoint pairs are merged at the same time, up to the limit.
+	re.Len(ops, 6)
+	for i := range 3 {
+		re.Equal(regions[2*i+1].GetID(), ops[2*i].RegionID())
+		re.Equal(regions[2*i].GetID(), ops[2*i+1].RegionID())
+	}
+	re.Equal(6, oc.AddWaitingOperator(ops...))
+
+	backlog := planner.GetMergeBacklog()
+	re.Equal(9, backlog.PendingMerges)
+	re.Equal(3, backlog.InflightMerges)
+	re.Len(backlog.Runs, 1)
+	re.Equal(uint64(1), backlog.Runs[0].StartRegionID)
+	re.Equal(10, backlog.Runs[0].RegionCount)
+	re.Equal(core.HexRegionKeyStr([]byte("k10")), backlog.Runs[0].EndKey)
+
+	// the rest of the run is a new run, which is limited by the inflight merges.
+	re.Empty(planner.Plan(regions[6]))
+	// removing a merge operator also removes the related one.
+	oc.RemoveOperator(oc.GetOperator(regions[1].GetID()))
+	ops = planner.Plan(regions[6])
+	re.Len(ops, 2)
+	re.Equal(regions[7].GetID(), ops[0].RegionID())
+	re.Equal(regions[6].GetID(), ops[1].RegionID())
+
+	// the label rules are respected.
+	err := tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "deny-merge",
+		Labels:   []labeler.RegionLabel{{Key: labeler.MergeOptionLabel, Value: labeler.PolicyValueDeny}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges(hex.EncodeToString([]byte("k08")), hex.EncodeToString([]byte("k09"))),
+	})
+	re.NoError(err)
+	re.Empty(planner.Plan(regions[6]))
+
+	tc.SetEnableRangeMerge(false)
+	re.Empty(planner.Plan(regions[0]))
+}
+
 func (suite *mergeCheckerTestSuite) TestMatchPeers() {
 	re := suite.Require()
 	suite.cluster.SetSplitMergeInterval(0)
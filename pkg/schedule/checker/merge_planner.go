@@ -0,0 +1,208 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/cache"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/config"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// maxMergeRunScan is the max number of the regions scanned for a run.
+	maxMergeRunScan = 4096
+	// mergeRunTTL is the time to keep a run in the backlog if it is not checked again.
+	mergeRunTTL = 30 * time.Minute
+)
+
+// MergeRun is a run of the adjacent small regions which can be merged together.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type MergeRun struct {
+	StartRegionID uint64    `json:"start_region_id"`
+	StartKey      string    `json:"start_key"`
+	EndKey        string    `json:"end_key"`
+	RegionCount   int       `json:"region_count"`
+	UpdateTime    time.Time `json:"update_time"`
+}
+
+// MergeBacklog is the pending merges found by the range merge planner.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type MergeBacklog struct {
+	// PendingMerges is the number of the merges needed to merge every run into one region.
+	PendingMerges int `json:"pending_merges"`
+	// InflightMerges is the number of the region pairs being merged by the planner.
+	InflightMerges int         `json:"inflight_merges"`
+	Runs           []*MergeRun `json:"runs"`
+}
+
+// RangeMergePlanner finds the long runs of small regions, e.g. after dropping a
+// table, and merges the disjoint adjacent pairs of a run at the same time, so
+// that a run of n regions is merged in about log2(n) rounds. Like the merge
+// checker, it does not merge regions within `split-merge-interval` after PD
+// starts, and it has its own concurrency limit besides `merge-schedule-limit`.
+type RangeMergePlanner struct {
+	cluster      sche.CheckerCluster
+	conf         config.CheckerConfigProvider
+	mergeChecker *MergeChecker
+	opController *operator.Controller
+	runs         *cache.TTLUint64 // start region id -> *MergeRun
+
+	syncutil.Mutex
+	inflight map[uint64]struct{} // source region ids of the merging pairs
+}
+
+// NewRangeMergePlanner creates a range merge planner.
+func NewRangeMergePlanner(ctx context.Context, cluster sche.CheckerCluster, conf config.CheckerConfigProvider,
+	mergeChecker *MergeChecker, opController *operator.Controller) *RangeMergePlanner {
+	return &RangeMergePlanner{
+		cluster:      cluster,
+		conf:         conf,
+		mergeChecker: mergeChecker,
+		opController: opController,
+		runs:         cache.NewIDTTL(ctx, gcInterval, mergeRunTTL),
+		inflight:     make(map[uint64]struct{}),
+	}
+}
+
+// Plan returns the merge operators if the region is the start of a long run of
+// small regions. The operators of each pair are adjacent in the result.
+func (p *RangeMergePlanner) Plan(region *core.RegionInfo) []*operator.Operator {
+	if !p.conf.IsRangeMergeEnabled() || p.mergeChecker.IsPaused() || p.mergeChecker.IsRecentlyStarted() ||
+		!p.isCandidate(region) {
+		return nil
+	}
+	// Only plan at the start of a run, so that each run is scanned once in a patrol.
+	if prev, _ := p.cluster.GetAdjacentRegions(region); prev != nil && p.isCandidate(prev) && AllowMerge(p.cluster, prev, region) {
+		return nil
+	}
+	run := p.scanRun(region)
+	if len(run) < p.conf.GetRangeMergeMinRegions() {
+		p.runs.Remove(region.GetID())
+		p.updateBacklogMetric()
+		return nil
+	}
+	p.runs.Put(region.GetID(), &MergeRun{
+		StartRegionID: region.GetID(),
+		StartKey:      core.HexRegionKeyStr(region.GetStartKey()),
+		EndKey:        core.HexRegionKeyStr(run[len(run)-1].GetEndKey()),
+		RegionCount:   len(run),
+		UpdateTime:    time.Now(),
+	})
+	p.updateBacklogMetric()
+
+	p.Lock()
+	defer p.Unlock()
+	p.gcInflightLocked()
+	var ops []*operator.Operator
+	for i := 0; i+1 < len(run) && uint64(len(p.inflight)) < p.conf.GetRangeMergeScheduleLimit(); i += 2 {
+		// merge the latter region into the former one.
+		source, target := run[i+1], run[i]
+		if !checkPeerStore(p.cluster, source, target) {
+			continue
+		}
+		pair, err := operator.CreateMergeRegionOperator("range-merge-region", p.cluster, source, target, operator.OpMerge)
+		if err != nil {
+			rangeMergePlannerCreateOpFailedCounter.Inc()
+			log.Debug("create range merge region operator failed", errs.ZapError(err))
+			continue
+		}
+		ops = append(ops, pair...)
+		p.inflight[source.GetID()] = struct{}{}
+		rangeMergePlannerNewOpCounter.Inc()
+	}
+	if len(ops) == 0 && len(p.inflight) > 0 {
+		rangeMergePlannerLimitCounter.Inc()
+	}
+	return ops
+}
+
+// isCandidate returns true if the region can be merged as a part of a run.
+func (p *RangeMergePlanner) isCandidate(region *core.RegionInfo) bool {
+	return region.GetLeader() != nil &&
+		region.NeedMerge(int64(p.conf.GetMaxMergeRegionSize()), int64(p.conf.GetMaxMergeRegionKeys())) &&
+		!p.mergeChecker.splitCache.Exists(region.GetID()) &&
+		p.opController.GetOperator(region.GetID()) == nil &&
+		filter.IsRegionHealthy(region) &&
+		filter.IsRegionReplicated(p.cluster, region) &&
+		!p.cluster.IsRegionHot(region)
+}
+
+// scanRun returns the run of the mergeable regions starting from the region.
+func (p *RangeMergePlanner) scanRun(region *core.RegionInfo) []*core.RegionInfo {
+	run := []*core.RegionInfo{region}
+	for cur := region; len(run) < maxMergeRunScan; {
+		_, next := p.cluster.GetAdjacentRegions(cur)
+		if next == nil || !p.isCandidate(next) || !AllowMerge(p.cluster, cur, next) {
+			break
+		}
+		run = append(run, next)
+		cur = next
+	}
+	return run
+}
+
+// gcInflightLocked removes the pairs which are finished or failed to be added.
+func (p *RangeMergePlanner) gcInflightLocked() {
+	for id := range p.inflight {
+		if p.opController.GetOperator(id) == nil {
+			delete(p.inflight, id)
+		}
+	}
+}
+
+func (p *RangeMergePlanner) getRuns() []*MergeRun {
+	runs := make([]*MergeRun, 0)
+	for _, id := range p.runs.GetAllID() {
+		if v, ok := p.runs.Get(id); ok {
+			runs = append(runs, v.(*MergeRun))
+		}
+	}
+	return runs
+}
+
+func (p *RangeMergePlanner) updateBacklogMetric() {
+	pending := 0
+	for _, run := range p.getRuns() {
+		pending += run.RegionCount - 1
+	}
+	rangeMergeBacklogGauge.Set(float64(pending))
+}
+
+// GetMergeBacklog returns the pending merges found by the planner, the runs are sorted by the start key.
+func (p *RangeMergePlanner) GetMergeBacklog() *MergeBacklog {
+	backlog := &MergeBacklog{Runs: p.getRuns()}
+	for _, run := range backlog.Runs {
+		backlog.PendingMerges += run.RegionCount - 1
+	}
+	sort.Slice(backlog.Runs, func(i, j int) bool {
+		return backlog.Runs[i].StartKey < backlog.Runs[j].StartKey
+	})
+	p.Lock()
+	defer p.Unlock()
+	p.gcInflightLocked()
+	backlog.InflightMerges = len(p.inflight)
+	return backlog
+}
@@ -39,23 +39,53 @@ var (
 			Name:      "patrol_regions_time",
 			Help:      "Time spent of patrol checks region.",
 		})
+
+	rangeMergeBacklogGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "checker",
+			Name:      "range_merge_backlog",
+			Help:      "Number of the pending merges of the small region runs.",
+		})
+
+	ruleComplianceGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "checker",
+			Name:      "rule_compliance_regions",
+			Help:      "Number of the regions about different compliance status of each placement rule.",
+		}, []string{"group", "rule", "status"})
+
+	ruleChangeComplianceDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "pd",
+			Subsystem: "checker",
+			Name:      "rule_change_compliance_duration_seconds",
+			Help:      "Bucketed histogram of the duration from the rule change to the region complies with the rules.",
+			Buckets:   []float64{0.5, 1, 2, 4, 8, 16, 20, 40, 60, 90, 120, 180, 240, 300, 480, 600},
+		})
 )
 
 func init() {
 	prometheus.MustRegister(checkerCounter)
 	prometheus.MustRegister(regionListGauge)
 	prometheus.MustRegister(patrolCheckRegionsGauge)
+	prometheus.MustRegister(rangeMergeBacklogGauge)
+	prometheus.MustRegister(ruleChangeComplianceDuration)
+	prometheus.MustRegister(ruleComplianceGauge)
 }
 
 const (
 	// NOTE: these types are different from pkg/schedule/config/type.go,
 	// they are only used for prometheus metrics to keep the compatibility.
-	ruleChecker       = "rule_checker"
-	jointStateChecker = "joint_state_checker"
-	learnerChecker    = "learner_checker"
-	mergeChecker      = "merge_checker"
-	replicaChecker    = "replica_checker"
-	splitChecker      = "split_checker"
+	ruleChecker        = "rule_checker"
+	jointStateChecker  = "joint_state_checker"
+	learnerChecker     = "learner_checker"
+	mergeChecker       = "merge_checker"
+	replicaChecker     = "replica_checker"
+	splitChecker       = "split_checker"
+	bucketSplitChecker = "bucket_split_checker"
+	rangeMergePlanner  = "range_merge_planner"
 )
 
 func ruleCheckerCounterWithEvent(event string) prometheus.Counter {
@@ -91,6 +121,8 @@ var (
 	ruleCheckerNoStoreReplaceCounter              = ruleCheckerCounterWithEvent("no-store-replace")
 	ruleCheckerFixPeerRoleCounter                 = ruleCheckerCounterWithEvent("fix-peer-role")
 	ruleCheckerFixLeaderRoleCounter               = ruleCheckerCounterWithEvent("fix-leader-role")
+	ruleCheckerFixLeaderPreferenceCounter         = ruleCheckerCounterWithEvent("fix-leader-preference")
+	ruleCheckerWaitRepairBudgetCounter            = ruleCheckerCounterWithEvent("wait-repair-budget")
 	ruleCheckerNotAllowLeaderCounter              = ruleCheckerCounterWithEvent("not-allow-leader")
 	ruleCheckerFixFollowerRoleCounter             = ruleCheckerCounterWithEvent("fix-follower-role")
 	ruleCheckerNoNewLeaderCounter                 = ruleCheckerCounterWithEvent("no-new-leader")
@@ -153,6 +185,21 @@ var (
 	replicaCheckerReplaceOfflineFailedCounter     = replicaCheckerCounterWithEvent("replace-offline-replica-failed")
 	replicaCheckerReplaceDownFailedCounter        = replicaCheckerCounterWithEvent("replace-down-replica-failed")
 
-	splitCheckerCounter       = checkerCounter.WithLabelValues(splitChecker, "check")
-	splitCheckerPausedCounter = checkerCounter.WithLabelValues(splitChecker, "paused")
+	splitCheckerCounter           = checkerCounter.WithLabelValues(splitChecker, "check")
+	splitCheckerPausedCounter     = checkerCounter.WithLabelValues(splitChecker, "paused")
+	splitCheckerPolicySizeCounter = checkerCounter.WithLabelValues(splitChecker, "policy-size")
+
+	bucketSplitCheckerCounter                = checkerCounter.WithLabelValues(bucketSplitChecker, "check")
+	bucketSplitCheckerPausedCounter          = checkerCounter.WithLabelValues(bucketSplitChecker, "paused")
+	bucketSplitCheckerFewBucketsCounter      = checkerCounter.WithLabelValues(bucketSplitChecker, "few-buckets")
+	bucketSplitCheckerColdCounter            = checkerCounter.WithLabelValues(bucketSplitChecker, "cold")
+	bucketSplitCheckerNotConcentratedCounter = checkerCounter.WithLabelValues(bucketSplitChecker, "not-concentrated")
+	bucketSplitCheckerFailedCounter          = checkerCounter.WithLabelValues(bucketSplitChecker, "create-operator-fail")
+	bucketSplitCheckerNewOpCounter           = checkerCounter.WithLabelValues(bucketSplitChecker, "new-operator")
+
+	rangeMergePlannerNewOpCounter          = checkerCounter.WithLabelValues(rangeMergePlanner, "new-operator")
+	rangeMergePlannerCreateOpFailedCounter = checkerCounter.WithLabelValues(rangeMergePlanner, "create-operator-fail")
+	rangeMergePlannerLimitCounter          = checkerCounter.WithLabelValues(rangeMergePlanner, "exceed-limit")
+
+	ruleChangedRegionExpiredCounter = checkerCounter.WithLabelValues(ruleChecker, "rule-changed-region-expired")
 )
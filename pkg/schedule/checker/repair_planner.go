@@ -0,0 +1,189 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	repairBudgetWindow = time.Minute
+	// repairWaitingTTL is the duration to keep a waiting region which is not
+	// checked again, e.g. it has been repaired by other operators.
+	repairWaitingTTL = 5 * time.Minute
+)
+
+type repairWaiting struct {
+	healthy  int
+	size     int64
+	lastSeen time.Time
+}
+
+// repairPending is a repair allowed by the planner whose operator is not added yet.
+type repairPending struct {
+	size          uint64
+	crossLocation bool
+	allowTime     time.Time
+}
+
+// RepairProgress is the status of the repair planner.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type RepairProgress struct {
+	// CrossLocationSizeLimit is the max size (in MiB) of the regions which can be repaired
+	// across locations per minute, 0 means no limit.
+	CrossLocationSizeLimit uint64 `json:"cross_location_size_limit"`
+	// CrossLocationSizeUsed is the size of the regions repaired across locations in the current minute.
+	CrossLocationSizeUsed uint64 `json:"cross_location_size_used"`
+	// WaitingRegions is the count of the regions waiting for the budget, grouped by the healthy replica count.
+	WaitingRegions map[int]int `json:"waiting_regions"`
+	// WaitingSize is the total size of the regions waiting for the budget.
+	WaitingSize int64 `json:"waiting_size"`
+	// RepairedRegions is the count of the regions which repair operators are created for.
+	RepairedRegions uint64 `json:"repaired_regions"`
+	// CrossLocationRepairedSize is the total size of the regions repaired across locations.
+	CrossLocationRepairedSize uint64 `json:"cross_location_repaired_size"`
+}
+
+// RepairPlanner decides whether the rule checker can repair a region now. The repairs which
+// send snapshots across the top level location label consume a per-minute size budget, and
+// the regions with fewer healthy replicas are repaired first when the budget is limited.
+type RepairPlanner struct {
+	syncutil.Mutex
+	conf config.CheckerConfigProvider
+
+	windowStart time.Time
+	usedSize    uint64
+	waiting     map[uint64]*repairWaiting // region id -> waiting info
+	pending     map[uint64]*repairPending // region id -> allowed repair
+
+	repairedRegions   uint64
+	crossLocationSize uint64
+}
+
+// NewRepairPlanner creates a repair planner.
+func NewRepairPlanner(conf config.CheckerConfigProvider) *RepairPlanner {
+	return &RepairPlanner{
+		conf:    conf,
+		waiting: make(map[uint64]*repairWaiting),
+		pending: make(map[uint64]*repairPending),
+	}
+}
+
+// Allow returns true if the region with the given healthy replica count can be repaired now.
+// crossLocation indicates whether the repair sends the snapshot across locations. The budget
+// is not consumed until Charge is called after the repair operator is added.
+func (p *RepairPlanner) Allow(region *core.RegionInfo, healthy int, crossLocation bool) bool {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	p.gcLocked(now)
+	if now.Sub(p.windowStart) >= repairBudgetWindow {
+		p.windowStart, p.usedSize = now, 0
+	}
+
+	regionID := region.GetID()
+	size := uint64(max(region.GetApproximateSize(), 1))
+	limit := p.conf.GetCrossLocationRepairSizePerMinute()
+	if crossLocation && limit > 0 && !p.canRepairLocked(regionID, healthy, size, limit, now) {
+		ruleCheckerWaitRepairBudgetCounter.Inc()
+		p.waiting[regionID] = &repairWaiting{healthy: healthy, size: region.GetApproximateSize(), lastSeen: now}
+		return false
+	}
+	p.pending[regionID] = &repairPending{size: size, crossLocation: crossLocation && limit > 0, allowTime: now}
+	return true
+}
+
+// Charge consumes the budget of the repairs allowed by Allow. It should be called
+// after the operators are added, the other operators are ignored.
+func (p *RepairPlanner) Charge(ops ...*operator.Operator) {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	for _, op := range ops {
+		if op.Kind()&operator.OpReplica == 0 {
+			continue
+		}
+		regionID := op.RegionID()
+		pending, ok := p.pending[regionID]
+		if !ok {
+			continue
+		}
+		delete(p.pending, regionID)
+		delete(p.waiting, regionID)
+		p.repairedRegions++
+		if !pending.crossLocation {
+			continue
+		}
+		if now.Sub(p.windowStart) >= repairBudgetWindow {
+			p.windowStart, p.usedSize = now, 0
+		}
+		p.usedSize += pending.size
+		p.crossLocationSize += pending.size
+	}
+}
+
+func (p *RepairPlanner) canRepairLocked(regionID uint64, healthy int, size, limit uint64, now time.Time) bool {
+	// Reserve the budget for the regions at higher risk. At least one region can be
+	// repaired in each window even if it is larger than the limit.
+	if p.usedSize > 0 && p.usedSize+size > limit {
+		return false
+	}
+	for id, w := range p.waiting {
+		if id != regionID && w.healthy < healthy && now.Sub(w.lastSeen) <= repairWaitingTTL {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *RepairPlanner) gcLocked(now time.Time) {
+	for id, w := range p.waiting {
+		if now.Sub(w.lastSeen) > repairWaitingTTL {
+			delete(p.waiting, id)
+		}
+	}
+	for id, pending := range p.pending {
+		if now.Sub(pending.allowTime) > repairBudgetWindow {
+			delete(p.pending, id)
+		}
+	}
+}
+
+// GetRepairProgress returns the status of the repair planner.
+func (p *RepairPlanner) GetRepairProgress() *RepairProgress {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	p.gcLocked(now)
+	progress := &RepairProgress{
+		CrossLocationSizeLimit:    p.conf.GetCrossLocationRepairSizePerMinute(),
+		WaitingRegions:            make(map[int]int),
+		RepairedRegions:           p.repairedRegions,
+		CrossLocationRepairedSize: p.crossLocationSize,
+	}
+	if now.Sub(p.windowStart) < repairBudgetWindow {
+		progress.CrossLocationSizeUsed = p.usedSize
+	}
+	for _, w := range p.waiting {
+		progress.WaitingRegions[w.healthy]++
+		progress.WaitingSize += w.size
+	}
+	return progress
+}
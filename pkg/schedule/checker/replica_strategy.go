@@ -16,6 +16,7 @@ package checker
 
 import (
 	"math/rand"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -25,6 +26,8 @@ import (
 	"github.com/tikv/pd/pkg/core/constant"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
 )
 
 // ReplicaStrategy collects some utilities to manipulate region peers. It
@@ -37,7 +40,12 @@ type ReplicaStrategy struct {
 	isolationLevel string
 	region         *core.RegionInfo
 	extraFilters   []filter.Filter
-	fastFailover   bool
+	// labelPreferences are the soft label constraints used to prefer stores.
+	labelPreferences []placement.LabelConstraint
+	fastFailover     bool
+	// isTiFlash indicates the replicas are placed on the TiFlash stores, whose
+	// columnar replica size differs from the approximate region size.
+	isTiFlash bool
 }
 
 // SelectStoreToAdd returns the store to add a replica to a region.
@@ -59,14 +67,37 @@ func (s *ReplicaStrategy) SelectStoreToAdd(coLocationStores []*core.StoreInfo, e
 	//
 	// The reason for it is to prevent the non-optimal replica placement due
 	// to the short-term state, resulting in redundant scheduling.
+	targetCandidate := s.selectCandidates(coLocationStores, extraFilters...)
+	if targetCandidate.Len() == 0 {
+		return 0, false
+	}
+	level := constant.High
+	if s.fastFailover {
+		level = constant.Urgent
+	}
+	strictStateFilter := &filter.StoreStateFilter{ActionScope: s.checkerName, MoveRegion: true, AllowFastFailover: s.fastFailover, OperatorLevel: level}
+	// The region score is discounted by the label preference, so the preferred
+	// stores are chosen unless they are much more loaded than the others.
+	target := targetCandidate.FilterTarget(s.cluster.GetCheckerConfig(), nil, nil, strictStateFilter).
+		PickTheTopStore(filter.PreferredRegionScoreComparer(s.cluster.GetCheckerConfig(), s.labelPreferences), true) // less region score is better
+	if target == nil {
+		return 0, true // filter by temporary states
+	}
+	return target.GetID(), false
+}
+
+// selectCandidates returns the stores with the highest isolation score which can
+// be the target of the new replica, the temporary states of the stores are ignored.
+func (s *ReplicaStrategy) selectCandidates(coLocationStores []*core.StoreInfo, extraFilters ...filter.Filter) *filter.StoreCandidates {
 	level := constant.High
 	if s.fastFailover {
 		level = constant.Urgent
 	}
 	filters := []filter.Filter{
 		filter.NewExcludedFilter(s.checkerName, nil, s.region.GetStoreIDs()),
-		filter.NewStorageThresholdFilter(s.checkerName),
+		s.storageThresholdFilter(),
 		filter.NewSpecialUseFilter(s.checkerName),
+		filter.NewEngineFilter(s.checkerName, filter.NotComputeEngines),
 		&filter.StoreStateFilter{ActionScope: s.checkerName, MoveRegion: true, AllowTemporaryStates: true, OperatorLevel: level},
 	}
 	if len(s.locationLabels) > 0 && s.isolationLevel != "" {
@@ -80,19 +111,19 @@ func (s *ReplicaStrategy) SelectStoreToAdd(coLocationStores []*core.StoreInfo, e
 	}
 
 	isolationComparer := filter.IsolationComparer(s.locationLabels, coLocationStores)
-	strictStateFilter := &filter.StoreStateFilter{ActionScope: s.checkerName, MoveRegion: true, AllowFastFailover: s.fastFailover, OperatorLevel: level}
-	targetCandidate := filter.NewCandidates(s.r, s.cluster.GetStores()).
+	return filter.NewCandidates(s.r, s.cluster.GetStores()).
 		FilterTarget(s.cluster.GetCheckerConfig(), nil, nil, filters...).
 		KeepTheTopStores(isolationComparer, false) // greater isolation score is better
-	if targetCandidate.Len() == 0 {
-		return 0, false
-	}
-	target := targetCandidate.FilterTarget(s.cluster.GetCheckerConfig(), nil, nil, strictStateFilter).
-		PickTheTopStore(filter.RegionScoreComparer(s.cluster.GetCheckerConfig()), true) // less region score is better
-	if target == nil {
-		return 0, true // filter by temporary states
-	}
-	return target.GetID(), false
+}
+
+// storageThresholdFilter returns the filter to check the space of the target store.
+// The TiFlash stores should have enough space for the estimated columnar replica.
+func (s *ReplicaStrategy) storageThresholdFilter() filter.Filter {
+	if !s.isTiFlash {
+		return filter.NewStorageThresholdFilter(s.checkerName)
+	}
+	ratio := s.cluster.GetCheckerConfig().GetTiFlashReplicaSizeRatio()
+	return filter.NewReplicaSizeThresholdFilter(s.checkerName, int64(float64(s.region.GetApproximateSize())*ratio))
 }
 
 // SelectStoreToFix returns a store to replace down/offline old peer. The location
@@ -151,10 +182,32 @@ func (s *ReplicaStrategy) SelectStoreToRemove(coLocationStores []*core.StoreInfo
 	source := filter.NewCandidates(s.r, coLocationStores).
 		FilterSource(s.cluster.GetCheckerConfig(), nil, nil, &filter.StoreStateFilter{ActionScope: s.checkerName, MoveRegion: true, OperatorLevel: level}).
 		KeepTheTopStores(isolationComparer, true).
-		PickTheTopStore(filter.RegionScoreComparer(s.cluster.GetCheckerConfig()), false)
+		PickTheTopStore(filter.PreferredRegionScoreComparer(s.cluster.GetCheckerConfig(), s.labelPreferences), false)
 	if source == nil {
 		log.Debug("no removable store", zap.Uint64("region-id", s.region.GetID()))
 		return 0
 	}
 	return source.GetID()
 }
+
+// NewSimulationTargetSelector returns the target selector of the placement rule
+// simulation. It filters the stores like the rule checker, but ignores the
+// temporary states of the stores.
+func NewSimulationTargetSelector(cluster sche.CheckerCluster) placement.TargetSelector {
+	checkerName := types.RuleChecker.String()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return func(region *core.RegionInfo, rule *placement.Rule, ruleStores []*core.StoreInfo, excluded map[uint64]struct{}) []*core.StoreInfo {
+		s := &ReplicaStrategy{
+			checkerName:      checkerName,
+			cluster:          cluster,
+			isolationLevel:   rule.IsolationLevel,
+			locationLabels:   rule.LocationLabels,
+			region:           region,
+			extraFilters:     []filter.Filter{filter.NewLabelConstraintFilter(checkerName, rule.LabelConstraints)},
+			labelPreferences: rule.LabelConstraints,
+			isTiFlash:        rule.IsTiFlash(),
+			r:                r,
+		}
+		return s.selectCandidates(ruleStores, filter.NewExcludedFilter(checkerName, nil, excluded)).PickAll()
+	}
+}
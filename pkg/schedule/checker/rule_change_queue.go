@@ -0,0 +1,146 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"time"
+
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/utils/keyutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const (
+	// maxRuleChangedRegions is the max number of the queued regions affected by the rule changes.
+	maxRuleChangedRegions = 16384
+	// ruleChangedScanLimit is the max number of regions to scan for a key range in a batch.
+	ruleChangedScanLimit = 1024
+	// ruleChangedCheckBatch is the max number of queued regions to check in a patrol tick.
+	ruleChangedCheckBatch = 128
+	// ruleChangedRegionTTL is the max duration to track a region which doesn't comply with the new rules.
+	ruleChangedRegionTTL = 10 * time.Minute
+)
+
+type ruleChangedRange struct {
+	startKey   []byte
+	endKey     []byte
+	changeTime time.Time
+}
+
+// ruleChangeQueue records the regions affected by the rule changes. The regions
+// are queued in the order of the rule changes and checked in rounds, so the earliest
+// changes are fixed first and the regions which cannot be fixed soon don't block the others.
+type ruleChangeQueue struct {
+	syncutil.Mutex
+	// ranges are the key ranges which are not scanned yet.
+	ranges []*ruleChangedRange
+	// regions records the time of the earliest rule change of each queued region.
+	regions map[uint64]time.Time
+	// order is the queued regions in the order of the rule changes, the removed
+	// regions are dropped from it when the cursor wraps around.
+	order  []uint64
+	cursor int
+}
+
+func newRuleChangeQueue() *ruleChangeQueue {
+	return &ruleChangeQueue{regions: make(map[uint64]time.Time)}
+}
+
+// push records the changed key ranges. It's called by the rule manager, so it must not block.
+func (q *ruleChangeQueue) push(ranges []*keyutil.KeyRange, now time.Time) {
+	q.Lock()
+	defer q.Unlock()
+	for _, r := range ranges {
+		q.ranges = append(q.ranges, &ruleChangedRange{startKey: r.StartKey, endKey: r.EndKey, changeTime: now})
+	}
+}
+
+// fill scans the regions of the pending key ranges and enqueues them.
+func (q *ruleChangeQueue) fill(cluster sche.CheckerCluster) {
+	q.Lock()
+	defer q.Unlock()
+	for len(q.ranges) > 0 && len(q.regions) < maxRuleChangedRegions {
+		r := q.ranges[0]
+		q.ranges = q.ranges[1:]
+		regions := cluster.ScanRegions(r.startKey, r.endKey, ruleChangedScanLimit)
+		for _, region := range regions {
+			if _, ok := q.regions[region.GetID()]; !ok {
+				q.regions[region.GetID()] = r.changeTime
+				q.order = append(q.order, region.GetID())
+			}
+		}
+		if len(regions) == 0 {
+			continue
+		}
+		// The remaining regions of the range are scanned before the later changes.
+		lastEndKey := regions[len(regions)-1].GetEndKey()
+		if len(lastEndKey) > 0 && (len(r.endKey) == 0 || bytes.Compare(lastEndKey, r.endKey) < 0) {
+			rest := &ruleChangedRange{startKey: lastEndKey, endKey: r.endKey, changeTime: r.changeTime}
+			q.ranges = append([]*ruleChangedRange{rest}, q.ranges...)
+		}
+	}
+}
+
+// pick returns at most limit queued regions after the cursor and moves the cursor
+// forward. The cursor starts over from the earliest rule change at the end of the queue.
+func (q *ruleChangeQueue) pick(limit int) ([]uint64, []time.Time) {
+	q.Lock()
+	defer q.Unlock()
+	if q.cursor >= len(q.order) {
+		q.compactLocked()
+		q.cursor = 0
+	}
+	ids := make([]uint64, 0, limit)
+	times := make([]time.Time, 0, limit)
+	for q.cursor < len(q.order) && len(ids) < limit {
+		id := q.order[q.cursor]
+		q.cursor++
+		if changeTime, ok := q.regions[id]; ok {
+			ids = append(ids, id)
+			times = append(times, changeTime)
+		}
+	}
+	return ids, times
+}
+
+// compactLocked drops the removed regions from the order.
+func (q *ruleChangeQueue) compactLocked() {
+	order := q.order[:0]
+	seen := make(map[uint64]struct{}, len(q.regions))
+	for _, id := range q.order {
+		if _, ok := q.regions[id]; !ok {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		order = append(order, id)
+	}
+	q.order = order
+}
+
+func (q *ruleChangeQueue) remove(id uint64) {
+	q.Lock()
+	defer q.Unlock()
+	delete(q.regions, id)
+}
+
+func (q *ruleChangeQueue) len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.regions)
+}
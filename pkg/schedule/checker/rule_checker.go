@@ -32,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -46,22 +47,28 @@ type RuleChecker struct {
 	PauseController
 	cluster                 sche.CheckerCluster
 	ruleManager             *placement.RuleManager
+	labeler                 *labeler.RegionLabeler
 	pendingProcessedRegions *cache.TTLUint64
 	pendingList             cache.Cache
 	switchWitnessCache      *cache.TTLUint64
 	record                  *recorder
+	repairPlanner           *RepairPlanner
+	compliance              *ruleComplianceTracker
 	r                       *rand.Rand
 }
 
 // NewRuleChecker creates a checker instance.
-func NewRuleChecker(ctx context.Context, cluster sche.CheckerCluster, ruleManager *placement.RuleManager, pendingProcessedRegions *cache.TTLUint64) *RuleChecker {
+func NewRuleChecker(ctx context.Context, cluster sche.CheckerCluster, ruleManager *placement.RuleManager, labeler *labeler.RegionLabeler, pendingProcessedRegions *cache.TTLUint64) *RuleChecker {
 	return &RuleChecker{
 		cluster:                 cluster,
 		ruleManager:             ruleManager,
+		labeler:                 labeler,
 		pendingProcessedRegions: pendingProcessedRegions,
 		pendingList:             cache.NewDefaultCache(maxPendingListLen),
 		switchWitnessCache:      cache.NewIDTTL(ctx, time.Minute, cluster.GetCheckerConfig().GetSwitchWitnessInterval()),
 		record:                  newRecord(),
+		repairPlanner:           NewRepairPlanner(cluster.GetCheckerConfig()),
+		compliance:              newRuleComplianceTracker(),
 		r:                       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
@@ -108,6 +115,7 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 
 	ruleCheckerCounter.Inc()
 	c.record.refresh(c.cluster)
+	c.compliance.observe(region.GetID(), fit)
 
 	if len(fit.RuleFits) == 0 {
 		ruleCheckerNeedSplitCounter.Inc()
@@ -133,6 +141,13 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 			return op
 		}
 	}
+	op, err = c.fixLeaderPreference(region, fit)
+	if err != nil {
+		log.Debug("fail to fix leader preference", errs.ZapError(err))
+	} else if op != nil {
+		c.pendingList.Remove(region.GetID())
+		return op
+	}
 	if c.cluster.GetCheckerConfig().IsPlacementRulesCacheEnabled() {
 		if placement.ValidateFit(fit) && placement.ValidateRegion(region) && placement.ValidateStores(fit.GetRegionStores()) {
 			// If there is no need to fix, we will cache the fit
@@ -143,6 +158,31 @@ func (c *RuleChecker) CheckWithFit(region *core.RegionInfo, fit *placement.Regio
 	return nil
 }
 
+// GetRepairPlanner returns the repair planner of the rule checker.
+func (c *RuleChecker) GetRepairPlanner() *RepairPlanner {
+	return c.repairPlanner
+}
+
+// GetRuleComplianceReport returns the compliance of all placement rules, which is
+// computed from the latest check results of the regions.
+func (c *RuleChecker) GetRuleComplianceReport() *RuleComplianceReport {
+	return &RuleComplianceReport{
+		Rules:    c.compliance.report(c.cluster, c.ruleManager.GetAllRules()),
+		FitCache: c.ruleManager.GetRuleFitCacheStats(),
+	}
+}
+
+// updateComplianceMetrics updates the metrics of the rule compliance.
+func (c *RuleChecker) updateComplianceMetrics() {
+	ruleComplianceGauge.Reset()
+	for _, rule := range c.GetRuleComplianceReport().Rules {
+		ruleComplianceGauge.WithLabelValues(rule.GroupID, rule.ID, "regions").Set(float64(rule.Regions))
+		ruleComplianceGauge.WithLabelValues(rule.GroupID, rule.ID, "satisfied").Set(float64(rule.Satisfied))
+		ruleComplianceGauge.WithLabelValues(rule.GroupID, rule.ID, "under_replicated").Set(float64(rule.UnderReplicated))
+		ruleComplianceGauge.WithLabelValues(rule.GroupID, rule.ID, "orphan_peer").Set(float64(rule.OrphanPeer))
+	}
+}
+
 // RecordRegionPromoteToNonWitness put the recently switch non-witness region into cache. RuleChecker
 // will skip switch it back to witness for a while.
 func (c *RuleChecker) RecordRegionPromoteToNonWitness(regionID uint64) {
@@ -222,6 +262,9 @@ func (c *RuleChecker) addRulePeer(region *core.RegionInfo, fit *placement.Region
 		}
 		return nil, errs.ErrNoStoreToAdd
 	}
+	if !c.allowRepair(region, rf, store) {
+		return nil, errs.ErrRepairBudgetLimited
+	}
 	peer := &metapb.Peer{StoreId: store, Role: rf.Rule.Role.MetaPeerRole(), IsWitness: isWitness}
 	op, err := operator.CreateAddPeerOperator("add-rule-peer", c.cluster, region, peer, operator.OpReplica)
 	if err != nil {
@@ -254,6 +297,9 @@ func (c *RuleChecker) replaceUnexpectedRulePeer(region *core.RegionInfo, rf *pla
 		c.handleFilterState(region, filterByTempState)
 		return nil, errs.ErrNoStoreToReplace
 	}
+	if !c.allowRepair(region, rf, store) {
+		return nil, errs.ErrRepairBudgetLimited
+	}
 	newPeer := &metapb.Peer{StoreId: store, Role: rf.Rule.Role.MetaPeerRole(), IsWitness: fastFailover}
 	//  pick the smallest leader store to avoid the Offline store be snapshot generator bottleneck.
 	var newLeader *metapb.Peer
@@ -268,7 +314,7 @@ func (c *RuleChecker) replaceUnexpectedRulePeer(region *core.RegionInfo, rf *pla
 				if region.GetDownPeer(p.GetId()) != nil || region.GetPendingPeer(p.GetId()) != nil {
 					return false
 				}
-				return c.allowLeader(fit, p)
+				return c.allowLeader(region, fit, p)
 			}
 			if minCount > count && checkPeerHealth() {
 				minCount = count
@@ -311,7 +357,7 @@ func (c *RuleChecker) fixLooseMatchPeer(region *core.RegionInfo, fit *placement.
 	}
 	if region.GetLeader().GetId() != peer.GetId() && rf.Rule.Role == placement.Leader {
 		ruleCheckerFixLeaderRoleCounter.Inc()
-		if c.allowLeader(fit, peer) {
+		if c.allowLeader(region, fit, peer) {
 			return operator.CreateTransferLeaderOperator("fix-leader-role", c.cluster, region, peer.GetStoreId(), []uint64{}, 0)
 		}
 		ruleCheckerNotAllowLeaderCounter.Inc()
@@ -320,7 +366,7 @@ func (c *RuleChecker) fixLooseMatchPeer(region *core.RegionInfo, fit *placement.
 	if region.GetLeader().GetId() == peer.GetId() && rf.Rule.Role == placement.Follower {
 		ruleCheckerFixFollowerRoleCounter.Inc()
 		for _, p := range region.GetPeers() {
-			if c.allowLeader(fit, p) {
+			if c.allowLeader(region, fit, p) {
 				return operator.CreateTransferLeaderOperator("fix-follower-role", c.cluster, region, p.GetStoreId(), []uint64{}, 0)
 			}
 		}
@@ -361,7 +407,7 @@ func (c *RuleChecker) fixLooseMatchPeer(region *core.RegionInfo, fit *placement.
 	return nil, nil
 }
 
-func (c *RuleChecker) allowLeader(fit *placement.RegionFit, peer *metapb.Peer) bool {
+func (c *RuleChecker) allowLeader(region *core.RegionInfo, fit *placement.RegionFit, peer *metapb.Peer) bool {
 	if core.IsLearner(peer) || core.IsWitness(peer) {
 		return false
 	}
@@ -373,6 +419,11 @@ func (c *RuleChecker) allowLeader(fit *placement.RegionFit, peer *metapb.Peer) b
 	if !stateFilter.Target(c.cluster.GetCheckerConfig(), s).IsOK() {
 		return false
 	}
+	// The leader should be kept in the zone of the `leader_zone` label policy.
+	if c.labeler != nil &&
+		!c.labeler.GetSchedulingPolicy(region).AllowLeaderOnStore(s, c.cluster.GetCheckerConfig().GetLeaderZoneLabel()) {
+		return false
+	}
 	for _, rf := range fit.RuleFits {
 		if (rf.Rule.Role == placement.Leader || rf.Rule.Role == placement.Voter) &&
 			placement.MatchLabelConstraints(s, rf.Rule.LabelConstraints) {
@@ -382,6 +433,39 @@ func (c *RuleChecker) allowLeader(fit *placement.RegionFit, peer *metapb.Peer) b
 	return false
 }
 
+// fixLeaderPreference transfers the leader to the most preferred store of the
+// leader preferences. The less preferred stores are the fallback when the more
+// preferred ones are unavailable.
+func (c *RuleChecker) fixLeaderPreference(region *core.RegionInfo, fit *placement.RegionFit) (*operator.Operator, error) {
+	preferences := placement.GetLeaderPreferences(fit.GetRules())
+	if len(preferences) == 0 {
+		return nil, nil
+	}
+	for _, rf := range fit.RuleFits {
+		// The leader is already pinned by the leader rule.
+		if rf.Rule.Role == placement.Leader {
+			return nil, nil
+		}
+	}
+	leader := region.GetLeader()
+	targetRank := placement.LeaderPreferenceRank(c.cluster.GetStore(leader.GetStoreId()), preferences)
+	var target *metapb.Peer
+	for _, peer := range region.GetVoters() {
+		if peer.GetId() == leader.GetId() || c.isDownPeer(region, peer) ||
+			region.GetPendingPeer(peer.GetId()) != nil || !c.allowLeader(region, fit, peer) {
+			continue
+		}
+		if rank := placement.LeaderPreferenceRank(c.cluster.GetStore(peer.GetStoreId()), preferences); rank < targetRank {
+			target, targetRank = peer, rank
+		}
+	}
+	if target == nil {
+		return nil, nil
+	}
+	ruleCheckerFixLeaderPreferenceCounter.Inc()
+	return operator.CreateTransferLeaderOperator("fix-leader-preference", c.cluster, region, target.GetStoreId(), []uint64{}, 0)
+}
+
 func (c *RuleChecker) fixBetterLocation(region *core.RegionInfo, fit *placement.RegionFit, rf *placement.RuleFit) (*operator.Operator, error) {
 	if len(rf.Rule.LocationLabels) == 0 {
 		return nil, nil
@@ -572,6 +656,37 @@ func (c *RuleChecker) fixOrphanPeers(region *core.RegionInfo, fit *placement.Reg
 	return nil, nil
 }
 
+// allowRepair checks whether the region can be repaired by adding a peer on the target store now.
+// The region will be checked again soon if it has to wait for the cross location repair budget.
+func (c *RuleChecker) allowRepair(region *core.RegionInfo, rf *placement.RuleFit, targetStoreID uint64) bool {
+	healthy := 0
+	for _, peer := range rf.Peers {
+		if !c.isDownPeer(region, peer) && !c.isOfflinePeer(peer) {
+			healthy++
+		}
+	}
+	if c.repairPlanner.Allow(region, healthy, c.isCrossLocation(region, rf.Rule, targetStoreID)) {
+		return true
+	}
+	c.pendingProcessedRegions.Put(region.GetID(), nil)
+	return false
+}
+
+// isCrossLocation returns true if the snapshot sent from the leader to the target store
+// crosses the top level location label of the rule.
+func (c *RuleChecker) isCrossLocation(region *core.RegionInfo, rule *placement.Rule, targetStoreID uint64) bool {
+	if len(rule.LocationLabels) == 0 {
+		return false
+	}
+	source := c.cluster.GetStore(region.GetLeader().GetStoreId())
+	target := c.cluster.GetStore(targetStoreID)
+	if source == nil || target == nil {
+		return false
+	}
+	label := rule.LocationLabels[0]
+	return source.GetLabelValue(label) != target.GetLabelValue(label)
+}
+
 func (c *RuleChecker) isDownPeer(region *core.RegionInfo, peer *metapb.Peer) bool {
 	for _, stats := range region.GetDownPeers() {
 		if stats.GetPeer().GetId() == peer.GetId() {
@@ -629,14 +744,16 @@ func (c *RuleChecker) hasAvailableWitness(region *core.RegionInfo, peer *metapb.
 
 func (c *RuleChecker) strategy(r *rand.Rand, region *core.RegionInfo, rule *placement.Rule, fastFailover bool) *ReplicaStrategy {
 	return &ReplicaStrategy{
-		checkerName:    c.Name(),
-		cluster:        c.cluster,
-		isolationLevel: rule.IsolationLevel,
-		locationLabels: rule.LocationLabels,
-		region:         region,
-		extraFilters:   []filter.Filter{filter.NewLabelConstraintFilter(c.Name(), rule.LabelConstraints)},
-		fastFailover:   fastFailover,
-		r:              r,
+		checkerName:      c.Name(),
+		cluster:          c.cluster,
+		isolationLevel:   rule.IsolationLevel,
+		locationLabels:   rule.LocationLabels,
+		region:           region,
+		extraFilters:     []filter.Filter{filter.NewLabelConstraintFilter(c.Name(), rule.LabelConstraints)},
+		labelPreferences: rule.LabelConstraints,
+		fastFailover:     fastFailover,
+		isTiFlash:        rule.IsTiFlash(),
+		r:                r,
 	}
 }
 
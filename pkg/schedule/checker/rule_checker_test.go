@@ -16,12 +16,14 @@ package checker
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/failpoint"
@@ -34,8 +36,12 @@ import (
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/utils/keyutil"
 	"github.com/tikv/pd/pkg/utils/operatorutil"
 	"github.com/tikv/pd/pkg/versioninfo"
 )
@@ -63,13 +69,50 @@ func (suite *ruleCheckerTestSuite) SetupTest() {
 	suite.cluster.SetEnableWitness(true)
 	suite.cluster.SetEnableUseJointConsensus(false)
 	suite.ruleManager = suite.cluster.RuleManager
-	suite.rc = NewRuleChecker(suite.ctx, suite.cluster, suite.ruleManager, cache.NewIDTTL(suite.ctx, time.Minute, 3*time.Minute))
+	suite.rc = NewRuleChecker(suite.ctx, suite.cluster, suite.ruleManager, suite.cluster.GetRegionLabeler(), cache.NewIDTTL(suite.ctx, time.Minute, 3*time.Minute))
 }
 
 func (suite *ruleCheckerTestSuite) TearDownTest() {
 	suite.cancel()
 }
 
+func (suite *ruleCheckerTestSuite) TestSimulationTargetSelector() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(3, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(4, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLabelsStore(5, 1, map[string]string{"zone": "z4"})
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 1, 3)
+	suite.cluster.SetStoreDown(4)
+	groups := []placement.GroupBundle{{
+		ID: placement.DefaultGroupID,
+		Rules: []*placement.Rule{{
+			GroupID:        placement.DefaultGroupID,
+			ID:             placement.DefaultRuleID,
+			Role:           placement.Voter,
+			Count:          3,
+			LocationLabels: []string{"zone"},
+			IsolationLevel: "zone",
+		}},
+	}}
+	simulate := func() *placement.SimulationResult {
+		res, err := suite.ruleManager.SimulateGroupBundles(suite.cluster, suite.cluster.GetRegions(),
+			groups, true, NewSimulationTargetSelector(suite.cluster))
+		re.NoError(err)
+		return res
+	}
+	// Store 2 breaks the isolation level and store 4 is down.
+	res := simulate()
+	re.Zero(res.UnsatisfiableCount)
+	re.Equal([]*placement.StoreSimulation{{StoreID: 5, AddPeers: 1}}, res.Stores)
+
+	suite.cluster.SetStoreDown(5)
+	res = simulate()
+	re.Equal(1, res.UnsatisfiableCount)
+	re.Empty(res.Stores)
+}
+
 func (suite *ruleCheckerTestSuite) TestAddRulePeer() {
 	re := suite.Require()
 	suite.cluster.AddLeaderStore(1, 1)
@@ -83,6 +126,44 @@ func (suite *ruleCheckerTestSuite) TestAddRulePeer() {
 	re.Equal(uint64(3), op.Step(0).(operator.AddLearner).ToStore)
 }
 
+func (suite *ruleCheckerTestSuite) TestAddRulePeerWithLabelPreference() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 10, map[string]string{"disk": "ssd"})
+	suite.cluster.AddLabelsStore(2, 10, map[string]string{"disk": "ssd"})
+	suite.cluster.AddLabelsStore(3, 10, map[string]string{"disk": "ssd"})
+	suite.cluster.AddLabelsStore(4, 12, map[string]string{"disk": "nvme"})
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 1, 2)
+	err := suite.ruleManager.SetRule(&placement.Rule{
+		GroupID: placement.DefaultGroupID,
+		ID:      placement.DefaultRuleID,
+		Role:    placement.Voter,
+		Count:   3,
+		LabelConstraints: []placement.LabelConstraint{
+			{Key: "disk", Op: placement.In, Values: []string{"nvme"}, Weight: 5},
+		},
+	})
+	re.NoError(err)
+	// The preferred store wins even if it has a bit more regions.
+	op := suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("add-rule-peer", op.Desc())
+	re.Equal(uint64(4), op.Step(0).(operator.AddLearner).ToStore)
+
+	// The preference does not override the region score if the preferred store
+	// is much more loaded.
+	suite.cluster.AddLabelsStore(4, 20, map[string]string{"disk": "nvme"})
+	op = suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal(uint64(3), op.Step(0).(operator.AddLearner).ToStore)
+	suite.cluster.AddLabelsStore(4, 12, map[string]string{"disk": "nvme"})
+
+	// Fall back to the other stores if the preferred store is unavailable.
+	suite.cluster.SetStoreDisconnect(4)
+	op = suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal(uint64(3), op.Step(0).(operator.AddLearner).ToStore)
+}
+
 func (suite *ruleCheckerTestSuite) TestAddRulePeerWithIsolationLevel() {
 	re := suite.Require()
 	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1", "rack": "r1", "host": "h1"})
@@ -159,6 +240,67 @@ func (suite *ruleCheckerTestSuite) TestReplaceDownPeerWithIsolationLevel() {
 	re.Nil(op)
 }
 
+func (suite *ruleCheckerTestSuite) TestRepairBudget() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(3, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLabelsStore(4, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(5, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLeaderRegionWithRange(1, "", "a", 1, 2, 3)
+	suite.cluster.AddLeaderRegionWithRange(2, "a", "", 1, 2, 3)
+	err := suite.ruleManager.SetRule(&placement.Rule{
+		GroupID:        placement.DefaultGroupID,
+		ID:             placement.DefaultRuleID,
+		Role:           placement.Voter,
+		Count:          3,
+		LocationLabels: []string{"zone"},
+	})
+	re.NoError(err)
+	suite.cluster.SetCrossLocationRepairSizePerMinute(1)
+	suite.cluster.SetStoreDown(2)
+	suite.cluster.SetStoreDown(3)
+	r1 := suite.cluster.GetRegion(1)
+	r1 = r1.Clone(core.WithDownPeers([]*pdpb.PeerStats{{Peer: r1.GetStorePeer(3), DownSeconds: 60000}}))
+	r2 := suite.cluster.GetRegion(2)
+	r2 = r2.Clone(core.WithDownPeers([]*pdpb.PeerStats{
+		{Peer: r2.GetStorePeer(2), DownSeconds: 60000},
+		{Peer: r2.GetStorePeer(3), DownSeconds: 60000},
+	}))
+
+	// the budget is not consumed until the operator is added.
+	re.NotNil(suite.rc.Check(r1))
+	re.NotNil(suite.rc.Check(r2))
+	re.Zero(suite.rc.GetRepairPlanner().GetRepairProgress().RepairedRegions)
+
+	// at least one region can be repaired in each window.
+	op := suite.rc.Check(r1)
+	re.NotNil(op)
+	suite.rc.GetRepairPlanner().Charge(op)
+	re.Nil(suite.rc.Check(r2))
+	re.True(suite.rc.pendingProcessedRegions.Exists(r2.GetID()))
+	progress := suite.rc.GetRepairPlanner().GetRepairProgress()
+	re.Equal(uint64(1), progress.CrossLocationSizeLimit)
+	re.Equal(map[int]int{1: 1}, progress.WaitingRegions)
+
+	// the region with only one healthy replica is repaired first in the next window.
+	suite.rc.repairPlanner.windowStart = time.Time{}
+	re.Nil(suite.rc.Check(r1))
+	op = suite.rc.Check(r2)
+	re.NotNil(op)
+	suite.rc.GetRepairPlanner().Charge(op)
+	progress = suite.rc.GetRepairPlanner().GetRepairProgress()
+	re.Equal(map[int]int{2: 1}, progress.WaitingRegions)
+	re.Equal(uint64(2), progress.RepairedRegions)
+
+	// no limit
+	suite.cluster.SetCrossLocationRepairSizePerMinute(0)
+	op = suite.rc.Check(r1)
+	re.NotNil(op)
+	suite.rc.GetRepairPlanner().Charge(op)
+	re.Empty(suite.rc.GetRepairPlanner().GetRepairProgress().WaitingRegions)
+}
+
 func (suite *ruleCheckerTestSuite) TestFixPeer() {
 	re := suite.Require()
 	suite.cluster.AddLeaderStore(1, 1)
@@ -411,6 +553,84 @@ func (suite *ruleCheckerTestSuite) TestFixRoleLeader() {
 	re.Equal(uint64(3), op.Step(0).(operator.TransferLeader).ToStore)
 }
 
+func (suite *ruleCheckerTestSuite) TestLeaderZone() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(3, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 1, 2, 3)
+	err := suite.ruleManager.SetRuleGroup(&placement.RuleGroup{
+		ID: placement.DefaultGroupID,
+		LeaderPreferences: []placement.LeaderPreference{
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z3"}}}},
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z2"}}}},
+		},
+	})
+	re.NoError(err)
+	setLeaderZone := func(zone string) {
+		re.NoError(suite.cluster.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+			ID:       "leader-zone",
+			Labels:   []labeler.RegionLabel{{Key: labeler.LeaderZoneLabel, Value: zone}},
+			RuleType: labeler.KeyRange,
+			Data:     labeler.MakeKeyRanges("", ""),
+		}))
+	}
+
+	// The leader is not transferred out of the zone of the label policy.
+	setLeaderZone("z2")
+	op := suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("fix-leader-preference", op.Desc())
+	re.Equal(uint64(2), op.Step(0).(operator.TransferLeader).ToStore)
+	setLeaderZone("z1")
+	re.Nil(suite.rc.Check(suite.cluster.GetRegion(1)))
+}
+
+func (suite *ruleCheckerTestSuite) TestFixLeaderPreference() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"zone": "z2"})
+	suite.cluster.AddLabelsStore(3, 1, map[string]string{"zone": "z3"})
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 1, 2, 3)
+	err := suite.ruleManager.SetRuleGroup(&placement.RuleGroup{
+		ID: placement.DefaultGroupID,
+		LeaderPreferences: []placement.LeaderPreference{
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z3"}}}},
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z2"}}}},
+		},
+	})
+	re.NoError(err)
+	op := suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("fix-leader-preference", op.Desc())
+	re.Equal(uint64(3), op.Step(0).(operator.TransferLeader).ToStore)
+
+	// Fall back to the next preference.
+	suite.cluster.SetStoreDisconnect(3)
+	op = suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal(uint64(2), op.Step(0).(operator.TransferLeader).ToStore)
+
+	// The leader is already on the most preferred available store.
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 2, 1, 3)
+	re.Nil(suite.rc.Check(suite.cluster.GetRegion(1)))
+
+	// The preferences of the rule take precedence over the group.
+	err = suite.ruleManager.SetRule(&placement.Rule{
+		GroupID: placement.DefaultGroupID,
+		ID:      placement.DefaultRuleID,
+		Role:    placement.Voter,
+		Count:   3,
+		LeaderPreferences: []placement.LeaderPreference{
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z1"}}}},
+		},
+	})
+	re.NoError(err)
+	op = suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal(uint64(1), op.Step(0).(operator.TransferLeader).ToStore)
+}
+
 func (suite *ruleCheckerTestSuite) TestFixRoleLeaderIssue3130() {
 	re := suite.Require()
 	suite.cluster.AddLabelsStore(1, 1, map[string]string{"role": "follower"})
@@ -2008,6 +2228,45 @@ func (suite *ruleCheckerTestSuite) TestPendingList() {
 	re.False(exist)
 }
 
+func (suite *ruleCheckerTestSuite) TestExplainRegion() {
+	re := suite.Require()
+	// no enough store
+	suite.cluster.AddLeaderStore(1, 1)
+	suite.cluster.AddLeaderRegionWithRange(1, "", "", 1, 2)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, suite.cluster, false /* no need to run */)
+	oc := operator.NewController(suite.ctx, suite.cluster.GetBasicCluster(), suite.cluster.GetSharedConfig(), stream)
+	c := NewController(suite.ctx, suite.cluster, suite.cluster.GetCheckerConfig(), suite.ruleManager, suite.cluster.GetRegionLabeler(), oc, nil)
+	ruleResult := func(results []*CheckResult) *CheckResult {
+		for _, result := range results {
+			if result.Type == types.RuleChecker {
+				return result
+			}
+		}
+		return nil
+	}
+	re.Equal(CheckNormal, ruleResult(c.ExplainRegion(suite.cluster.GetRegion(1))).Status)
+	_, exist := c.ruleChecker.pendingList.Get(1)
+	re.False(exist)
+	re.Nil(c.ruleChecker.Check(suite.cluster.GetRegion(1)))
+	_, exist = c.ruleChecker.pendingList.Get(1)
+	re.True(exist)
+
+	// Explaining the region does not remove it from the pending list of the patrol.
+	suite.cluster.AddLeaderStore(2, 1)
+	suite.cluster.AddLeaderStore(3, 1)
+	result := ruleResult(c.ExplainRegion(suite.cluster.GetRegion(1)))
+	re.Equal(CheckScheduling, result.Status)
+	re.Len(result.Operators, 1)
+	re.Equal("add-rule-peer", result.Operators[0].Desc())
+	re.Nil(oc.GetOperator(1))
+	_, exist = c.ruleChecker.pendingList.Get(1)
+	re.True(exist)
+
+	// The pause is judged by the checker of the patrol.
+	c.ruleChecker.PauseOrResume(60)
+	re.Equal(CheckPaused, ruleResult(c.ExplainRegion(suite.cluster.GetRegion(1))).Status)
+}
+
 func (suite *ruleCheckerTestSuite) TestLocationLabels() {
 	re := suite.Require()
 	suite.cluster.AddLabelsStore(1, 1, map[string]string{"zone": "z1", "rack": "r1", "host": "h1"})
@@ -2107,6 +2366,49 @@ func (suite *ruleCheckerTestSuite) TestTiFlashLocationLabels() {
 	re.Nil(op)
 }
 
+func (suite *ruleCheckerTestSuite) TestTiFlashReplicaStrategy() {
+	re := suite.Require()
+	suite.cluster.AddLabelsStore(1, 1, map[string]string{"host": "h1"})
+	suite.cluster.AddLabelsStore(2, 1, map[string]string{"host": "h2"})
+	suite.cluster.AddLabelsStore(3, 10, map[string]string{"host": "h3"})
+	suite.cluster.AddLabelsStore(4, 10, map[string]string{"host": "h4", "engine": "tiflash"})
+	suite.cluster.AddLabelsStore(5, 0, map[string]string{"host": "h5", "engine": "tiflash_compute"})
+	suite.cluster.UpdateStorageRatio(4, 0.5, 0.5)
+
+	// the compute node is never selected even if it matches the rule and has the least region score.
+	rule := suite.ruleManager.GetRule(placement.DefaultGroupID, placement.DefaultRuleID)
+	rule.LabelConstraints = []placement.LabelConstraint{
+		{Key: core.EngineKey, Op: placement.NotIn, Values: []string{core.EngineTiFlash}},
+	}
+	re.NoError(suite.ruleManager.SetRule(rule))
+	suite.cluster.AddLeaderRegion(1, 1, 2)
+	op := suite.rc.Check(suite.cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("add-rule-peer", op.Desc())
+	re.Equal(uint64(3), op.Step(0).(operator.AddLearner).ToStore)
+
+	err := suite.ruleManager.SetRule(&placement.Rule{
+		GroupID: "tiflash",
+		ID:      "learner",
+		Role:    placement.Learner,
+		Count:   1,
+		LabelConstraints: []placement.LabelConstraint{
+			{Key: core.EngineKey, Op: placement.In, Values: []string{core.EngineTiFlash}},
+		},
+	})
+	re.NoError(err)
+	// 10GiB region, the TiFlash store has 50GiB available and reserves 20GiB.
+	region := suite.cluster.AddLeaderRegion(2, 1, 2, 3)
+	suite.cluster.PutRegion(region.Clone(core.SetApproximateSize(10 * units.GiB / units.MiB)))
+	op = suite.rc.Check(suite.cluster.GetRegion(2))
+	re.NotNil(op)
+	re.Equal(uint64(4), op.Step(0).(operator.AddLearner).ToStore)
+
+	// the columnar replica is estimated to be 40GiB.
+	suite.cluster.SetTiFlashReplicaSizeRatio(4)
+	re.Nil(suite.rc.Check(suite.cluster.GetRegion(2)))
+}
+
 type ruleCheckerTestAdvancedSuite struct {
 	suite.Suite
 	cluster     *mockcluster.Cluster
@@ -2125,7 +2427,7 @@ func (suite *ruleCheckerTestAdvancedSuite) SetupTest() {
 	suite.cluster.SetEnableWitness(true)
 	suite.cluster.SetEnableUseJointConsensus(true)
 	suite.ruleManager = suite.cluster.RuleManager
-	suite.rc = NewRuleChecker(suite.ctx, suite.cluster, suite.ruleManager, cache.NewIDTTL(suite.ctx, time.Minute, 3*time.Minute))
+	suite.rc = NewRuleChecker(suite.ctx, suite.cluster, suite.ruleManager, suite.cluster.GetRegionLabeler(), cache.NewIDTTL(suite.ctx, time.Minute, 3*time.Minute))
 }
 
 func (suite *ruleCheckerTestAdvancedSuite) TearDownTest() {
@@ -2410,3 +2712,142 @@ func (suite *ruleCheckerTestSuite) TestFixBetterLocationEngineConstraint() {
 	op = suite.rc.Check(region2)
 	re.Empty(op)
 }
+
+func (suite *ruleCheckerTestSuite) TestRuleChangedRegions() {
+	re := suite.Require()
+	for i := uint64(1); i <= 4; i++ {
+		suite.cluster.AddLeaderStore(i, 1)
+	}
+	suite.cluster.AddLeaderRegionWithRange(1, "", "a", 1, 2, 3)
+	suite.cluster.AddLeaderRegionWithRange(2, "a", "b", 1, 2, 3)
+	suite.cluster.AddLeaderRegionWithRange(3, "b", "", 1, 2, 3)
+	stream := hbstream.NewTestHeartbeatStreams(suite.ctx, suite.cluster, false /* no need to run */)
+	oc := operator.NewController(suite.ctx, suite.cluster.GetBasicCluster(), suite.cluster.GetSharedConfig(), stream)
+	c := NewController(suite.ctx, suite.cluster, suite.cluster.GetCheckerConfig(), suite.ruleManager, suite.cluster.GetRegionLabeler(), oc, nil)
+
+	// Only the region in the range of the new rule is enqueued.
+	err := suite.ruleManager.SetRule(&placement.Rule{
+		GroupID:     "test",
+		ID:          "1",
+		StartKeyHex: hex.EncodeToString([]byte("a")),
+		EndKeyHex:   hex.EncodeToString([]byte("b")),
+		Role:        placement.Voter,
+		Count:       1,
+	})
+	re.NoError(err)
+	c.checkRuleChangedRegions()
+	re.Equal(1, c.GetRuleChangedRegionCount())
+	op := oc.GetOperator(2)
+	re.NotNil(op)
+	re.Equal("add-rule-peer", op.Desc())
+	re.Nil(oc.GetOperator(1))
+	re.Nil(oc.GetOperator(3))
+
+	// The region being scheduled is skipped, and it is removed from the queue after
+	// it complies with the rules.
+	suite.cluster.AddLeaderRegionWithRange(2, "a", "b", 1, 2, 3, 4)
+	c.checkRuleChangedRegions()
+	re.Equal(1, c.GetRuleChangedRegionCount())
+	re.True(oc.RemoveOperator(op))
+	c.checkRuleChangedRegions()
+	re.Zero(c.GetRuleChangedRegionCount())
+
+	// The regions of the rule which is not changed are not enqueued again.
+	err = suite.ruleManager.SetRule(suite.ruleManager.GetRule("test", "1").Clone())
+	re.NoError(err)
+	c.checkRuleChangedRegions()
+	re.Zero(c.GetRuleChangedRegionCount())
+
+	// The regions which never comply with the rules are dropped after the TTL.
+	err = suite.ruleManager.SetRule(&placement.Rule{
+		GroupID:     "test",
+		ID:          "2",
+		StartKeyHex: hex.EncodeToString([]byte("b")),
+		Role:        placement.Voter,
+		Count:       5,
+	})
+	re.NoError(err)
+	c.checkRuleChangedRegions()
+	re.Equal(1, c.GetRuleChangedRegionCount())
+	c.ruleChanges.regions[3] = time.Now().Add(-ruleChangedRegionTTL)
+	c.checkRuleChangedRegions()
+	re.Zero(c.GetRuleChangedRegionCount())
+}
+
+func (suite *ruleCheckerTestSuite) TestRuleChangeQueuePick() {
+	re := suite.Require()
+	for i := uint64(1); i <= 5; i++ {
+		suite.cluster.AddLeaderRegionWithRange(i, fmt.Sprintf("%d", i), fmt.Sprintf("%d", i+1), 1)
+	}
+	q := newRuleChangeQueue()
+	now := time.Now()
+	q.push([]*keyutil.KeyRange{{StartKey: []byte("3"), EndKey: []byte("6")}}, now)
+	q.push([]*keyutil.KeyRange{{StartKey: []byte("1"), EndKey: []byte("3")}}, now.Add(time.Second))
+	q.fill(suite.cluster)
+	re.Equal(5, q.len())
+
+	// The regions are picked in the order of the rule changes, and the ones which
+	// are not removed don't block the others.
+	ids, times := q.pick(2)
+	re.Equal([]uint64{3, 4}, ids)
+	re.Equal([]time.Time{now, now}, times)
+	ids, _ = q.pick(2)
+	re.Equal([]uint64{5, 1}, ids)
+	q.remove(3)
+	ids, times = q.pick(2)
+	re.Equal([]uint64{2}, ids)
+	re.Equal([]time.Time{now.Add(time.Second)}, times)
+	// The cursor starts over at the end of the queue.
+	ids, _ = q.pick(2)
+	re.Equal([]uint64{4, 5}, ids)
+	re.Equal(4, q.len())
+}
+
+func (suite *ruleCheckerTestSuite) TestRuleComplianceReport() {
+	re := suite.Require()
+	for i := uint64(1); i <= 4; i++ {
+		suite.cluster.AddLeaderStore(i, 1)
+	}
+	err := suite.ruleManager.SetRule(&placement.Rule{
+		GroupID:     "test",
+		ID:          "1",
+		StartKeyHex: hex.EncodeToString([]byte("b")),
+		Role:        placement.Learner,
+		Count:       1,
+	})
+	re.NoError(err)
+	suite.cluster.AddLeaderRegionWithRange(1, "", "a", 1, 2, 3)
+	suite.cluster.AddLeaderRegionWithRange(2, "a", "b", 1, 2)
+	suite.cluster.AddLeaderRegionWithRange(3, "b", "c", 1, 2, 3)
+	suite.cluster.AddLeaderRegionWithRange(4, "c", "", 1, 2, 3, 4)
+	for i := uint64(1); i <= 4; i++ {
+		suite.rc.Check(suite.cluster.GetRegion(i))
+	}
+	checkCompliance := func(expect [][4]int) {
+		report := suite.rc.GetRuleComplianceReport()
+		re.Len(report.Rules, len(expect))
+		for i, rule := range report.Rules {
+			re.Equal(expect[i], [4]int{rule.Regions, rule.Satisfied, rule.UnderReplicated, rule.OrphanPeer}, rule.ID)
+		}
+	}
+	// region 2 lacks a voter, region 3 lacks a learner and region 4 has a voter to be a learner.
+	checkCompliance([][4]int{{4, 3, 1, 0}, {2, 0, 1, 0}})
+	report := suite.rc.GetRuleComplianceReport()
+	re.Equal(placement.DefaultGroupID, report.Rules[0].GroupID)
+	re.InDelta(0.75, report.Rules[0].ComplianceRatio, 1e-9)
+
+	// The result is updated incrementally.
+	suite.cluster.AddLeaderRegionWithRange(2, "a", "b", 1, 2, 3)
+	suite.rc.Check(suite.cluster.GetRegion(2))
+	checkCompliance([][4]int{{4, 4, 0, 0}, {2, 0, 1, 0}})
+
+	// The region with orphan peers.
+	err = suite.ruleManager.DeleteRule("test", "1")
+	re.NoError(err)
+	suite.rc.Check(suite.cluster.GetRegion(4))
+	checkCompliance([][4]int{{4, 3, 0, 1}})
+
+	// The removed regions are dropped.
+	suite.cluster.RemoveRegion(suite.cluster.GetRegion(4))
+	checkCompliance([][4]int{{3, 3, 0, 0}})
+}
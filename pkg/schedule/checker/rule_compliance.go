@@ -0,0 +1,144 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// RuleCompliance is the compliance of the regions matching a placement rule.
+type RuleCompliance struct {
+	GroupID string `json:"group_id"`
+	ID      string `json:"id"`
+	// Regions is the number of the checked regions which match the rule.
+	Regions int `json:"regions"`
+	// Satisfied is the number of the regions which satisfy the rule and have no orphan peers.
+	Satisfied int `json:"satisfied"`
+	// UnderReplicated is the number of the regions which have fewer peers than the rule requires.
+	UnderReplicated int `json:"under_replicated"`
+	// OrphanPeer is the number of the regions which have peers matching no rules.
+	OrphanPeer int `json:"orphan_peer"`
+	// ComplianceRatio is the ratio of the satisfied regions, it's 0 if no region is checked.
+	ComplianceRatio float64 `json:"compliance_ratio"`
+}
+
+// RuleComplianceReport is the compliance of all placement rules.
+type RuleComplianceReport struct {
+	Rules    []*RuleCompliance           `json:"rules"`
+	FitCache placement.RuleFitCacheStats `json:"fit_cache"`
+}
+
+type ruleComplianceState struct {
+	key             [2]string
+	satisfied       bool
+	underReplicated bool
+	orphanPeer      bool
+}
+
+type ruleComplianceCounts struct {
+	regions         int
+	satisfied       int
+	underReplicated int
+	orphanPeer      int
+}
+
+// ruleComplianceTracker records the latest rule checker result of each region, and
+// maintains the compliance of each rule incrementally.
+type ruleComplianceTracker struct {
+	syncutil.Mutex
+	regions map[uint64][]ruleComplianceState
+	rules   map[[2]string]*ruleComplianceCounts
+}
+
+func newRuleComplianceTracker() *ruleComplianceTracker {
+	return &ruleComplianceTracker{
+		regions: make(map[uint64][]ruleComplianceState),
+		rules:   make(map[[2]string]*ruleComplianceCounts),
+	}
+}
+
+// observe replaces the result of the region with the given fit.
+func (t *ruleComplianceTracker) observe(regionID uint64, fit *placement.RegionFit) {
+	states := make([]ruleComplianceState, 0, len(fit.RuleFits))
+	orphanPeer := len(fit.OrphanPeers) > 0
+	for _, rf := range fit.RuleFits {
+		states = append(states, ruleComplianceState{
+			key:             rf.Rule.Key(),
+			satisfied:       rf.IsSatisfied() && !orphanPeer,
+			underReplicated: len(rf.Peers) < rf.Rule.Count,
+			orphanPeer:      orphanPeer,
+		})
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.applyLocked(t.regions[regionID], -1)
+	if len(states) == 0 {
+		delete(t.regions, regionID)
+		return
+	}
+	t.applyLocked(states, 1)
+	t.regions[regionID] = states
+}
+
+func (t *ruleComplianceTracker) applyLocked(states []ruleComplianceState, delta int) {
+	for _, s := range states {
+		counts, ok := t.rules[s.key]
+		if !ok {
+			counts = &ruleComplianceCounts{}
+			t.rules[s.key] = counts
+		}
+		counts.regions += delta
+		if s.satisfied {
+			counts.satisfied += delta
+		}
+		if s.underReplicated {
+			counts.underReplicated += delta
+		}
+		if s.orphanPeer {
+			counts.orphanPeer += delta
+		}
+		if counts.regions == 0 {
+			delete(t.rules, s.key)
+		}
+	}
+}
+
+// report returns the compliance of the given rules. The regions which no longer
+// exist are dropped first.
+func (t *ruleComplianceTracker) report(cluster sche.CheckerCluster, rules []*placement.Rule) []*RuleCompliance {
+	t.Lock()
+	defer t.Unlock()
+	for id, states := range t.regions {
+		if cluster.GetRegion(id) == nil {
+			t.applyLocked(states, -1)
+			delete(t.regions, id)
+		}
+	}
+	res := make([]*RuleCompliance, 0, len(rules))
+	for _, rule := range rules {
+		compliance := &RuleCompliance{GroupID: rule.GroupID, ID: rule.ID}
+		if counts, ok := t.rules[rule.Key()]; ok {
+			compliance.Regions = counts.regions
+			compliance.Satisfied = counts.satisfied
+			compliance.UnderReplicated = counts.underReplicated
+			compliance.OrphanPeer = counts.orphanPeer
+			compliance.ComplianceRatio = float64(counts.satisfied) / float64(counts.regions)
+		}
+		res = append(res, compliance)
+	}
+	return res
+}
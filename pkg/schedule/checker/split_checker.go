@@ -70,7 +70,7 @@ func (c *SplitChecker) Check(region *core.RegionInfo) *operator.Operator {
 	}
 
 	if len(keys) == 0 {
-		return nil
+		return c.checkPolicyMaxSize(region)
 	}
 
 	op, err := operator.CreateSplitRegionOperator(desc, region, 0, pdpb.CheckPolicy_USEKEY, keys)
@@ -80,3 +80,19 @@ func (c *SplitChecker) Check(region *core.RegionInfo) *operator.Operator {
 	}
 	return op
 }
+
+// checkPolicyMaxSize splits the region into halves if it is larger than the
+// `max_region_size` set by the label rules.
+func (c *SplitChecker) checkPolicyMaxSize(region *core.RegionInfo) *operator.Operator {
+	policy := c.labeler.GetSchedulingPolicy(region)
+	if policy.MaxRegionSize == 0 || uint64(region.GetApproximateSize()) <= policy.MaxRegionSize {
+		return nil
+	}
+	splitCheckerPolicySizeCounter.Inc()
+	op, err := operator.CreateSplitRegionOperator("policy-split-region", region, 0, pdpb.CheckPolicy_APPROXIMATE, nil)
+	if err != nil {
+		log.Debug("create split region operator failed", errs.ZapError(err))
+		return nil
+	}
+	return op
+}
@@ -21,6 +21,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/pingcap/kvproto/pkg/pdpb"
+
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
 	"github.com/tikv/pd/pkg/schedule/labeler"
@@ -71,3 +74,34 @@ func TestSplit(t *testing.T) {
 	re.Equal("bb", hex.EncodeToString(splitKeys[0]))
 	re.Equal("dd", hex.EncodeToString(splitKeys[1]))
 }
+
+func TestSplitByPolicySize(t *testing.T) {
+	re := require.New(t)
+	cfg := mockconfig.NewTestOptions()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster := mockcluster.NewCluster(ctx, cfg)
+	regionLabeler := cluster.RegionLabeler
+	sc := NewSplitChecker(cluster, cluster.RuleManager, regionLabeler)
+	cluster.AddLeaderStore(1, 1)
+	cluster.AddLeaderRegionWithRange(1, "", "", 1)
+	cluster.PutRegion(cluster.GetRegion(1).Clone(core.SetApproximateSize(100)))
+	re.Nil(sc.Check(cluster.GetRegion(1)))
+
+	err := regionLabeler.SetLabelRule(&labeler.LabelRule{
+		ID:       "test",
+		Labels:   []labeler.RegionLabel{{Key: labeler.MaxRegionSizeLabel, Value: "64"}},
+		RuleType: labeler.KeyRange,
+		Data:     makeKeyRanges("", ""),
+	})
+	re.NoError(err)
+	op := sc.Check(cluster.GetRegion(1))
+	re.NotNil(op)
+	re.Equal("policy-split-region", op.Desc())
+	step := op.Step(0).(operator.SplitRegion)
+	re.Equal(pdpb.CheckPolicy_APPROXIMATE, step.Policy)
+	re.Empty(step.SplitKeys)
+
+	cluster.PutRegion(cluster.GetRegion(1).Clone(core.SetApproximateSize(64)))
+	re.Nil(sc.Check(cluster.GetRegion(1)))
+}
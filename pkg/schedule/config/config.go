@@ -17,6 +17,8 @@ package config
 import (
 	"time"
 
+	"github.com/docker/go-units"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/metapb"
 
@@ -51,6 +53,7 @@ const (
 	defaultHotRegionCacheHitsThreshold = 3
 	defaultSchedulerMaxWaitingOperator = 5
 	defaultHotRegionsReservedDays      = 7
+	defaultOperatorHistoryReservedDays = 7
 	// When a slow store affected more than 30% of total stores, it will trigger evicting.
 	defaultSlowStoreEvictingAffectedStoreRatioThreshold = 0.3
 	defaultMaxMovableHotPeerSize                        = int64(512)
@@ -69,6 +72,15 @@ const (
 	defaultRegionScoreFormulaVersion = "v2"
 	defaultLeaderSchedulePolicy      = "count"
 	defaultStoreLimitVersion         = "v1"
+	defaultOperatorRollbackPolicy    = "none"
+	defaultOperatorPreemptionPolicy  = "none"
+	defaultLeaderZoneLabel           = "zone"
+	defaultBucketSplitMinLoad        = 1 * units.MiB
+	defaultBucketSplitHotRatio       = 0.8
+	defaultBucketSplitMaxHotBuckets  = 2
+	defaultRangeMergeMinRegions      = 8
+	defaultRangeMergeScheduleLimit   = 8
+	defaultTiFlashReplicaSizeRatio   = 1.0
 	defaultPatrolRegionWorkerCount   = 1
 	maxPatrolRegionWorkerCount       = 8
 
@@ -78,6 +90,9 @@ const (
 	defaultPatrolRegionInterval    = 10 * time.Millisecond
 	defaultMaxStoreDownTime        = 30 * time.Minute
 	defaultHotRegionsWriteInterval = 10 * time.Minute
+	// defaultOperatorHistoryWriteInterval should be less than the time the
+	// finished operators are kept in memory by the operator controller.
+	defaultOperatorHistoryWriteInterval = time.Minute
 	// It means we skip the preparing stage after the 48 hours no matter if the store has finished preparing stage.
 	defaultMaxStorePreparingTime = 48 * time.Hour
 )
@@ -289,6 +304,12 @@ type ScheduleConfig struct {
 	// The day of hot regions data to be reserved. 0 means close.
 	HotRegionsReservedDays uint64 `toml:"hot-regions-reserved-days" json:"hot-regions-reserved-days"`
 
+	// Controls the time interval between write finished operators into leveldb.
+	OperatorHistoryWriteInterval typeutil.Duration `toml:"operator-history-write-interval" json:"operator-history-write-interval"`
+
+	// The day of finished operators to be reserved. 0 means close.
+	OperatorHistoryReservedDays uint64 `toml:"operator-history-reserved-days" json:"operator-history-reserved-days"`
+
 	// MaxMovableHotPeerSize is the threshold of region size for balance hot region.
 	// Hot region must be split before moved if it's region size is greater than MaxMovableHotPeerSize.
 	MaxMovableHotPeerSize int64 `toml:"max-movable-hot-peer-size" json:"max-movable-hot-peer-size,omitempty"`
@@ -314,6 +335,54 @@ type ScheduleConfig struct {
 
 	// PatrolRegionWorkerCount is the number of workers to patrol region.
 	PatrolRegionWorkerCount int `toml:"patrol-region-worker-count" json:"patrol-region-worker-count"`
+
+	// OperatorRollbackPolicy is the option to roll back the partially applied operators by removing
+	// the peers they added, there are some policies supported: ["none", "timeout", "cancel"], default: "none"
+	OperatorRollbackPolicy string `toml:"operator-rollback-policy" json:"operator-rollback-policy"`
+
+	// OperatorPreemptionPolicy is the option to let the high priority operators preempt the running
+	// lower priority operators on the stores whose limits are exhausted, there are some policies
+	// supported: ["none", "replica", "priority"], default: "none". Only the snapshot limit of the
+	// v2 store limit is released by canceling the operators, so it requires store-limit-version v2.
+	OperatorPreemptionPolicy string `toml:"operator-preemption-policy" json:"operator-preemption-policy"`
+
+	// LeaderZoneLabel is the store label key used to match the zone of the `leader_zone`
+	// region label policy, default: "zone"
+	LeaderZoneLabel string `toml:"leader-zone-label" json:"leader-zone-label"`
+
+	// SchedulerStoreLimitQuota is the max ratio of the store limit that the operators of a scheduler
+	// can use on each store, the key is the scheduler name, e.g. {"balance-hot-region-scheduler": 0.3}.
+	// The schedulers without quota are only limited by the store limit.
+	SchedulerStoreLimitQuota map[string]float64 `toml:"scheduler-store-limit-quota" json:"scheduler-store-limit-quota"`
+
+	// EnableBucketSplit is the option to split the regions whose load is concentrated in a few
+	// buckets at the bucket boundaries.
+	EnableBucketSplit bool `toml:"enable-bucket-split" json:"enable-bucket-split,string"`
+	// BucketSplitMinLoad is the min read and write byte rate of a region to be split by buckets.
+	BucketSplitMinLoad float64 `toml:"bucket-split-min-load" json:"bucket-split-min-load"`
+	// BucketSplitHotRatio is the min ratio of the region load in the hottest buckets to split the region.
+	BucketSplitHotRatio float64 `toml:"bucket-split-hot-ratio" json:"bucket-split-hot-ratio"`
+	// BucketSplitMaxHotBuckets is the max number of the hottest buckets which the region load is concentrated in.
+	BucketSplitMaxHotBuckets int `toml:"bucket-split-max-hot-buckets" json:"bucket-split-max-hot-buckets"`
+
+	// CrossLocationRepairSizePerMinute is the max size (in MiB) of the regions which the rule checker can
+	// repair across the top level location label (e.g. zone) per minute. 0 means no limit.
+	CrossLocationRepairSizePerMinute uint64 `toml:"cross-location-repair-size-per-minute" json:"cross-location-repair-size-per-minute"`
+
+	// EnableRangeMerge is the option to merge the long runs of small regions, e.g. after dropping
+	// a table, in a tree-shaped sequence.
+	EnableRangeMerge bool `toml:"enable-range-merge" json:"enable-range-merge,string"`
+	// RangeMergeMinRegions is the min number of the adjacent small regions to be merged as a run.
+	RangeMergeMinRegions int `toml:"range-merge-min-regions" json:"range-merge-min-regions"`
+	// RangeMergeScheduleLimit is the max number of the region pairs merged by the range merge at the same time.
+	RangeMergeScheduleLimit uint64 `toml:"range-merge-schedule-limit" json:"range-merge-schedule-limit"`
+
+	// TiFlashReplicaSizeRatio is the ratio of the size of a TiFlash columnar replica to the approximate
+	// region size. It is used to estimate the space needed by a new TiFlash replica.
+	TiFlashReplicaSizeRatio float64 `toml:"tiflash-replica-size-ratio" json:"tiflash-replica-size-ratio"`
+	// TiFlashRegionScheduleLimit is the max number of the balance region operators which move the peers
+	// on the TiFlash stores at the same time. 0 means they are only limited by `region-schedule-limit`.
+	TiFlashRegionScheduleLimit uint64 `toml:"tiflash-region-schedule-limit" json:"tiflash-region-schedule-limit"`
 }
 
 // Clone returns a cloned scheduling configuration.
@@ -326,8 +395,16 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 			storeLimit[k] = v
 		}
 	}
+	var storeLimitQuota map[string]float64
+	if c.SchedulerStoreLimitQuota != nil {
+		storeLimitQuota = make(map[string]float64, len(c.SchedulerStoreLimitQuota))
+		for k, v := range c.SchedulerStoreLimitQuota {
+			storeLimitQuota[k] = v
+		}
+	}
 	cfg := *c
 	cfg.StoreLimit = storeLimit
+	cfg.SchedulerStoreLimitQuota = storeLimitQuota
 	cfg.Schedulers = schedulers
 	return &cfg
 }
@@ -348,6 +425,7 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 	configutil.AdjustDuration(&c.PatrolRegionInterval, defaultPatrolRegionInterval)
 	configutil.AdjustDuration(&c.MaxStoreDownTime, defaultMaxStoreDownTime)
 	configutil.AdjustDuration(&c.HotRegionsWriteInterval, defaultHotRegionsWriteInterval)
+	configutil.AdjustDuration(&c.OperatorHistoryWriteInterval, defaultOperatorHistoryWriteInterval)
 	configutil.AdjustDuration(&c.MaxStorePreparingTime, defaultMaxStorePreparingTime)
 	if !meta.IsDefined("leader-schedule-limit") {
 		configutil.AdjustUint64(&c.LeaderScheduleLimit, defaultLeaderScheduleLimit)
@@ -385,6 +463,33 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 	if !meta.IsDefined("patrol-region-worker-count") {
 		configutil.AdjustInt(&c.PatrolRegionWorkerCount, defaultPatrolRegionWorkerCount)
 	}
+	if !meta.IsDefined("operator-rollback-policy") {
+		configutil.AdjustString(&c.OperatorRollbackPolicy, defaultOperatorRollbackPolicy)
+	}
+	if !meta.IsDefined("operator-preemption-policy") {
+		configutil.AdjustString(&c.OperatorPreemptionPolicy, defaultOperatorPreemptionPolicy)
+	}
+	if !meta.IsDefined("leader-zone-label") {
+		configutil.AdjustString(&c.LeaderZoneLabel, defaultLeaderZoneLabel)
+	}
+	if !meta.IsDefined("bucket-split-min-load") {
+		configutil.AdjustFloat64(&c.BucketSplitMinLoad, defaultBucketSplitMinLoad)
+	}
+	if !meta.IsDefined("bucket-split-hot-ratio") {
+		configutil.AdjustFloat64(&c.BucketSplitHotRatio, defaultBucketSplitHotRatio)
+	}
+	if !meta.IsDefined("bucket-split-max-hot-buckets") {
+		configutil.AdjustInt(&c.BucketSplitMaxHotBuckets, defaultBucketSplitMaxHotBuckets)
+	}
+	if !meta.IsDefined("range-merge-min-regions") {
+		configutil.AdjustInt(&c.RangeMergeMinRegions, defaultRangeMergeMinRegions)
+	}
+	if !meta.IsDefined("range-merge-schedule-limit") {
+		configutil.AdjustUint64(&c.RangeMergeScheduleLimit, defaultRangeMergeScheduleLimit)
+	}
+	if !meta.IsDefined("tiflash-replica-size-ratio") {
+		configutil.AdjustFloat64(&c.TiFlashReplicaSizeRatio, defaultTiFlashReplicaSizeRatio)
+	}
 
 	if !meta.IsDefined("enable-joint-consensus") {
 		c.EnableJointConsensus = defaultEnableJointConsensus
@@ -446,6 +551,10 @@ func (c *ScheduleConfig) Adjust(meta *configutil.ConfigMetaData, reloading bool)
 		configutil.AdjustUint64(&c.HotRegionsReservedDays, defaultHotRegionsReservedDays)
 	}
 
+	if !meta.IsDefined("operator-history-reserved-days") {
+		configutil.AdjustUint64(&c.OperatorHistoryReservedDays, defaultOperatorHistoryReservedDays)
+	}
+
 	if !meta.IsDefined("max-movable-hot-peer-size") {
 		configutil.AdjustInt64(&c.MaxMovableHotPeerSize, defaultMaxMovableHotPeerSize)
 	}
@@ -526,6 +635,36 @@ func (c *ScheduleConfig) Validate() error {
 	if c.LeaderSchedulePolicy != "count" && c.LeaderSchedulePolicy != "size" {
 		return errors.Errorf("leader-schedule-policy %v is invalid", c.LeaderSchedulePolicy)
 	}
+	if c.OperatorRollbackPolicy != "none" && c.OperatorRollbackPolicy != "timeout" && c.OperatorRollbackPolicy != "cancel" {
+		return errors.Errorf("operator-rollback-policy %v is invalid", c.OperatorRollbackPolicy)
+	}
+	if c.OperatorPreemptionPolicy != "none" && c.OperatorPreemptionPolicy != "replica" && c.OperatorPreemptionPolicy != "priority" {
+		return errors.Errorf("operator-preemption-policy %v is invalid", c.OperatorPreemptionPolicy)
+	}
+	// Only the v2 store limit gives back the tokens of the canceled operators.
+	if c.OperatorPreemptionPolicy != "none" && c.StoreLimitVersion != "v2" {
+		return errors.Errorf("operator-preemption-policy %v requires store-limit-version v2", c.OperatorPreemptionPolicy)
+	}
+	for name, quota := range c.SchedulerStoreLimitQuota {
+		if quota <= 0 || quota > 1 {
+			return errors.Errorf("scheduler-store-limit-quota of %s should be in (0, 1], got %v", name, quota)
+		}
+	}
+	if c.BucketSplitMinLoad < 0 {
+		return errors.Errorf("bucket-split-min-load should be non-negative")
+	}
+	if c.BucketSplitHotRatio <= 0 || c.BucketSplitHotRatio > 1 {
+		return errors.Errorf("bucket-split-hot-ratio should be in (0, 1]")
+	}
+	if c.BucketSplitMaxHotBuckets < 1 {
+		return errors.Errorf("bucket-split-max-hot-buckets should be positive")
+	}
+	if c.RangeMergeMinRegions < 2 {
+		return errors.Errorf("range-merge-min-regions should be at least 2")
+	}
+	if c.TiFlashReplicaSizeRatio <= 0 {
+		return errors.Errorf("tiflash-replica-size-ratio should be positive")
+	}
 	if c.SlowStoreEvictingAffectedStoreRatioThreshold == 0 {
 		return errors.Errorf("slow-store-evicting-affected-store-ratio-threshold is not set")
 	}
@@ -55,6 +55,7 @@ type SchedulerConfigProvider interface {
 	GetLeaderScheduleLimit() uint64
 	GetHotRegionScheduleLimit() uint64
 	GetWitnessScheduleLimit() uint64
+	GetTiFlashRegionScheduleLimit() uint64
 
 	GetHotRegionCacheHitsThreshold() int
 	GetMaxMovableHotPeerSize() int64
@@ -89,6 +90,15 @@ type CheckerConfigProvider interface {
 	GetMaxMergeRegionSize() uint64
 	GetMaxMergeRegionKeys() uint64
 	GetReplicaScheduleLimit() uint64
+	IsBucketSplitEnabled() bool
+	GetBucketSplitMinLoad() float64
+	GetBucketSplitHotRatio() float64
+	GetBucketSplitMaxHotBuckets() int
+	GetCrossLocationRepairSizePerMinute() uint64
+	IsRangeMergeEnabled() bool
+	GetRangeMergeMinRegions() int
+	GetRangeMergeScheduleLimit() uint64
+	GetTiFlashReplicaSizeRatio() float64
 }
 
 // SharedConfigProvider is the interface for shared configurations.
@@ -113,6 +123,10 @@ type SharedConfigProvider interface {
 	GetStoreLimitByType(uint64, storelimit.Type) float64
 	IsWitnessAllowed() bool
 	IsPlacementRulesCacheEnabled() bool
+	GetOperatorRollbackPolicy() string
+	GetOperatorPreemptionPolicy() string
+	GetLeaderZoneLabel() string
+	GetSchedulerStoreLimitQuota(string) float64
 	SetHaltScheduling(bool, string)
 	GetHotRegionCacheHitsThreshold() int
 
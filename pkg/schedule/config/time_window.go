@@ -0,0 +1,247 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/endpoint"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const timeWindowClockLayout = "15:04"
+
+// TimeWindow is a period of time in which a scheduler or checker is allowed to run.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type TimeWindow struct {
+	// Weekdays is a cron-like day-of-week field, such as "*", "1-5" or "0,6".
+	// Both 0 and 7 are Sunday. It is "*" if not set.
+	Weekdays string `json:"weekdays,omitempty"`
+	// Start and End are the clock in the format of "15:04". The window crosses
+	// midnight if End is not after Start, and in this case the weekday of the
+	// window is the day it starts.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// TimeZone is the IANA time zone name, such as "Asia/Shanghai". It is UTC if not set.
+	TimeZone string `json:"time-zone,omitempty"`
+
+	weekdays   [7]bool
+	start, end int // minutes since midnight
+	location   *time.Location
+}
+
+// Adjust validates the time window and prepares it for matching.
+func (w *TimeWindow) Adjust() error {
+	if err := w.parseWeekdays(); err != nil {
+		return err
+	}
+	var err error
+	if w.start, err = parseClock(w.Start); err != nil {
+		return err
+	}
+	if w.end, err = parseClock(w.End); err != nil {
+		return err
+	}
+	if w.location, err = time.LoadLocation(w.TimeZone); err != nil {
+		return errors.Errorf("invalid time zone %q", w.TimeZone)
+	}
+	return nil
+}
+
+func (w *TimeWindow) parseWeekdays() error {
+	w.weekdays = [7]bool{}
+	if w.Weekdays == "" || w.Weekdays == "*" {
+		for i := range w.weekdays {
+			w.weekdays[i] = true
+		}
+		return nil
+	}
+	for _, item := range strings.Split(w.Weekdays, ",") {
+		first, last, isRange := strings.Cut(item, "-")
+		from, err := parseWeekday(first)
+		if err != nil {
+			return err
+		}
+		to := from
+		if isRange {
+			if to, err = parseWeekday(last); err != nil {
+				return err
+			}
+			if to < from {
+				return errors.Errorf("invalid weekday range %q", item)
+			}
+		}
+		for i := from; i <= to; i++ {
+			w.weekdays[i%7] = true
+		}
+	}
+	return nil
+}
+
+func parseWeekday(s string) (int, error) {
+	d, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || d < 0 || d > 7 {
+		return 0, errors.Errorf("invalid weekday %q, it should be between 0 and 7", s)
+	}
+	return d, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse(timeWindowClockLayout, s)
+	if err != nil {
+		return 0, errors.Errorf("invalid clock %q, it should be in the format of %q", s, timeWindowClockLayout)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains returns whether the given time is in the time window.
+// It should be called after Adjust.
+func (w *TimeWindow) Contains(t time.Time) bool {
+	t = t.In(w.location)
+	now := t.Hour()*60 + t.Minute()
+	weekday := int(t.Weekday())
+	if w.start < w.end {
+		return w.weekdays[weekday] && now >= w.start && now < w.end
+	}
+	// The window crosses midnight, so the time after midnight belongs to
+	// the window started in the day before.
+	if now >= w.start {
+		return w.weekdays[weekday]
+	}
+	return now < w.end && w.weekdays[(weekday+6)%7]
+}
+
+// TimeWindows manages the scheduling time windows of the schedulers and checkers.
+// A scheduler or checker without any time window is always allowed to run,
+// otherwise it only runs when the current time is in one of its time windows.
+type TimeWindows struct {
+	syncutil.RWMutex
+	storage endpoint.ConfigStorage
+	windows map[string][]*TimeWindow
+}
+
+// NewTimeWindows creates a new TimeWindows.
+func NewTimeWindows(storage endpoint.ConfigStorage) *TimeWindows {
+	return &TimeWindows{
+		storage: storage,
+		windows: make(map[string][]*TimeWindow),
+	}
+}
+
+// Load loads the time windows from the persisted data.
+func (tw *TimeWindows) Load(data []byte) error {
+	windows := make(map[string][]*TimeWindow)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &windows); err != nil {
+			return errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+	}
+	for _, ws := range windows {
+		for _, w := range ws {
+			if err := w.Adjust(); err != nil {
+				return err
+			}
+		}
+	}
+	tw.Lock()
+	defer tw.Unlock()
+	tw.windows = windows
+	return nil
+}
+
+// Reload reloads the time windows from the storage.
+func (tw *TimeWindows) Reload() error {
+	data, err := tw.storage.LoadScheduleTimeWindows()
+	if err != nil {
+		return err
+	}
+	return tw.Load([]byte(data))
+}
+
+// Get returns the time windows of the given scheduler or checker.
+func (tw *TimeWindows) Get(name string) []*TimeWindow {
+	tw.RLock()
+	defer tw.RUnlock()
+	return tw.windows[name]
+}
+
+// GetAll returns the time windows of all the schedulers and checkers.
+func (tw *TimeWindows) GetAll() map[string][]*TimeWindow {
+	tw.RLock()
+	defer tw.RUnlock()
+	windows := make(map[string][]*TimeWindow, len(tw.windows))
+	for name, ws := range tw.windows {
+		windows[name] = ws
+	}
+	return windows
+}
+
+// Set sets and persists the time windows of the given scheduler or checker.
+// The time windows are removed if the given windows are empty.
+func (tw *TimeWindows) Set(name string, windows []*TimeWindow) error {
+	for _, w := range windows {
+		if err := w.Adjust(); err != nil {
+			return err
+		}
+	}
+	tw.Lock()
+	defer tw.Unlock()
+	old, ok := tw.windows[name]
+	if len(windows) == 0 {
+		delete(tw.windows, name)
+	} else {
+		tw.windows[name] = windows
+	}
+	if err := tw.persistLocked(); err != nil {
+		if ok {
+			tw.windows[name] = old
+		} else {
+			delete(tw.windows, name)
+		}
+		return err
+	}
+	return nil
+}
+
+func (tw *TimeWindows) persistLocked() error {
+	data, err := json.Marshal(tw.windows)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	return tw.storage.SaveScheduleTimeWindows(data)
+}
+
+// IsAllowed returns whether the given scheduler or checker is allowed to run at the given time.
+func (tw *TimeWindows) IsAllowed(name string, t time.Time) bool {
+	tw.RLock()
+	defer tw.RUnlock()
+	windows, ok := tw.windows[name]
+	if !ok {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/storage"
+)
+
+func TestTimeWindow(t *testing.T) {
+	re := require.New(t)
+	// 2025-01-06 is a Monday.
+	monday := func(clock string) time.Time {
+		t, err := time.Parse(time.DateTime, "2025-01-06 "+clock+":00")
+		re.NoError(err)
+		return t
+	}
+	testCases := []struct {
+		window   TimeWindow
+		time     time.Time
+		contains bool
+	}{
+		{TimeWindow{Start: "01:00", End: "05:00"}, monday("00:59"), false},
+		{TimeWindow{Start: "01:00", End: "05:00"}, monday("01:00"), true},
+		{TimeWindow{Start: "01:00", End: "05:00"}, monday("05:00"), false},
+		{TimeWindow{Weekdays: "1-5", Start: "01:00", End: "05:00"}, monday("02:00"), true},
+		{TimeWindow{Weekdays: "0,6", Start: "01:00", End: "05:00"}, monday("02:00"), false},
+		{TimeWindow{Weekdays: "7", Start: "01:00", End: "05:00"}, monday("02:00").AddDate(0, 0, -1), true},
+		// The window crosses midnight.
+		{TimeWindow{Weekdays: "1", Start: "22:00", End: "06:00"}, monday("23:00"), true},
+		{TimeWindow{Weekdays: "1", Start: "22:00", End: "06:00"}, monday("05:00"), false},
+		{TimeWindow{Weekdays: "1", Start: "22:00", End: "06:00"}, monday("05:00").AddDate(0, 0, 1), true},
+		{TimeWindow{Start: "00:00", End: "00:00"}, monday("12:00"), true},
+		// 02:00 UTC is 10:00 in Shanghai.
+		{TimeWindow{Start: "09:00", End: "11:00", TimeZone: "Asia/Shanghai"}, monday("02:00"), true},
+		{TimeWindow{Start: "01:00", End: "03:00", TimeZone: "Asia/Shanghai"}, monday("02:00"), false},
+	}
+	for i, tc := range testCases {
+		re.NoError(tc.window.Adjust(), i)
+		re.Equal(tc.contains, tc.window.Contains(tc.time), i)
+	}
+
+	for _, w := range []TimeWindow{
+		{Start: "1:00pm", End: "05:00"},
+		{Start: "01:00", End: "24:00"},
+		{Weekdays: "8", Start: "01:00", End: "05:00"},
+		{Weekdays: "5-1", Start: "01:00", End: "05:00"},
+		{Weekdays: "mon", Start: "01:00", End: "05:00"},
+		{Start: "01:00", End: "05:00", TimeZone: "Mars/Olympus"},
+	} {
+		re.Error(w.Adjust())
+	}
+}
+
+func TestTimeWindows(t *testing.T) {
+	re := require.New(t)
+	store := storage.NewStorageWithMemoryBackend()
+	tw := NewTimeWindows(store)
+	night, err := time.Parse(time.DateTime, "2025-01-06 02:00:00")
+	re.NoError(err)
+	day := night.Add(10 * time.Hour)
+
+	re.True(tw.IsAllowed("balance-region-scheduler", day))
+	re.NoError(tw.Set("balance-region-scheduler", []*TimeWindow{{Start: "01:00", End: "05:00"}}))
+	re.NoError(tw.Set("merge-checker", []*TimeWindow{{Start: "23:00", End: "01:00"}, {Start: "01:00", End: "03:00"}}))
+	re.Error(tw.Set("merge-checker", []*TimeWindow{{Start: "23:00"}}))
+	re.Len(tw.Get("merge-checker"), 2)
+	re.True(tw.IsAllowed("balance-region-scheduler", night))
+	re.False(tw.IsAllowed("balance-region-scheduler", day))
+	re.True(tw.IsAllowed("merge-checker", night))
+	re.False(tw.IsAllowed("merge-checker", day))
+	re.True(tw.IsAllowed("balance-leader-scheduler", day))
+
+	// The time windows are persisted.
+	other := NewTimeWindows(store)
+	re.NoError(other.Reload())
+	re.Len(other.GetAll(), 2)
+	re.False(other.IsAllowed("balance-region-scheduler", day))
+
+	re.NoError(tw.Set("balance-region-scheduler", nil))
+	re.True(tw.IsAllowed("balance-region-scheduler", day))
+	re.NoError(other.Reload())
+	re.Len(other.GetAll(), 1)
+	re.True(other.IsAllowed("balance-region-scheduler", day))
+}
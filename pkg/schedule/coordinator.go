@@ -83,8 +83,9 @@ type Coordinator struct {
 func NewCoordinator(parentCtx context.Context, cluster sche.ClusterInformer, hbStreams *hbstream.HeartbeatStreams) *Coordinator {
 	ctx, cancel := context.WithCancel(parentCtx)
 	opController := operator.NewController(ctx, cluster.GetBasicCluster(), cluster.GetSharedConfig(), hbStreams)
-	schedulers := schedulers.NewController(ctx, cluster, cluster.GetStorage(), opController)
-	checkers := checker.NewController(ctx, cluster, cluster.GetCheckerConfig(), cluster.GetRuleManager(), cluster.GetRegionLabeler(), opController)
+	timeWindows := sc.NewTimeWindows(cluster.GetStorage())
+	schedulers := schedulers.NewController(ctx, cluster, cluster.GetStorage(), opController, timeWindows)
+	checkers := checker.NewController(ctx, cluster, cluster.GetCheckerConfig(), cluster.GetRuleManager(), cluster.GetRegionLabeler(), opController, timeWindows)
 	return &Coordinator{
 		ctx:                   ctx,
 		cancel:                cancel,
@@ -271,6 +272,12 @@ func (c *Coordinator) InitSchedulers(needRun bool) {
 	if err != nil {
 		log.Fatal("cannot load schedulers' config", errs.ZapError(err))
 	}
+	if err := c.schedulers.GetTimeWindows().Reload(); err != nil {
+		log.Error("can not load scheduling time windows", errs.ZapError(err))
+	}
+	if err := c.schedulers.ReloadShadowSchedulers(); err != nil {
+		log.Error("can not load shadow schedulers", errs.ZapError(err))
+	}
 	scheduleCfg := c.cluster.GetSchedulerConfig().GetScheduleConfig().Clone()
 	// The new way to create scheduler with the independent configuration.
 	for i, name := range scheduleNames {
@@ -48,6 +48,7 @@ type SchedulerCluster interface {
 // CheckerCluster is an aggregate interface that wraps multiple interfaces
 type CheckerCluster interface {
 	SharedCluster
+	buckets.BucketStatInformer
 
 	GetCheckerConfig() sc.CheckerConfigProvider
 	GetStoreConfig() sc.StoreConfigProvider
@@ -17,6 +17,7 @@ package filter
 import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/placement"
 )
 
 // StoreComparer compares 2 stores. Often used for StoreCandidates to
@@ -55,3 +56,20 @@ func IsolationComparer(locationLabels []string, regionStores []*core.StoreInfo)
 		}
 	}
 }
+
+// PreferredRegionScoreComparer creates a StoreComparer to sort store by the region
+// score discounted by the weights of the matched soft label constraints.
+func PreferredRegionScoreComparer(conf config.SharedConfigProvider, constraints []placement.LabelConstraint) StoreComparer {
+	return func(a, b *core.StoreInfo) int {
+		sa := placement.PreferredScore(a.RegionScore(conf.GetRegionScoreFormulaVersion(), conf.GetHighSpaceRatio(), conf.GetLowSpaceRatio(), 0), a, constraints)
+		sb := placement.PreferredScore(b.RegionScore(conf.GetRegionScoreFormulaVersion(), conf.GetHighSpaceRatio(), conf.GetLowSpaceRatio(), 0), b, constraints)
+		switch {
+		case sa > sb:
+			return 1
+		case sa < sb:
+			return -1
+		default:
+			return 0
+		}
+	}
+}
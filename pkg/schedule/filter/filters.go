@@ -17,6 +17,7 @@ package filter
 import (
 	"strconv"
 
+	"github.com/docker/go-units"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
@@ -185,7 +186,11 @@ func (f *excludedFilter) Target(_ config.SharedConfigProvider, store *core.Store
 	return statusOK
 }
 
-type storageThresholdFilter struct{ scope string }
+type storageThresholdFilter struct {
+	scope string
+	// replicaSize is the estimated size (in MiB) of the replica to be added.
+	replicaSize int64
+}
 
 // NewStorageThresholdFilter creates a Filter that filters all stores that are
 // almost full.
@@ -193,6 +198,12 @@ func NewStorageThresholdFilter(scope string) Filter {
 	return &storageThresholdFilter{scope: scope}
 }
 
+// NewReplicaSizeThresholdFilter creates a Filter that filters all stores that are
+// almost full or will be almost full after adding a replica of the given size (in MiB).
+func NewReplicaSizeThresholdFilter(scope string, replicaSize int64) Filter {
+	return &storageThresholdFilter{scope: scope, replicaSize: replicaSize}
+}
+
 // Scope returns the scheduler or the checker which the filter acts on.
 func (f *storageThresholdFilter) Scope() string {
 	return f.scope
@@ -209,11 +220,17 @@ func (*storageThresholdFilter) Source(config.SharedConfigProvider, *core.StoreIn
 }
 
 // Target filters stores when select them as schedule target.
-func (*storageThresholdFilter) Target(conf config.SharedConfigProvider, store *core.StoreInfo) *plan.Status {
-	if !store.IsLowSpace(conf.GetLowSpaceRatio()) {
-		return statusOK
+func (f *storageThresholdFilter) Target(conf config.SharedConfigProvider, store *core.StoreInfo) *plan.Status {
+	if store.IsLowSpace(conf.GetLowSpaceRatio()) {
+		return statusStoreLowSpace
+	}
+	if f.replicaSize > 0 && store.GetCapacity() > 0 {
+		available := float64(store.GetAvailable()) - float64(f.replicaSize)*units.MiB
+		if available < float64(store.GetCapacity())*(1-conf.GetLowSpaceRatio()) {
+			return statusStoreLowSpace
+		}
 	}
-	return statusStoreLowSpace
+	return statusOK
 }
 
 // distinctScoreFilter ensures that distinct score will not decrease.
@@ -659,19 +676,27 @@ type ruleLeaderFitFilter struct {
 	oldFit           *placement.RegionFit
 	srcLeaderStoreID uint64
 	allowMoveLeader  bool
+	// srcLeaderRank is the leader preference rank of the source store, the
+	// leader can't be transferred to a less preferred store.
+	srcLeaderRank     int
+	leaderPreferences []placement.LeaderPreference
 }
 
 // newRuleLeaderFitFilter creates a filter that ensures after transfer leader with new store,
-// the isolation level will not decrease.
+// the isolation level will not decrease and the leader preference will not become worse.
 func newRuleLeaderFitFilter(scope string, cluster *core.BasicCluster, ruleManager *placement.RuleManager, region *core.RegionInfo, srcLeaderStoreID uint64, allowMoveLeader bool) Filter {
+	oldFit := ruleManager.FitRegion(cluster, region)
+	preferences := placement.GetLeaderPreferences(oldFit.GetRules())
 	return &ruleLeaderFitFilter{
-		scope:            scope,
-		cluster:          cluster,
-		ruleManager:      ruleManager,
-		region:           region,
-		oldFit:           ruleManager.FitRegion(cluster, region),
-		srcLeaderStoreID: srcLeaderStoreID,
-		allowMoveLeader:  allowMoveLeader,
+		scope:             scope,
+		cluster:           cluster,
+		ruleManager:       ruleManager,
+		region:            region,
+		oldFit:            oldFit,
+		srcLeaderStoreID:  srcLeaderStoreID,
+		allowMoveLeader:   allowMoveLeader,
+		srcLeaderRank:     placement.LeaderPreferenceRank(cluster.GetStore(srcLeaderStoreID), preferences),
+		leaderPreferences: preferences,
 	}
 }
 
@@ -701,6 +726,9 @@ func (f *ruleLeaderFitFilter) Target(_ config.SharedConfigProvider, store *core.
 	if targetPeer != nil && targetPeer.IsWitness {
 		return statusStoreNotMatchRule
 	}
+	if placement.LeaderPreferenceRank(store, f.leaderPreferences) > f.srcLeaderRank {
+		return statusStoreNotMatchRule
+	}
 	if f.oldFit.Replace(f.srcLeaderStoreID, store) {
 		return statusOK
 	}
@@ -896,6 +924,8 @@ var (
 	NotSpecialEngines = placement.LabelConstraint{Key: core.EngineKey, Op: placement.NotIn, Values: allSpecialEngines}
 	// SpecialEngines is used to filter the TiFlash engine.
 	SpecialEngines = placement.LabelConstraint{Key: core.EngineKey, Op: placement.In, Values: allSpecialEngines}
+	// NotComputeEngines is used to filter the TiFlash compute nodes, which do not store any replica.
+	NotComputeEngines = placement.LabelConstraint{Key: core.EngineKey, Op: placement.NotIn, Values: []string{core.EngineTiFlashCompute}}
 )
 
 type isolationFilter struct {
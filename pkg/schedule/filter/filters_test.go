@@ -16,6 +16,7 @@ package filter
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -153,6 +154,43 @@ func TestRuleFitFilter(t *testing.T) {
 	re.False(leaderFilter.Target(testCluster.GetSharedConfig(), testCluster.GetStore(6)).IsOK())
 }
 
+func TestRuleLeaderFitFilterWithLeaderPreference(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opt := mockconfig.NewTestOptions()
+	testCluster := mockcluster.NewCluster(ctx, opt)
+	testCluster.SetEnablePlacementRules(true)
+	for id := uint64(1); id <= 3; id++ {
+		testCluster.AddLabelsStore(id, 1, map[string]string{"zone": fmt.Sprintf("z%d", id)})
+	}
+	re.NoError(testCluster.GetRuleManager().SetRule(&placement.Rule{
+		GroupID: placement.DefaultGroupID,
+		ID:      placement.DefaultRuleID,
+		Role:    placement.Voter,
+		Count:   3,
+		LeaderPreferences: []placement.LeaderPreference{
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z1"}}}},
+			{LabelConstraints: []placement.LabelConstraint{{Key: "zone", Op: placement.In, Values: []string{"z2"}}}},
+		},
+	}))
+	region := core.NewRegionInfo(&metapb.Region{Peers: []*metapb.Peer{
+		{StoreId: 1, Id: 1},
+		{StoreId: 2, Id: 2},
+		{StoreId: 3, Id: 3},
+	}}, &metapb.Peer{StoreId: 2, Id: 2})
+
+	// The leader can't be transferred to a less preferred store.
+	leaderFilter := newRuleLeaderFitFilter("", testCluster.GetBasicCluster(), testCluster.GetRuleManager(), region, 2, false)
+	re.True(leaderFilter.Target(testCluster.GetSharedConfig(), testCluster.GetStore(1)).IsOK())
+	re.False(leaderFilter.Target(testCluster.GetSharedConfig(), testCluster.GetStore(3)).IsOK())
+	region = region.Clone(core.WithLeader(region.GetStorePeer(3)))
+	leaderFilter = newRuleLeaderFitFilter("", testCluster.GetBasicCluster(), testCluster.GetRuleManager(), region, 3, false)
+	re.True(leaderFilter.Target(testCluster.GetSharedConfig(), testCluster.GetStore(1)).IsOK())
+	re.True(leaderFilter.Target(testCluster.GetSharedConfig(), testCluster.GetStore(2)).IsOK())
+}
+
 func TestRuleFitFilterWithPlacementRule(t *testing.T) {
 	re := require.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -15,6 +15,8 @@
 package filter
 
 import (
+	"slices"
+
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/core/storelimit"
@@ -172,6 +174,30 @@ func (f *regionWitnessFilter) Select(region *core.RegionInfo) *plan.Status {
 	return statusOK
 }
 
+type regionVoterMajorityFilter struct {
+	storeIDs []uint64
+}
+
+// NewRegionVoterMajorityFilter creates a RegionFilter that filters the regions whose majority of voters are on the specific stores.
+func NewRegionVoterMajorityFilter(storeIDs ...uint64) RegionFilter {
+	return &regionVoterMajorityFilter{storeIDs: storeIDs}
+}
+
+// Select implements the RegionFilter interface.
+func (f *regionVoterMajorityFilter) Select(region *core.RegionInfo) *plan.Status {
+	voters := region.GetVoters()
+	count := 0
+	for _, voter := range voters {
+		if slices.Contains(f.storeIDs, voter.GetStoreId()) {
+			count++
+		}
+	}
+	if count*2 > len(voters) {
+		return statusRegionMajorityVoters
+	}
+	return statusOK
+}
+
 // SnapshotSenderFilter filer the region who's leader store reaches the limit.
 type SnapshotSenderFilter struct {
 	senders map[uint64]struct{}
@@ -49,5 +49,6 @@ var (
 	statusRegionNotMatchRule                = plan.NewStatus(plan.StatusRegionNotMatchRule)
 	statusRegionNotReplicated               = plan.NewStatus(plan.StatusRegionNotReplicated)
 	statusRegionWitnessPeer                 = plan.NewStatus(plan.StatusRegionNotMatchRule)
+	statusRegionMajorityVoters              = plan.NewStatus(plan.StatusRegionUnhealthy)
 	statusRegionLeaderSendSnapshotThrottled = plan.NewStatus(plan.StatusRegionSendSnapshotThrottled)
 )
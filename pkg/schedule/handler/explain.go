@@ -0,0 +1,232 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"sort"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/labeler"
+	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/schedulers"
+	"github.com/tikv/pd/pkg/schedule/types"
+)
+
+const (
+	filterActionSource = "source"
+	filterActionTarget = "target"
+)
+
+// RegionExplanation explains why a region is or is not scheduled.
+type RegionExplanation struct {
+	RegionID       uint64                  `json:"region_id"`
+	PlacementRules []*placement.Rule       `json:"placement_rules,omitempty"`
+	LabelRules     []*labeler.LabelRule    `json:"label_rules,omitempty"`
+	StoreLimits    []*StoreLimitResult     `json:"store_limits,omitempty"`
+	Checkers       []*ComponentExplanation `json:"checkers"`
+	Schedulers     []*ComponentExplanation `json:"schedulers"`
+}
+
+// ComponentExplanation is the explanation of a checker or a scheduler for a region.
+type ComponentExplanation struct {
+	Name      string          `json:"name"`
+	Status    string          `json:"status"`
+	Operators []string        `json:"operators,omitempty"`
+	Filters   []*FilterResult `json:"filters,omitempty"`
+	Plans     []*PlanResult   `json:"plans,omitempty"`
+}
+
+// FilterResult is a filter which rejects one of the stores.
+type FilterResult struct {
+	Filter  string `json:"filter"`
+	StoreID uint64 `json:"store_id,omitempty"`
+	// Action is "source" or "target" for the store filters.
+	Action string `json:"action,omitempty"`
+	Status string `json:"status"`
+}
+
+// PlanResult is the status of a store summarized from the plans of a scheduler
+// which does not create operators for the region.
+type PlanResult struct {
+	StoreID uint64 `json:"store_id"`
+	Status  string `json:"status"`
+}
+
+// StoreLimitResult is a store whose store limit is exhausted.
+type StoreLimitResult struct {
+	StoreID   uint64 `json:"store_id"`
+	LimitType string `json:"limit_type"`
+}
+
+// ExplainRegion runs all checkers and schedulers in dry run against the region
+// and explains the result, operators are not added to the operator controller.
+func (h *Handler) ExplainRegion(region *core.RegionInfo) (*RegionExplanation, error) {
+	c := h.GetCluster()
+	if c == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	explanation := &RegionExplanation{RegionID: region.GetID()}
+	if c.GetSharedConfig().IsPlacementRulesEnabled() {
+		fit := c.GetRuleManager().FitRegion(c, region)
+		for _, rf := range fit.RuleFits {
+			explanation.PlacementRules = append(explanation.PlacementRules, rf.Rule)
+		}
+	}
+	if l := c.GetRegionLabeler(); l != nil {
+		explanation.LabelRules = l.GetRegionLabelRules(region)
+	}
+	stores := c.GetStores()
+	for _, store := range stores {
+		for _, typ := range []storelimit.Type{storelimit.AddPeer, storelimit.RemovePeer} {
+			if !store.IsAvailable(typ, constant.Medium) {
+				explanation.StoreLimits = append(explanation.StoreLimits, &StoreLimitResult{
+					StoreID:   store.GetID(),
+					LimitType: typ.String(),
+				})
+			}
+		}
+	}
+
+	for _, result := range co.GetCheckerController().ExplainRegion(region) {
+		e := &ComponentExplanation{Name: result.Type.String(), Status: result.Status}
+		for _, op := range result.Operators {
+			e.Operators = append(e.Operators, op.String())
+		}
+		e.Filters = explainStoreFilters(c, region, result.Type.String())
+		explanation.Checkers = append(explanation.Checkers, e)
+	}
+
+	sc := co.GetSchedulersController()
+	names := sc.GetSchedulerNames()
+	sort.Strings(names)
+	denied := false
+	if l := c.GetRegionLabeler(); l != nil {
+		denied = l.ScheduleDisabled(region)
+	}
+	for _, name := range names {
+		s := sc.GetScheduler(name)
+		if s == nil {
+			continue
+		}
+		e := &ComponentExplanation{Name: name}
+		explanation.Schedulers = append(explanation.Schedulers, e)
+		disabled, err := sc.IsSchedulerDisabled(name)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case disabled:
+			e.Status = schedulers.Disabled
+		case !s.IsScheduleAllowed(c):
+			e.Status = schedulers.Pending
+		case c.IsSchedulingHalted():
+			e.Status = schedulers.Halted
+		case s.IsPaused():
+			e.Status = schedulers.Paused
+		case !s.IsInTimeWindow():
+			e.Status = schedulers.OutOfWindow
+		}
+		if e.Status != "" {
+			continue
+		}
+		ops, plans, err := s.ExplainRegion(region)
+		if err != nil {
+			return nil, err
+		}
+		// The operators of the evict-leader-scheduler are not denied, the same as ScheduleController.Schedule.
+		if len(ops) > 0 && denied && s.GetType() != types.EvictLeaderScheduler {
+			e.Status = schedulers.Denied
+			continue
+		}
+		if len(ops) > 0 {
+			e.Status = schedulers.Scheduling
+			for _, op := range ops {
+				e.Operators = append(e.Operators, op.String())
+			}
+			continue
+		}
+		e.Status = schedulers.Normal
+		summary, ok := schedulers.DiagnosableSummaryFunc[s.GetType()]
+		if !ok {
+			continue
+		}
+		storeStatus, isAllNormal, err := summary(plans)
+		if err != nil {
+			return nil, err
+		}
+		if !isAllNormal {
+			e.Status = schedulers.Pending
+		}
+		for storeID, status := range storeStatus {
+			e.Plans = append(e.Plans, &PlanResult{StoreID: storeID, Status: status.String()})
+		}
+		sort.Slice(e.Plans, func(i, j int) bool { return e.Plans[i].StoreID < e.Plans[j].StoreID })
+	}
+	return explanation, nil
+}
+
+// explainStoreFilters returns the store filters used by the checkers which reject
+// the stores of the region as the source, or the other stores as the target.
+func explainStoreFilters(c sche.SchedulerCluster, region *core.RegionInfo, scope string) []*FilterResult {
+	var results []*FilterResult
+	conf := c.GetSharedConfig()
+	check := func(f filter.Filter, store *core.StoreInfo, action string) {
+		var status *plan.Status
+		if action == filterActionSource {
+			status = f.Source(conf, store)
+		} else {
+			status = f.Target(conf, store)
+		}
+		if !status.IsOK() {
+			results = append(results, &FilterResult{
+				Filter:  f.Type().String(),
+				StoreID: store.GetID(),
+				Action:  action,
+				Status:  status.String(),
+			})
+		}
+	}
+	stateFilter := &filter.StoreStateFilter{ActionScope: scope, MoveRegion: true, OperatorLevel: constant.High}
+	specialUseFilter := filter.NewSpecialUseFilter(scope)
+	leaderStoreID := region.GetLeader().GetStoreId()
+	var safeguard filter.Filter
+	if leaderStore := c.GetStore(leaderStoreID); leaderStore != nil && conf.IsPlacementRulesEnabled() {
+		safeguard = filter.NewPlacementSafeguard(scope, conf, c.GetBasicCluster(), c.GetRuleManager(), region, leaderStore, nil)
+	}
+
+	for _, store := range c.GetStores() {
+		if _, isSource := region.GetStoreIDs()[store.GetID()]; isSource {
+			check(stateFilter, store, filterActionSource)
+			check(specialUseFilter, store, filterActionSource)
+			continue
+		}
+		check(stateFilter, store, filterActionTarget)
+		check(specialUseFilter, store, filterActionTarget)
+		if safeguard != nil {
+			check(safeguard, store, filterActionTarget)
+		}
+	}
+	return results
+}
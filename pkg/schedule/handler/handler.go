@@ -34,6 +34,8 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule"
+	"github.com/tikv/pd/pkg/schedule/checker"
+	"github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/labeler"
@@ -45,6 +47,7 @@ import (
 	"github.com/tikv/pd/pkg/statistics"
 	"github.com/tikv/pd/pkg/statistics/buckets"
 	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 )
 
@@ -63,6 +66,9 @@ type Server interface {
 // Handler is a handler to handle http request about schedule.
 type Handler struct {
 	Server
+	// opCollector is used to collect the operators instead of adding them to
+	// the operator controller, see BuildOperators.
+	opCollector func(ops ...*operator.Operator)
 }
 
 // NewHandler creates a new handler.
@@ -240,6 +246,36 @@ func (h *Handler) GetRecords(from time.Time) ([]*operator.OpRecord, error) {
 	return records, nil
 }
 
+// GetHistoryOperators gets the operators finished since the given time in HistoryOperator form.
+func (h *Handler) GetHistoryOperators(from time.Time) ([]storage.HistoryOperator, error) {
+	c, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	records := c.GetRecords(from)
+	ops := make([]storage.HistoryOperator, 0, len(records))
+	for _, record := range records {
+		steps := make([]string, 0, record.Len())
+		for i := range record.Len() {
+			steps = append(steps, record.Step(i).String())
+		}
+		ops = append(ops, storage.HistoryOperator{
+			FinishTime:   record.FinishTime.UnixNano() / int64(time.Millisecond),
+			RegionID:     record.RegionID(),
+			Seq:          record.Seq,
+			Scheduler:    record.Desc(),
+			Brief:        record.Brief(),
+			Kind:         record.Kind().String(),
+			Steps:        steps,
+			StoreIDs:     record.RelatedStores(),
+			Status:       operator.OpStatusToString(record.Status()),
+			CancelReason: record.GetCancelReason(),
+			Duration:     record.Duration().Milliseconds(),
+		})
+	}
+	return ops, nil
+}
+
 // HandleOperatorCreation processes the request and creates an operator based on the provided input.
 // It supports various types of operators such as transfer-leader, transfer-region, add-peer, remove-peer, merge-region, split-region, scatter-region, and scatter-regions.
 // The function validates the input, performs the corresponding operation, and returns the HTTP status code, response body, and any error encountered during the process.
@@ -404,6 +440,116 @@ func (h *Handler) HandleOperatorCreation(input map[string]any) (int, any, error)
 	return http.StatusOK, nil, nil
 }
 
+// BuildOperators builds the operators described by the input, which has the
+// same format as HandleOperatorCreation, without adding them.
+func (h *Handler) BuildOperators(input map[string]any) ([]*operator.Operator, error) {
+	if name, _ := input["name"].(string); name == "scatter-regions" {
+		return nil, errors.Errorf("operator %s can not be built separately", name)
+	}
+	var ops []*operator.Operator
+	builder := &Handler{
+		Server:      h.Server,
+		opCollector: func(o ...*operator.Operator) { ops = append(ops, o...) },
+	}
+	if _, _, err := builder.HandleOperatorCreation(input); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// AddOperatorBatch creates a batch of operators with ordering dependencies.
+// Each element of the "operators" field has the same format as HandleOperatorCreation,
+// with an optional "depends" field listing the indexes of the operators it depends on.
+func (h *Handler) AddOperatorBatch(input map[string]any) (uint64, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return 0, err
+	}
+	desc, _ := input["desc"].(string)
+	policy, _ := input["policy"].(string)
+	maxRunning := 0
+	if m, ok := input["max-running"].(float64); ok {
+		maxRunning = int(m)
+	}
+	items, ok := input["operators"].([]any)
+	if !ok {
+		return 0, errors.Errorf("missing operators")
+	}
+	steps := make([]operator.BatchStep, 0, len(items))
+	for i, item := range items {
+		opInput, ok := item.(map[string]any)
+		if !ok {
+			return 0, errors.Errorf("bad format operator %d", i)
+		}
+		name, ok := opInput["name"].(string)
+		if !ok {
+			return 0, errors.Errorf("missing name of operator %d", i)
+		}
+		var depends []uint64
+		if _, ok := opInput["depends"]; ok {
+			if depends, ok = typeutil.JSONToUint64Slice(opInput["depends"]); !ok {
+				return 0, errors.Errorf("bad format depends of operator %d", i)
+			}
+		}
+		step := operator.BatchStep{
+			Desc:    name,
+			Depends: make([]int, 0, len(depends)),
+			Build: func() ([]*operator.Operator, error) {
+				return h.BuildOperators(opInput)
+			},
+		}
+		for _, dep := range depends {
+			step.Depends = append(step.Depends, int(dep))
+		}
+		steps = append(steps, step)
+	}
+	b, err := operator.NewBatch(desc, operator.BatchPolicy(policy), maxRunning, steps...)
+	if err != nil {
+		return 0, err
+	}
+	return oc.AddBatch(b), nil
+}
+
+// GetOperatorBatches returns the running and recently finished operator batches.
+func (h *Handler) GetOperatorBatches() ([]*operator.BatchObject, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	batches := oc.GetBatches()
+	objs := make([]*operator.BatchObject, 0, len(batches))
+	for _, b := range batches {
+		objs = append(objs, b.ToJSONObject())
+	}
+	return objs, nil
+}
+
+// GetOperatorBatch returns the operator batch with the given id.
+func (h *Handler) GetOperatorBatch(id uint64) (*operator.BatchObject, error) {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return nil, err
+	}
+	b := oc.GetBatch(id)
+	if b == nil {
+		return nil, errs.ErrOperatorNotFound
+	}
+	return b.ToJSONObject(), nil
+}
+
+// CancelOperatorBatch cancels the operator batch with the given id.
+func (h *Handler) CancelOperatorBatch(id uint64) error {
+	oc, err := h.GetOperatorController()
+	if err != nil {
+		return err
+	}
+	if oc.GetBatch(id) == nil {
+		return errs.ErrOperatorNotFound
+	}
+	_ = oc.CancelBatch(id, operator.AdminStop)
+	return nil
+}
+
 // AddTransferLeaderOperator adds an operator to transfer leader to the store.
 func (h *Handler) AddTransferLeaderOperator(regionID uint64, storeID uint64) error {
 	c := h.GetCluster()
@@ -718,6 +864,10 @@ func (h *Handler) AddScatterRegionsOperators(regionIDs []uint64, startRawKey, en
 }
 
 func (h *Handler) addOperator(ops ...*operator.Operator) error {
+	if h.opCollector != nil {
+		h.opCollector(ops...)
+		return nil
+	}
 	oc, err := h.GetOperatorController()
 	if err != nil {
 		return err
@@ -867,6 +1017,30 @@ func (h *Handler) GetSchedulerByStatus(status string, needTS bool) (any, error)
 			}
 		}
 		return disabledSchedulers, nil
+	case "out-of-window":
+		outOfWindowSchedulers := make([]string, 0, len(schedulers))
+		for _, scheduler := range schedulers {
+			inWindow, err := sc.IsSchedulerInTimeWindow(scheduler)
+			if err != nil {
+				return nil, err
+			}
+			if !inWindow {
+				outOfWindowSchedulers = append(outOfWindowSchedulers, scheduler)
+			}
+		}
+		return outOfWindowSchedulers, nil
+	case "shadow":
+		shadowSchedulers := make([]string, 0, len(schedulers))
+		for _, scheduler := range schedulers {
+			shadow, err := sc.IsSchedulerShadow(scheduler)
+			if err != nil {
+				return nil, err
+			}
+			if shadow {
+				shadowSchedulers = append(shadowSchedulers, scheduler)
+			}
+		}
+		return shadowSchedulers, nil
 	default:
 		// The default scheduler could not be deleted in scheduling server,
 		// so schedulers could only be disabled.
@@ -926,6 +1100,67 @@ func (h *Handler) PauseOrResumeScheduler(name string, t int64) (err error) {
 	return err
 }
 
+// SetSchedulerShadow enables or disables the shadow mode of a scheduler.
+// In shadow mode, the operators created by the scheduler are only recorded.
+// The mode is persisted and kept until the scheduler is removed.
+func (h *Handler) SetSchedulerShadow(name string, enable bool) error {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return err
+	}
+	if err = sc.SetSchedulerShadow(name, enable); err != nil {
+		log.Error("can not set scheduler shadow mode", zap.String("scheduler-name", name), zap.Bool("enable", enable), errs.ZapError(err))
+		return err
+	}
+	log.Info("set scheduler shadow mode successfully", zap.String("scheduler-name", name), zap.Bool("enable", enable))
+	return nil
+}
+
+// GetSchedulerShadowResults returns the shadow results of the specified scheduler.
+func (h *Handler) GetSchedulerShadowResults(name string) ([]*schedulers.ShadowResult, error) {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return nil, err
+	}
+	return sc.GetSchedulerShadowResults(name)
+}
+
+// GetScheduleTimeWindows returns the scheduling time windows of all the schedulers and checkers.
+func (h *Handler) GetScheduleTimeWindows() (map[string][]*config.TimeWindow, error) {
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return nil, err
+	}
+	return sc.GetTimeWindows().GetAll(), nil
+}
+
+// SetScheduleTimeWindows sets the scheduling time windows of a scheduler or checker.
+// The scheduler or checker is always allowed to run if the windows are empty.
+func (h *Handler) SetScheduleTimeWindows(name string, windows []*config.TimeWindow) error {
+	if !isCheckerName(name) && len(schedulers.FindSchedulerTypeByName(name)) == 0 {
+		return errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	sc, err := h.GetSchedulersController()
+	if err != nil {
+		return err
+	}
+	if err = sc.GetTimeWindows().Set(name, windows); err != nil {
+		log.Error("can not set scheduling time windows", zap.String("name", name), errs.ZapError(err))
+		return err
+	}
+	log.Info("set scheduling time windows successfully", zap.String("name", name), zap.Int("windows", len(windows)))
+	return nil
+}
+
+func isCheckerName(name string) bool {
+	switch types.CheckerSchedulerType(name) {
+	case types.JointStateChecker, types.LearnerChecker, types.MergeChecker,
+		types.ReplicaChecker, types.RuleChecker, types.SplitChecker, types.BucketSplitChecker:
+		return true
+	}
+	return false
+}
+
 // PauseOrResumeChecker pauses checker for delay seconds or resume checker
 // t == 0 : resume checker.
 // t > 0 : checker delays t seconds.
@@ -1086,6 +1321,45 @@ func (h *Handler) GetHotBuckets(regionIDs ...uint64) (HotBucketsResponse, error)
 	return ret, nil
 }
 
+// GetSplitAdvices returns the load-based split advices of the regions at the bucket boundaries.
+func (h *Handler) GetSplitAdvices() ([]*checker.SplitAdvice, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetBucketSplitChecker().GetSplitAdvices(), nil
+}
+
+// GetRepairProgress returns the status of the rule checker repair planner.
+func (h *Handler) GetRepairProgress() (*checker.RepairProgress, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetRuleChecker().GetRepairPlanner().GetRepairProgress(), nil
+}
+
+// GetMergeBacklog returns the pending merges found by the range merge planner.
+func (h *Handler) GetMergeBacklog() (*checker.MergeBacklog, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	return co.GetCheckerController().GetRangeMergePlanner().GetMergeBacklog(), nil
+}
+
+// GetRuleComplianceReport returns the compliance of all placement rules.
+func (h *Handler) GetRuleComplianceReport() (*checker.RuleComplianceReport, error) {
+	co := h.GetCoordinator()
+	if co == nil {
+		return nil, errs.ErrNotBootstrapped.GenWithStackByArgs()
+	}
+	if _, err := h.GetRuleManager(); err != nil {
+		return nil, err
+	}
+	return co.GetCheckerController().GetRuleChecker().GetRuleComplianceReport(), nil
+}
+
 // GetRegion returns the region labeler.
 func (h *Handler) GetRegion(id uint64) (*core.RegionInfo, error) {
 	c := h.GetCluster()
@@ -16,6 +16,7 @@ package labeler
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -214,6 +215,9 @@ func (l *RegionLabeler) getAndCheckRule(id string, now time.Time) *LabelRule {
 
 // SetLabelRule inserts or updates a LabelRule.
 func (l *RegionLabeler) SetLabelRule(rule *LabelRule) error {
+	if err := rule.validatePolicyLabels(); err != nil {
+		return err
+	}
 	l.Lock()
 	defer l.Unlock()
 	if err := l.SetLabelRuleLocked(rule); err != nil {
@@ -276,6 +280,9 @@ func (l *RegionLabeler) Patch(patch LabelRulePatch) error {
 	setRulesMap := make(map[string]*LabelRule)
 
 	for _, rule := range patch.SetRules {
+		if err := rule.validatePolicyLabels(); err != nil {
+			return err
+		}
 		if err := rule.checkAndAdjust(); err != nil {
 			return err
 		}
@@ -385,6 +392,22 @@ func (l *RegionLabeler) GetRegionLabels(region *core.RegionInfo) []*RegionLabel
 	return result
 }
 
+// GetRegionLabelRules returns the label rules which cover the region.
+func (l *RegionLabeler) GetRegionLabelRules(region *core.RegionInfo) []*LabelRule {
+	l.RLock()
+	defer l.RUnlock()
+	var rules []*LabelRule
+	if i, data := l.rangeList.GetData(region.GetStartKey(), region.GetEndKey()); i != -1 {
+		for _, rule := range data {
+			rules = append(rules, rule.(*LabelRule))
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
 // MakeKeyRanges is a helper function to make key ranges.
 func MakeKeyRanges(keys ...string) []any {
 	var res []any
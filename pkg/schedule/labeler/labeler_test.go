@@ -267,6 +267,12 @@ func TestIndex(t *testing.T) {
 			re.Equal(testCase.labels[k], labeler.GetRegionLabel(region, k))
 		}
 	}
+
+	region := core.NewTestRegionInfo(1, 1, []byte{0x12, 0x34}, []byte{0x56, 0x78})
+	labelRules := labeler.GetRegionLabelRules(region)
+	re.Len(labelRules, 2)
+	re.Equal("rule0", labelRules[0].ID)
+	re.Equal("rule1", labelRules[1].ID)
 }
 
 func TestSaveLoadRule(t *testing.T) {
@@ -494,3 +500,78 @@ func TestGC(t *testing.T) {
 	labeler.RUnlock()
 	re.LessOrEqual(currentRuleLen, 5)
 }
+
+func TestSchedulingPolicy(t *testing.T) {
+	re := require.New(t)
+	store := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	labeler, err := NewRegionLabeler(ctx, store, time.Millisecond*10)
+	re.NoError(err)
+
+	badLabels := []RegionLabel{
+		{Key: MaxRegionSizeLabel, Value: "abc"},
+		{Key: MaxRegionSizeLabel, Value: "0"},
+		{Key: MergeOptionLabel, Value: "maybe"},
+		{Key: HotScheduleLabel, Value: "true"},
+		{Key: HotSplitThresholdsLabel, Value: "abc"},
+		{Key: HotSplitThresholdsLabel, Value: "0"},
+		{Key: HotSplitThresholdsLabel, Value: "1.5"},
+	}
+	for _, l := range badLabels {
+		err := labeler.SetLabelRule(&LabelRule{ID: "bad", Labels: []RegionLabel{l}, RuleType: KeyRange, Data: MakeKeyRanges("", "")})
+		re.Error(err)
+	}
+	re.Nil(labeler.GetLabelRule("bad"))
+
+	rules := []*LabelRule{
+		{ID: "rule1", Index: 1, RuleType: KeyRange, Data: MakeKeyRanges("1234", "5678"), Labels: []RegionLabel{
+			{Key: MaxRegionSizeLabel, Value: "64"},
+			{Key: MergeOptionLabel, Value: PolicyValueDeny},
+			{Key: LeaderZoneLabel, Value: "z1"},
+		}},
+		{ID: "rule2", Index: 2, RuleType: KeyRange, Data: MakeKeyRanges("3456", "5678"), Labels: []RegionLabel{
+			{Key: MergeOptionLabel, Value: PolicyValueAllow},
+			{Key: HotScheduleLabel, Value: "DENY"},
+			{Key: HotSplitThresholdsLabel, Value: "0.05"},
+		}},
+	}
+	for _, r := range rules {
+		re.NoError(labeler.SetLabelRule(r))
+	}
+
+	policy := labeler.GetSchedulingPolicy(core.NewTestRegionInfo(1, 1, []byte{0x12, 0x34}, []byte{0x23, 0x45}))
+	re.Equal(&SchedulingPolicy{MaxRegionSize: 64, MergeDisabled: true, LeaderZone: "z1"}, policy)
+	re.True(policy.AllowLeaderOnStore(core.NewStoreInfoWithLabel(1, map[string]string{"zone": "z1"}), "zone"))
+	re.False(policy.AllowLeaderOnStore(core.NewStoreInfoWithLabel(2, map[string]string{"zone": "z2"}), "zone"))
+	// The store label key of the zone is configurable.
+	re.True(policy.AllowLeaderOnStore(core.NewStoreInfoWithLabel(3, map[string]string{"zone": "z2", "dc": "z1"}), "dc"))
+	re.False(policy.AllowLeaderOnStore(core.NewStoreInfoWithLabel(1, map[string]string{"zone": "z1"}), "dc"))
+
+	// the rule with higher index wins.
+	policy = labeler.GetSchedulingPolicy(core.NewTestRegionInfo(2, 1, []byte{0x34, 0x56}, []byte{0x45, 0x67}))
+	re.Equal(&SchedulingPolicy{MaxRegionSize: 64, HotScheduleDenied: true, LeaderZone: "z1", HotSplitThresholds: 0.05}, policy)
+
+	policy = labeler.GetSchedulingPolicy(core.NewTestRegionInfo(3, 1, []byte{0x56, 0x78}, []byte{0x67, 0x89}))
+	re.True(policy.IsEmpty())
+	re.True(policy.AllowLeaderOnStore(core.NewStoreInfoWithLabel(2, map[string]string{"zone": "z2"}), "zone"))
+
+	// The invalid policy labels are also rejected by the patch.
+	re.Error(labeler.Patch(LabelRulePatch{SetRules: []*LabelRule{
+		{ID: "bad", Labels: badLabels[:1], RuleType: KeyRange, Data: MakeKeyRanges("", "")},
+	}}))
+	re.Nil(labeler.GetLabelRule("bad"))
+
+	// The stored rules with invalid policy labels are still loaded, only the invalid labels are ignored.
+	re.NoError(store.RunInTxn(ctx, func(txn kv.Txn) error {
+		return store.SaveRegionRule(txn, "stored", &LabelRule{ID: "stored", Index: 3, RuleType: KeyRange, Data: MakeKeyRanges("5678", ""), Labels: []RegionLabel{
+			{Key: MergeOptionLabel, Value: "maybe"},
+			{Key: HotScheduleLabel, Value: PolicyValueDeny},
+		}})
+	}))
+	labeler, err = NewRegionLabeler(ctx, store, time.Millisecond*10)
+	re.NoError(err)
+	re.NotNil(labeler.GetLabelRule("stored"))
+	policy = labeler.GetSchedulingPolicy(core.NewTestRegionInfo(3, 1, []byte{0x56, 0x78}, []byte{0x67, 0x89}))
+	re.Equal(&SchedulingPolicy{HotScheduleDenied: true}, policy)
+}
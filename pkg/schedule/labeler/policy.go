@@ -0,0 +1,160 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package labeler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// The label keys below are interpreted as scheduling policies. A label rule
+// carrying one of them changes how the checkers and schedulers treat the
+// regions it covers.
+const (
+	// MaxRegionSizeLabel limits the approximate size (in MiB) of the regions.
+	// Regions larger than the limit are split by the split checker, and the
+	// merge checker does not merge regions beyond it.
+	MaxRegionSizeLabel = "max_region_size"
+	// MergeOptionLabel controls whether the regions can be merged.
+	MergeOptionLabel = "merge_option"
+	// HotScheduleLabel controls whether the hot region scheduler can schedule the regions.
+	HotScheduleLabel = "hot_schedule"
+	// LeaderZoneLabel restricts the leaders of the regions to the stores in the given zone,
+	// the zone of a store is the value of its store label configured by `leader-zone-label`.
+	LeaderZoneLabel = "leader_zone"
+	// HotSplitThresholdsLabel overrides the `split-thresholds` of the hot region scheduler
+	// for the regions, e.g. to split the hot key ranges of a table more aggressively.
+	HotSplitThresholdsLabel = "hot_split_thresholds"
+
+	// PolicyValueAllow is the value to allow the scheduling behavior.
+	PolicyValueAllow = "allow"
+	// PolicyValueDeny is the value to deny the scheduling behavior.
+	PolicyValueDeny = "deny"
+
+	// The same range as the `split-thresholds` of the hot region scheduler.
+	minHotSplitThresholds = 0.01
+	maxHotSplitThresholds = 1.0
+)
+
+// SchedulingPolicy is the scheduling attributes of a region resolved from the label rules.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type SchedulingPolicy struct {
+	// MaxRegionSize is the max approximate region size in MiB, 0 means no limit.
+	MaxRegionSize     uint64 `json:"max_region_size,omitempty"`
+	MergeDisabled     bool   `json:"merge_disabled,omitempty"`
+	HotScheduleDenied bool   `json:"hot_schedule_denied,omitempty"`
+	LeaderZone        string `json:"leader_zone,omitempty"`
+	// HotSplitThresholds is the split thresholds of the hot region scheduler, 0 means using the scheduler config.
+	HotSplitThresholds float64 `json:"hot_split_thresholds,omitempty"`
+}
+
+// IsEmpty returns true if the policy does not change any scheduling behavior.
+func (p *SchedulingPolicy) IsEmpty() bool {
+	return p == nil || *p == SchedulingPolicy{}
+}
+
+// AllowLeaderOnStore returns true if the leader of the region can be placed on the store.
+// The zoneLabel is the store label key of the zone.
+func (p *SchedulingPolicy) AllowLeaderOnStore(store *core.StoreInfo, zoneLabel string) bool {
+	if p == nil || p.LeaderZone == "" {
+		return true
+	}
+	return store != nil && store.GetLabelValue(zoneLabel) == p.LeaderZone
+}
+
+// validatePolicyLabel checks the value of the label if its key is a policy label.
+func validatePolicyLabel(l *RegionLabel) error {
+	switch l.Key {
+	case MaxRegionSizeLabel:
+		size, err := strconv.ParseUint(l.Value, 10, 64)
+		if err != nil || size == 0 {
+			return errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid %s value: %s", l.Key, l.Value))
+		}
+	case MergeOptionLabel, HotScheduleLabel:
+		if !strings.EqualFold(l.Value, PolicyValueAllow) && !strings.EqualFold(l.Value, PolicyValueDeny) {
+			return errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid %s value: %s, should be %s or %s",
+				l.Key, l.Value, PolicyValueAllow, PolicyValueDeny))
+		}
+	case HotSplitThresholdsLabel:
+		thresholds, err := strconv.ParseFloat(l.Value, 64)
+		if err != nil || thresholds < minHotSplitThresholds || thresholds > maxHotSplitThresholds {
+			return errs.ErrRegionRuleContent.FastGenByArgs(fmt.Sprintf("invalid %s value: %s, should be in [%v, %v]",
+				l.Key, l.Value, minHotSplitThresholds, maxHotSplitThresholds))
+		}
+	}
+	return nil
+}
+
+// validatePolicyLabels checks the policy labels of the rule. It is only called when
+// the rule is set by the user, so that the stored rules are still loaded even if
+// the validation becomes stricter.
+func (rule *LabelRule) validatePolicyLabels() error {
+	for i := range rule.Labels {
+		if err := validatePolicyLabel(&rule.Labels[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyTo updates the policy according to the label. The label must be validated before.
+func (l *RegionLabel) applyTo(p *SchedulingPolicy) {
+	switch l.Key {
+	case MaxRegionSizeLabel:
+		p.MaxRegionSize, _ = strconv.ParseUint(l.Value, 10, 64)
+	case MergeOptionLabel:
+		p.MergeDisabled = strings.EqualFold(l.Value, PolicyValueDeny)
+	case HotScheduleLabel:
+		p.HotScheduleDenied = strings.EqualFold(l.Value, PolicyValueDeny)
+	case LeaderZoneLabel:
+		p.LeaderZone = l.Value
+	case HotSplitThresholdsLabel:
+		p.HotSplitThresholds, _ = strconv.ParseFloat(l.Value, 64)
+	}
+}
+
+// GetSchedulingPolicy returns the scheduling policy of the region.
+// If there are multiple rules that set the same policy label, the one with max rule index wins.
+func (l *RegionLabeler) GetSchedulingPolicy(region *core.RegionInfo) *SchedulingPolicy {
+	l.RLock()
+	defer l.RUnlock()
+	policy := &SchedulingPolicy{}
+	i, data := l.rangeList.GetData(region.GetStartKey(), region.GetEndKey())
+	if i == -1 {
+		return policy
+	}
+	now := time.Now()
+	indexes := make(map[string]int)
+	for _, rule := range data {
+		r := rule.(*LabelRule)
+		for _, label := range r.Labels {
+			// The invalid labels may be loaded from the storage, ignore them.
+			if label.expireBefore(now) || validatePolicyLabel(&label) != nil {
+				continue
+			}
+			if old, ok := indexes[label.Key]; ok && old >= r.Index {
+				continue
+			}
+			indexes[label.Key] = r.Index
+			label.applyTo(policy)
+		}
+	}
+	return policy
+}
@@ -0,0 +1,360 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const batchIDKey = "batch-id"
+
+// BatchPolicy decides what to do with a batch when one of its steps fails.
+type BatchPolicy string
+
+const (
+	// BatchAllOrCancel cancels the whole batch once any step of it fails.
+	BatchAllOrCancel BatchPolicy = "all-or-cancel"
+	// BatchBestEffort skips the steps depending on the failed one and keeps
+	// running the others.
+	BatchBestEffort BatchPolicy = "best-effort"
+)
+
+// BatchStepBuilder builds the operators of a batch step. It is called only
+// after all the dependencies of the step have succeeded, so the operators are
+// created against the latest region state.
+type BatchStepBuilder func() ([]*Operator, error)
+
+// BatchStep describes a step of a batch.
+type BatchStep struct {
+	Desc string
+	// Depends are the indexes of the steps that must succeed before this step
+	// starts. A step can only depend on the steps before it.
+	Depends []int
+	Build   BatchStepBuilder
+}
+
+type batchStep struct {
+	BatchStep
+	status     OpStatusTracker
+	ops        []*Operator
+	unfinished int
+	err        string
+}
+
+// Batch groups operators across regions with ordering dependencies.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type Batch struct {
+	syncutil.RWMutex
+	id           uint64
+	desc         string
+	policy       BatchPolicy
+	maxRunning   int
+	steps        []*batchStep
+	status       OpStatusTracker
+	cancelReason string
+}
+
+// NewBatch creates a batch. maxRunning limits the number of steps running at
+// the same time, 0 means no limit.
+func NewBatch(desc string, policy BatchPolicy, maxRunning int, steps ...BatchStep) (*Batch, error) {
+	switch policy {
+	case "":
+		policy = BatchAllOrCancel
+	case BatchAllOrCancel, BatchBestEffort:
+	default:
+		return nil, errors.Errorf("unknown batch policy %s", policy)
+	}
+	if maxRunning < 0 {
+		return nil, errors.Errorf("invalid max running %d", maxRunning)
+	}
+	if len(steps) == 0 {
+		return nil, errors.New("batch should have at least one step")
+	}
+	b := &Batch{
+		desc:       desc,
+		policy:     policy,
+		maxRunning: maxRunning,
+		steps:      make([]*batchStep, 0, len(steps)),
+		status:     NewOpStatusTracker(),
+	}
+	for i, step := range steps {
+		if step.Build == nil {
+			return nil, errors.Errorf("step %d has no builder", i)
+		}
+		for _, dep := range step.Depends {
+			if dep < 0 || dep >= i {
+				return nil, errors.Errorf("step %d can not depend on step %d", i, dep)
+			}
+		}
+		b.steps = append(b.steps, &batchStep{BatchStep: step, status: NewOpStatusTracker()})
+	}
+	return b, nil
+}
+
+// ID returns the id of the batch.
+func (b *Batch) ID() uint64 {
+	b.RLock()
+	defer b.RUnlock()
+	return b.id
+}
+
+// Status returns the status of the batch.
+func (b *Batch) Status() OpStatus {
+	return b.status.Status()
+}
+
+// IsEnd checks whether the batch is finished or canceled.
+func (b *Batch) IsEnd() bool {
+	return b.status.IsEnd()
+}
+
+func (b *Batch) start(id uint64) bool {
+	b.Lock()
+	defer b.Unlock()
+	b.id = id
+	return b.status.To(STARTED)
+}
+
+// popReadySteps marks the steps whose dependencies all succeeded as started
+// and returns them, respecting the running limit.
+func (b *Batch) popReadySteps() []*batchStep {
+	b.Lock()
+	defer b.Unlock()
+	if b.status.IsEnd() {
+		return nil
+	}
+	running := 0
+	for _, step := range b.steps {
+		if step.status.Status() == STARTED {
+			running++
+		}
+	}
+	var ready []*batchStep
+	for _, step := range b.steps {
+		if b.maxRunning > 0 && running >= b.maxRunning {
+			break
+		}
+		if step.status.Status() != CREATED || !b.isDependencyDoneLocked(step) {
+			continue
+		}
+		_ = step.status.To(STARTED)
+		ready = append(ready, step)
+		running++
+	}
+	return ready
+}
+
+func (b *Batch) isDependencyDoneLocked(step *batchStep) bool {
+	for _, dep := range step.Depends {
+		if b.steps[dep].status.Status() != SUCCESS {
+			return false
+		}
+	}
+	return true
+}
+
+// setStepOperators binds the built operators to the step. It returns false if
+// the batch has been ended meanwhile.
+func (b *Batch) setStepOperators(step *batchStep, ops []*Operator) bool {
+	b.Lock()
+	defer b.Unlock()
+	if b.status.IsEnd() {
+		return false
+	}
+	step.ops = ops
+	step.unfinished = len(ops)
+	if len(ops) == 0 {
+		_ = step.status.To(SUCCESS)
+		b.checkFinishLocked()
+		return true
+	}
+	for _, op := range ops {
+		op.SetAdditionalInfo(batchIDKey, fmt.Sprint(b.id))
+	}
+	return true
+}
+
+// failStep marks the step as failed. It returns true if the whole batch
+// should be canceled.
+func (b *Batch) failStep(step *batchStep, reason string) bool {
+	b.Lock()
+	defer b.Unlock()
+	return b.failStepLocked(step, reason)
+}
+
+func (b *Batch) failStepLocked(step *batchStep, reason string) bool {
+	if !step.status.To(CANCELED) {
+		return false
+	}
+	step.err = reason
+	if b.policy == BatchAllOrCancel {
+		return true
+	}
+	// Skip all the steps which depend on the failed step directly or indirectly.
+	for _, s := range b.steps {
+		if s.status.Status() == CREATED && b.isDependencyFailedLocked(s) {
+			_ = s.status.To(CANCELED)
+			s.err = "dependency failed"
+		}
+	}
+	b.checkFinishLocked()
+	return false
+}
+
+func (b *Batch) isDependencyFailedLocked(step *batchStep) bool {
+	for _, dep := range step.Depends {
+		if b.steps[dep].status.Status() == CANCELED {
+			return true
+		}
+	}
+	return false
+}
+
+// operatorEnd updates the step which the operator belongs to. It returns true
+// if the whole batch should be canceled.
+func (b *Batch) operatorEnd(op *Operator) bool {
+	b.Lock()
+	defer b.Unlock()
+	if b.status.IsEnd() {
+		return false
+	}
+	for _, step := range b.steps {
+		for _, o := range step.ops {
+			if o != op {
+				continue
+			}
+			if op.Status() != SUCCESS {
+				return b.failStepLocked(step, fmt.Sprintf("operator of region %d is %s", op.RegionID(), OpStatusToString(op.Status())))
+			}
+			step.unfinished--
+			if step.unfinished == 0 {
+				_ = step.status.To(SUCCESS)
+				b.checkFinishLocked()
+			}
+			return false
+		}
+	}
+	return false
+}
+
+func (b *Batch) checkFinishLocked() {
+	failed := 0
+	for _, step := range b.steps {
+		switch step.status.Status() {
+		case SUCCESS:
+		case CANCELED:
+			failed++
+		default:
+			return
+		}
+	}
+	if failed == 0 {
+		_ = b.status.To(SUCCESS)
+	} else {
+		b.cancelReason = fmt.Sprintf("%d step(s) failed", failed)
+		_ = b.status.To(CANCELED)
+	}
+	batchCounter.WithLabelValues(b.status.String()).Inc()
+}
+
+// cancel marks the batch and all its unfinished steps canceled, and returns
+// the operators which are still running.
+func (b *Batch) cancel(reason string) []*Operator {
+	b.Lock()
+	defer b.Unlock()
+	if b.status.IsEnd() {
+		return nil
+	}
+	var running []*Operator
+	for _, step := range b.steps {
+		if step.status.IsEnd() {
+			continue
+		}
+		if step.status.Status() == STARTED {
+			for _, op := range step.ops {
+				if !op.IsEnd() {
+					running = append(running, op)
+				}
+			}
+		}
+		_ = step.status.To(CANCELED)
+		if step.err == "" {
+			step.err = reason
+		}
+	}
+	b.cancelReason = reason
+	_ = b.status.To(CANCELED)
+	batchCounter.WithLabelValues(b.status.String()).Inc()
+	return running
+}
+
+// BatchStepObject is used to return a batch step as a json object for API.
+type BatchStepObject struct {
+	Desc      string      `json:"desc"`
+	Depends   []int       `json:"depends,omitempty"`
+	Status    string      `json:"status"`
+	Operators []*OpObject `json:"operators,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// BatchObject is used to return a batch as a json object for API.
+type BatchObject struct {
+	ID           uint64             `json:"id"`
+	Desc         string             `json:"desc"`
+	Policy       BatchPolicy        `json:"policy"`
+	MaxRunning   int                `json:"max-running"`
+	Status       string             `json:"status"`
+	CreateTime   time.Time          `json:"create-time"`
+	FinishTime   time.Time          `json:"finish-time,omitempty"`
+	CancelReason string             `json:"cancel-reason,omitempty"`
+	Steps        []*BatchStepObject `json:"steps"`
+}
+
+// ToJSONObject serializes the batch as a JSON object.
+func (b *Batch) ToJSONObject() *BatchObject {
+	b.RLock()
+	defer b.RUnlock()
+	obj := &BatchObject{
+		ID:           b.id,
+		Desc:         b.desc,
+		Policy:       b.policy,
+		MaxRunning:   b.maxRunning,
+		Status:       b.status.String(),
+		CreateTime:   b.status.ReachTimeOf(CREATED),
+		CancelReason: b.cancelReason,
+		Steps:        make([]*BatchStepObject, 0, len(b.steps)),
+	}
+	if b.status.IsEnd() {
+		obj.FinishTime = b.status.ReachTime()
+	}
+	for _, step := range b.steps {
+		s := &BatchStepObject{
+			Desc:    step.Desc,
+			Depends: step.Depends,
+			Status:  step.status.String(),
+			Error:   step.err,
+		}
+		for _, op := range step.ops {
+			s.Operators = append(s.Operators, op.ToJSONObject())
+		}
+		obj.Steps = append(obj.Steps, s)
+	}
+	return obj
+}
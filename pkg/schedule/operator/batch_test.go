@@ -0,0 +1,163 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+)
+
+func TestNewBatch(t *testing.T) {
+	re := require.New(t)
+	build := func() ([]*Operator, error) { return nil, nil }
+	_, err := NewBatch("test", "unknown", 0, BatchStep{Build: build})
+	re.Error(err)
+	_, err = NewBatch("test", BatchAllOrCancel, -1, BatchStep{Build: build})
+	re.Error(err)
+	_, err = NewBatch("test", BatchAllOrCancel, 0)
+	re.Error(err)
+	_, err = NewBatch("test", BatchAllOrCancel, 0, BatchStep{Build: build, Depends: []int{0}})
+	re.Error(err)
+	_, err = NewBatch("test", BatchAllOrCancel, 0, BatchStep{Build: build}, BatchStep{Build: build, Depends: []int{2}})
+	re.Error(err)
+	b, err := NewBatch("test", "", 0, BatchStep{Build: build}, BatchStep{Build: build, Depends: []int{0}})
+	re.NoError(err)
+	re.Equal(BatchAllOrCancel, b.policy)
+	re.Equal(CREATED, b.Status())
+}
+
+func prepareBatchTest(ctx context.Context) (*mockcluster.Cluster, *Controller) {
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc, false /* no need to run */)
+	oc := NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 3)
+	tc.AddLeaderStore(2, 0)
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddLeaderRegion(i, 1, 2)
+	}
+	return tc, oc
+}
+
+func transferLeaderStep(tc *mockcluster.Cluster, regionID uint64, depends ...int) BatchStep {
+	return BatchStep{
+		Desc:    "transfer-leader",
+		Depends: depends,
+		Build: func() ([]*Operator, error) {
+			region := tc.GetRegion(regionID)
+			return []*Operator{NewTestOperator(regionID, region.GetRegionEpoch(), OpLeader, TransferLeader{FromStore: 1, ToStore: 2})}, nil
+		},
+	}
+}
+
+func finishOperator(tc *mockcluster.Cluster, oc *Controller, regionID uint64) {
+	ApplyOperator(tc, oc.GetOperator(regionID))
+	oc.Dispatch(tc.GetRegion(regionID), "test", nil)
+}
+
+func TestBatchDependency(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc, oc := prepareBatchTest(ctx)
+
+	b, err := NewBatch("test", BatchAllOrCancel, 1,
+		transferLeaderStep(tc, 1),
+		transferLeaderStep(tc, 2, 0),
+		transferLeaderStep(tc, 3))
+	re.NoError(err)
+	id := oc.AddBatch(b)
+	re.Equal(b, oc.GetBatch(id))
+	re.Equal(STARTED, b.Status())
+	// Only one step can run at the same time.
+	re.NotNil(oc.GetOperator(1))
+	re.Nil(oc.GetOperator(2))
+	re.Nil(oc.GetOperator(3))
+
+	finishOperator(tc, oc, 1)
+	re.NotNil(oc.GetOperator(2))
+	re.Nil(oc.GetOperator(3))
+	finishOperator(tc, oc, 2)
+	re.NotNil(oc.GetOperator(3))
+	finishOperator(tc, oc, 3)
+	re.Equal(SUCCESS, b.Status())
+
+	obj := b.ToJSONObject()
+	re.Equal(id, obj.ID)
+	re.Len(obj.Steps, 3)
+	for _, step := range obj.Steps {
+		re.Equal(OpStatusToString(SUCCESS), step.Status)
+		re.Len(step.Operators, 1)
+	}
+}
+
+func TestBatchAllOrCancel(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc, oc := prepareBatchTest(ctx)
+
+	b, err := NewBatch("test", BatchAllOrCancel, 0,
+		transferLeaderStep(tc, 1),
+		transferLeaderStep(tc, 2),
+		transferLeaderStep(tc, 3, 0))
+	re.NoError(err)
+	oc.AddBatch(b)
+	op1, op2 := oc.GetOperator(1), oc.GetOperator(2)
+	re.NotNil(op1)
+	re.NotNil(op2)
+	re.Nil(oc.GetOperator(3))
+
+	// Canceling one operator cancels the whole batch.
+	re.True(oc.RemoveOperator(op1, AdminStop))
+	re.Equal(CANCELED, b.Status())
+	re.Nil(oc.GetOperator(2))
+	re.Equal(CANCELED, op2.Status())
+	re.Equal(string(BatchCanceled), op2.GetAdditionalInfo(cancelReason))
+	re.Nil(oc.GetOperator(3))
+	re.False(oc.CancelBatch(b.ID(), AdminStop))
+}
+
+func TestBatchBestEffort(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc, oc := prepareBatchTest(ctx)
+
+	b, err := NewBatch("test", BatchBestEffort, 0,
+		transferLeaderStep(tc, 1),
+		transferLeaderStep(tc, 2),
+		transferLeaderStep(tc, 3, 0))
+	re.NoError(err)
+	oc.AddBatch(b)
+	re.True(oc.RemoveOperator(oc.GetOperator(1), AdminStop))
+	// The step depending on the failed one is skipped, the others keep running.
+	re.Equal(STARTED, b.Status())
+	re.NotNil(oc.GetOperator(2))
+	re.Nil(oc.GetOperator(3))
+	finishOperator(tc, oc, 2)
+	re.Equal(CANCELED, b.Status())
+
+	obj := b.ToJSONObject()
+	re.Equal(OpStatusToString(CANCELED), obj.Steps[0].Status)
+	re.Equal(OpStatusToString(SUCCESS), obj.Steps[1].Status)
+	re.Equal(OpStatusToString(CANCELED), obj.Steps[2].Status)
+	re.Equal("2 step(s) failed", obj.CancelReason)
+}
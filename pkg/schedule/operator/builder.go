@@ -410,7 +410,11 @@ func (b *Builder) Build(kind OpKind) (*Operator, error) {
 		return nil, b.err
 	}
 
-	return NewOperator(b.desc, brief, b.regionID, b.regionEpoch, kind, b.approximateSize, b.steps...), nil
+	op := NewOperator(b.desc, brief, b.regionID, b.regionEpoch, kind, b.approximateSize, b.steps...)
+	for storeID := range b.originPeers {
+		op.originStores = append(op.originStores, storeID)
+	}
+	return op, nil
 }
 
 // Initialize intermediate states.
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/log"
 
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/errs"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/placement"
@@ -79,6 +80,46 @@ func CreateRemovePeerOperator(desc string, ci sche.SharedCluster, kind OpKind, r
 		Build(kind)
 }
 
+// CreateRollbackOperator creates an operator that removes the peers added by
+// the partially applied operator which is timeout or canceled. The region must
+// consist of the original peers and the added peers exactly, otherwise it has
+// been changed by others and should be handled by the checkers instead.
+func CreateRollbackOperator(ci sche.SharedCluster, region *core.RegionInfo, origin *Operator) (*Operator, error) {
+	added := origin.RollbackStores()
+	if len(added) == 0 || len(origin.originStores) == 0 {
+		return nil, errors.Errorf("operator of region %d cannot be rolled back", region.GetID())
+	}
+	expected := make(map[uint64]struct{}, len(origin.originStores)+len(added))
+	for _, storeID := range origin.originStores {
+		expected[storeID] = struct{}{}
+	}
+	for _, storeID := range added {
+		expected[storeID] = struct{}{}
+	}
+	current := region.GetStoreIDs()
+	if len(current) != len(expected) {
+		return nil, errors.Errorf("the peers of region %d are changed since the operator is created", region.GetID())
+	}
+	for storeID := range expected {
+		if _, ok := current[storeID]; !ok {
+			return nil, errors.Errorf("the peers of region %d are changed since the operator is created", region.GetID())
+		}
+	}
+	// The original peers may not match the placement rules either, so the
+	// rules check is skipped to revert the region as it was.
+	b := NewBuilder("rollback-"+origin.Desc(), ci, region, SkipPlacementRulesCheck)
+	for _, storeID := range added {
+		b.RemovePeer(storeID)
+	}
+	op, err := b.Build(OpReplica)
+	if err != nil {
+		return nil, err
+	}
+	op.SetAdditionalInfo(RollbackReason, origin.GetCancelReason())
+	op.SetPriorityLevel(constant.High)
+	return op, nil
+}
+
 // CreateTransferLeaderOperator creates an operator that transfers the leader from a source store to a target store.
 func CreateTransferLeaderOperator(desc string, ci sche.SharedCluster, region *core.RegionInfo, targetStoreID uint64, targetStoreIDs []uint64, kind OpKind) (*Operator, error) {
 	return NewBuilder(desc, ci, region, SkipOriginJointStateCheck).
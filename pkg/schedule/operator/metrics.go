@@ -65,6 +65,46 @@ var (
 			Buckets:   []float64{0.5, 1, 2, 4, 8, 16, 20, 40, 60, 90, 120, 180, 240, 300, 480, 600, 720, 900, 1200, 1800, 3600},
 		}, []string{"type"})
 
+	batchCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_batch_count",
+			Help:      "Counter of operator batches.",
+		}, []string{"event"})
+
+	operatorRollbackCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_rollback_count",
+			Help:      "Counter of rolling back the partially applied operators.",
+		}, []string{"type", "reason", "event"})
+
+	operatorPreemptedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_preempted_count",
+			Help:      "Counter of the operators preempted by the higher priority operators.",
+		}, []string{"type", "preemptor"})
+
+	operatorExceededQuotaCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "operator_exceeded_quota_count",
+			Help:      "Counter of the operators exceeding the store limit quota of their schedulers.",
+		}, []string{"scheduler"})
+
+	storeLimitQuotaTokensCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pd",
+			Subsystem: "schedule",
+			Name:      "store_limit_quota_tokens",
+			Help:      "Counter of the store limit tokens taken by the schedulers with quota.",
+		}, []string{"scheduler", "store", "type"})
+
 	operatorSizeHist = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "pd",
@@ -82,6 +122,11 @@ func init() {
 	prometheus.MustRegister(operatorCounter)
 	prometheus.MustRegister(operatorDuration)
 	prometheus.MustRegister(operatorSizeHist)
+	prometheus.MustRegister(batchCounter)
+	prometheus.MustRegister(operatorRollbackCounter)
+	prometheus.MustRegister(operatorPreemptedCounter)
+	prometheus.MustRegister(operatorExceededQuotaCounter)
+	prometheus.MustRegister(storeLimitQuotaTokensCounter)
 }
 
 // IncOperatorLimitCounter increases the counter of operator meeting limit.
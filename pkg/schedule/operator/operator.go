@@ -17,6 +17,7 @@ package operator
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -65,6 +66,12 @@ var (
 	ExceedWaitLimit CancelReasonType = "exceed wait limit"
 	// RelatedMergeRegion is the cancel reason when the operator is cancelled by related merge region.
 	RelatedMergeRegion CancelReasonType = "related merge region"
+	// BatchCanceled is the cancel reason when the operator is cancelled by the batch it belongs to.
+	BatchCanceled CancelReasonType = "batch canceled"
+	// Preempted is the cancel reason when the operator is preempted by a higher priority operator.
+	Preempted CancelReasonType = "preempted"
+	// Shadowed is the cancel reason when the operator is created by a scheduler in shadow mode.
+	Shadowed CancelReasonType = "shadowed"
 	// Unknown is the cancel reason when the operator is cancelled by an unknown reason.
 	Unknown CancelReasonType = "unknown"
 )
@@ -82,12 +89,16 @@ type Operator struct {
 	currentStep      int32
 	status           OpStatusTracker
 	level            constant.PriorityLevel
+	source           string
 	Counters         []prometheus.Counter
 	FinishedCounters []prometheus.Counter
 	additionalInfos  opAdditionalInfo
 	ApproximateSize  int64
 	timeout          time.Duration
 	influence        *OpInfluence
+	// originStores are the stores of the region peers when the operator is built,
+	// which are used to verify the region before rolling back the operator.
+	originStores []uint64
 }
 
 // NewOperator creates a new operator.
@@ -415,6 +426,17 @@ func (o *Operator) GetPriorityLevel() constant.PriorityLevel {
 	return o.level
 }
 
+// SetSource sets the name of the scheduler which creates the operator.
+func (o *Operator) SetSource(source string) {
+	o.source = source
+}
+
+// GetSource returns the name of the scheduler which creates the operator.
+// It returns empty string if the operator is not created by a scheduler.
+func (o *Operator) GetSource() string {
+	return o.source
+}
+
 // UnfinishedInfluence calculates the store difference which unfinished operator steps make.
 func (o *Operator) UnfinishedInfluence(opInfluence OpInfluence, region *core.RegionInfo) {
 	for step := atomic.LoadInt32(&o.currentStep); int(step) < len(o.steps); step++ {
@@ -481,12 +503,73 @@ func (o *Operator) History() []OpHistory {
 	return histories
 }
 
+// GetCancelReason returns the reason why the operator is canceled.
+func (o *Operator) GetCancelReason() string {
+	return o.GetAdditionalInfo(cancelReason)
+}
+
+// RelatedStores returns the stores involved in the operator's steps, in the
+// order they first appear.
+func (o *Operator) RelatedStores() []uint64 {
+	var stores []uint64
+	add := func(ids ...uint64) {
+		for _, id := range ids {
+			if id != 0 && !slices.Contains(stores, id) {
+				stores = append(stores, id)
+			}
+		}
+	}
+	for _, step := range o.steps {
+		switch s := step.(type) {
+		case TransferLeader:
+			add(s.FromStore, s.ToStore)
+			add(s.ToStores...)
+		case AddPeer:
+			add(s.ToStore)
+		case AddLearner:
+			add(s.ToStore)
+		case PromoteLearner:
+			add(s.ToStore)
+		case RemovePeer:
+			add(s.FromStore)
+		case BecomeWitness:
+			add(s.StoreID)
+		case BecomeNonWitness:
+			add(s.StoreID)
+		case ChangePeerV2Enter:
+			for _, pl := range s.PromoteLearners {
+				add(pl.ToStore)
+			}
+			for _, dv := range s.DemoteVoters {
+				add(dv.ToStore)
+			}
+		case ChangePeerV2Leave:
+			for _, pl := range s.PromoteLearners {
+				add(pl.ToStore)
+			}
+			for _, dv := range s.DemoteVoters {
+				add(dv.ToStore)
+			}
+		case BatchSwitchWitness:
+			for _, w := range s.ToWitnesses {
+				add(w.StoreID)
+			}
+			for _, nw := range s.ToNonWitnesses {
+				add(nw.StoreID)
+			}
+		}
+	}
+	return stores
+}
+
 // OpRecord is used to log and visualize completed operators.
 // NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
 type OpRecord struct {
 	*Operator
 	FinishTime time.Time
-	duration   time.Duration
+	// Seq is the sequence number of the record in the operator controller.
+	Seq      uint64
+	duration time.Duration
 }
 
 func (o *OpRecord) String() string {
@@ -498,6 +581,11 @@ func (o *OpRecord) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + o.String() + `"`), nil
 }
 
+// Duration returns the duration the operator takes before it finishes.
+func (o *OpRecord) Duration() time.Duration {
+	return o.duration
+}
+
 // Record transfers the operator to OpRecord.
 func (o *Operator) Record(finishTime time.Time) *OpRecord {
 	step := atomic.LoadInt32(&o.currentStep)
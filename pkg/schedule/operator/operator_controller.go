@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -101,6 +102,18 @@ type Controller struct {
 	wop       WaitingOperator
 	wopStatus *waitingOperatorStatus
 	counts    *opCounter
+
+	// batches groups operators across regions with ordering dependencies.
+	batches sync.Map
+	batchID atomic.Uint64
+
+	// sourceLimits tracks the store limit tokens taken by each scheduler, it is
+	// keyed by the store ID.
+	sourceLimits sync.Map
+
+	// rollbacks records the partially applied operators to be rolled back,
+	// it is keyed by the region ID.
+	rollbacks sync.Map
 }
 
 // NewController creates a Controller.
@@ -356,13 +369,17 @@ func (oc *Controller) AddOperator(ops ...*Operator) bool {
 	// note: checkAddOperator uses false param for `isPromoting`.
 	// This is used to keep check logic before fixing issue #4946,
 	// but maybe user want to add operator when waiting queue is busy
+	var victims []*Operator
 	if oc.ExceedStoreLimit(ops...) {
-		for _, op := range ops {
-			operatorCounter.WithLabelValues(op.Desc(), "exceed-limit").Inc()
-			_ = op.Cancel(ExceedStoreLimit)
-			oc.buryOperator(op)
+		victims = oc.preemptVictims(ops)
+		if len(victims) == 0 {
+			for _, op := range ops {
+				operatorCounter.WithLabelValues(op.Desc(), "exceed-limit").Inc()
+				_ = op.Cancel(ExceedStoreLimit)
+				oc.buryOperator(op)
+			}
+			return false
 		}
-		return false
 	}
 	if pass, reason := oc.checkAddOperator(false, ops...); !pass {
 		for _, op := range ops {
@@ -371,6 +388,9 @@ func (oc *Controller) AddOperator(ops ...*Operator) bool {
 		}
 		return false
 	}
+	if !oc.preempt(ops, victims) {
+		return false
+	}
 	for _, op := range ops {
 		if !oc.addOperatorInner(op) {
 			return false
@@ -381,7 +401,7 @@ func (oc *Controller) AddOperator(ops ...*Operator) bool {
 
 // PromoteWaitingOperator promotes operators from waiting operators.
 func (oc *Controller) PromoteWaitingOperator() {
-	var ops []*Operator
+	var ops, victims []*Operator
 	for {
 		// GetOperator returns one operator or two merge operators
 		// need write lock
@@ -390,14 +410,18 @@ func (oc *Controller) PromoteWaitingOperator() {
 			return
 		}
 		operatorCounter.WithLabelValues(ops[0].Desc(), "get").Inc()
+		victims = nil
 		if oc.ExceedStoreLimit(ops...) {
-			for _, op := range ops {
-				operatorCounter.WithLabelValues(op.Desc(), "exceed-limit").Inc()
-				_ = op.Cancel(ExceedStoreLimit)
-				oc.buryOperator(op)
+			victims = oc.preemptVictims(ops)
+			if len(victims) == 0 {
+				for _, op := range ops {
+					operatorCounter.WithLabelValues(op.Desc(), "exceed-limit").Inc()
+					_ = op.Cancel(ExceedStoreLimit)
+					oc.buryOperator(op)
+				}
+				oc.wopStatus.decCount(ops[0].Desc())
+				continue
 			}
-			oc.wopStatus.decCount(ops[0].Desc())
-			continue
 		}
 
 		if pass, reason := oc.checkAddOperator(true, ops...); !pass {
@@ -410,6 +434,9 @@ func (oc *Controller) PromoteWaitingOperator() {
 			continue
 		}
 		oc.wopStatus.decCount(ops[0].Desc())
+		if !oc.preempt(ops, victims) {
+			continue
+		}
 		break
 	}
 
@@ -564,6 +591,7 @@ func (oc *Controller) addOperatorInner(op *Operator) bool {
 				continue
 			}
 			limit.Take(stepCost, v, op.GetPriorityLevel())
+			oc.takeSourceQuota(op, storeID, v, stepCost)
 		}
 	}
 
@@ -740,7 +768,11 @@ func (oc *Controller) buryOperator(op *Operator) {
 		operatorCounter.WithLabelValues(op.Desc(), "cancel").Inc()
 	}
 
+	if st == TIMEOUT || st == CANCELED {
+		oc.addRollbackOperator(op)
+	}
 	oc.records.Put(op)
+	oc.onBatchOperatorEnd(op)
 }
 
 // GetOperatorStatus gets the operator and its status with the specify id.
@@ -817,7 +849,9 @@ func (oc *Controller) GetRecords(from time.Time) []*OpRecord {
 		if op == nil || op.FinishTime.Before(from) {
 			continue
 		}
-		records = append(records, op.Record(op.FinishTime))
+		record := op.Record(op.FinishTime)
+		record.Seq = op.Seq
+		records = append(records, record)
 	}
 	return records
 }
@@ -933,6 +967,9 @@ type OpWithStatus struct {
 	*Operator
 	Status     pdpb.OperatorStatus
 	FinishTime time.Time
+	// Seq is the sequence number of the record, which tells apart the operators
+	// of the same region finished at the same time.
+	Seq uint64
 }
 
 // NewOpWithStatus creates an OpWithStatus from an operator.
@@ -952,6 +989,7 @@ func (o *OpWithStatus) MarshalJSON() ([]byte, error) {
 // records remains the operator and its status for a while.
 type records struct {
 	ttl *cache.TTLUint64
+	seq atomic.Uint64
 }
 
 const operatorStatusRemainTime = 10 * time.Minute
@@ -976,6 +1014,7 @@ func (o *records) Get(id uint64) *OpWithStatus {
 func (o *records) Put(op *Operator) {
 	id := op.RegionID()
 	record := NewOpWithStatus(op)
+	record.Seq = o.seq.Add(1)
 	o.ttl.Put(id, record)
 }
 
@@ -1004,6 +1043,10 @@ func (oc *Controller) ExceedStoreLimit(ops ...*Operator) bool {
 				OperatorExceededStoreLimitCounter.WithLabelValues(desc).Inc()
 				return true
 			}
+			if oc.exceedSourceQuota(ops[0], storeID, v, stepCost) {
+				operatorExceededQuotaCounter.WithLabelValues(ops[0].GetSource()).Inc()
+				return true
+			}
 		}
 	}
 	return false
@@ -1023,3 +1066,108 @@ func (oc *Controller) getOrCreateStoreLimit(storeID uint64, limitType storelimit
 	}
 	return s.GetStoreLimit()
 }
+
+// AddBatch adds a batch of operators and starts the steps without dependencies.
+// It returns the id of the batch.
+func (oc *Controller) AddBatch(b *Batch) uint64 {
+	oc.cleanFinishedBatches()
+	id := oc.batchID.Add(1)
+	if !b.start(id) {
+		return 0
+	}
+	oc.batches.Store(id, b)
+	batchCounter.WithLabelValues("create").Inc()
+	oc.advanceBatch(b)
+	return id
+}
+
+// GetBatch returns the batch with the given id.
+func (oc *Controller) GetBatch(id uint64) *Batch {
+	if v, ok := oc.batches.Load(id); ok {
+		return v.(*Batch)
+	}
+	return nil
+}
+
+// GetBatches returns all the batches which are running or finished recently.
+func (oc *Controller) GetBatches() []*Batch {
+	oc.cleanFinishedBatches()
+	var batches []*Batch
+	oc.batches.Range(func(_, value any) bool {
+		batches = append(batches, value.(*Batch))
+		return true
+	})
+	return batches
+}
+
+// CancelBatch cancels a batch and removes all its running operators.
+func (oc *Controller) CancelBatch(id uint64, reason CancelReasonType) bool {
+	b := oc.GetBatch(id)
+	if b == nil || b.IsEnd() {
+		return false
+	}
+	oc.cancelBatch(b, reason)
+	return true
+}
+
+func (oc *Controller) cancelBatch(b *Batch, reason CancelReasonType) {
+	running := b.cancel(string(reason))
+	log.Info("batch canceled", zap.Uint64("batch-id", b.ID()), zap.String("reason", string(reason)))
+	for _, op := range running {
+		_ = oc.RemoveOperator(op, BatchCanceled)
+	}
+}
+
+// advanceBatch builds and adds the operators of the steps whose dependencies
+// have been satisfied.
+func (oc *Controller) advanceBatch(b *Batch) {
+	for _, step := range b.popReadySteps() {
+		ops, err := step.Build()
+		if err != nil {
+			log.Info("failed to build batch step", zap.Uint64("batch-id", b.ID()), zap.String("step", step.Desc), errs.ZapError(err))
+			if b.failStep(step, err.Error()) {
+				oc.cancelBatch(b, BatchCanceled)
+				return
+			}
+			continue
+		}
+		if !b.setStepOperators(step, ops) {
+			return
+		}
+		if len(ops) > 0 {
+			// If the operators can not be added, they are buried and the
+			// batch is notified by onBatchOperatorEnd.
+			_ = oc.AddOperator(ops...)
+		}
+	}
+}
+
+func (oc *Controller) onBatchOperatorEnd(op *Operator) {
+	idStr := op.GetAdditionalInfo(batchIDKey)
+	if idStr == "" {
+		return
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return
+	}
+	b := oc.GetBatch(id)
+	if b == nil {
+		return
+	}
+	if b.operatorEnd(op) {
+		oc.cancelBatch(b, BatchCanceled)
+		return
+	}
+	oc.advanceBatch(b)
+}
+
+func (oc *Controller) cleanFinishedBatches() {
+	oc.batches.Range(func(key, value any) bool {
+		b := value.(*Batch)
+		if b.IsEnd() && time.Since(b.status.ReachTime()) > operatorStatusRemainTime {
+			oc.batches.Delete(key)
+		}
+		return true
+	})
+}
@@ -169,6 +169,11 @@ func (suite *operatorControllerTestSuite) TestOperatorStatus() {
 	ApplyOperator(tc, op2)
 	oc.Dispatch(region2, "test", nil)
 	re.Equal(pdpb.OperatorStatus_SUCCESS, oc.GetOperatorStatus(2).Status)
+	// The finished operators are numbered in order.
+	re.Less(oc.GetOperatorStatus(1).Seq, oc.GetOperatorStatus(2).Seq)
+	for _, record := range oc.GetRecords(time.Time{}) {
+		re.Equal(oc.GetOperatorStatus(record.RegionID()).Seq, record.Seq)
+	}
 }
 
 func (suite *operatorControllerTestSuite) TestFastFailOperator() {
@@ -541,6 +541,21 @@ func (suite *operatorTestSuite) TestRecord() {
 	re.Greater(ob.duration.Seconds(), time.Second.Seconds())
 }
 
+func (suite *operatorTestSuite) TestRelatedStores() {
+	re := suite.Require()
+	op := NewTestOperator(1, &metapb.RegionEpoch{}, OpRegion,
+		AddLearner{ToStore: 4, PeerID: 4},
+		ChangePeerV2Enter{
+			PromoteLearners: []PromoteLearner{{ToStore: 4, PeerID: 4}},
+			DemoteVoters:    []DemoteVoter{{ToStore: 1, PeerID: 1}},
+		},
+		TransferLeader{FromStore: 1, ToStore: 2},
+		RemovePeer{FromStore: 1, PeerID: 1})
+	re.Equal([]uint64{4, 1, 2}, op.RelatedStores())
+	op.Cancel(AdminStop)
+	re.Equal(string(AdminStop), op.GetCancelReason())
+}
+
 func (suite *operatorTestSuite) TestToJSONObject() {
 	re := suite.Require()
 	steps := []OpStep{
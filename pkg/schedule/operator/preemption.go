@@ -0,0 +1,182 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/core/storelimit"
+)
+
+// The policies of preempting the running operators when the store limit is exhausted.
+const (
+	// PreemptNone never preempts the running operators.
+	PreemptNone = "none"
+	// PreemptByReplica only allows the replica operators with high priority, like
+	// the replica repair operators created by the rule checker, to preempt the
+	// lower priority operators which are not replica operators, like the balance operators.
+	PreemptByReplica = "replica"
+	// PreemptByPriority allows any operator to preempt the lower priority operators.
+	PreemptByPriority = "priority"
+)
+
+type storeLimitKey struct {
+	storeID   uint64
+	limitType storelimit.Type
+}
+
+// canPreempt returns true if the running operator can be preempted by the new one under the policy.
+func canPreempt(policy string, op, running *Operator) bool {
+	if running.GetPriorityLevel() >= op.GetPriorityLevel() {
+		return false
+	}
+	// The admin, merge and rollback operators are never preempted.
+	if running.Kind()&(OpAdmin|OpMerge) != 0 || running.GetAdditionalInfo(RollbackReason) != "" {
+		return false
+	}
+	switch policy {
+	case PreemptByReplica:
+		return op.Kind()&OpReplica != 0 && op.GetPriorityLevel() >= constant.High &&
+			running.Kind()&OpReplica == 0
+	case PreemptByPriority:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRefundable returns true if the canceled operators give back the tokens they
+// took from the limit. Only the snapshot limit of the v2 store limit is acked when
+// an operator is removed, the v1 token buckets are refilled by time.
+func isRefundable(limit storelimit.StoreLimit, typ storelimit.Type) bool {
+	return limit.Version() == storelimit.VersionV2 && typ == storelimit.SendSnapshot
+}
+
+// preemptVictims returns the running operators which should be canceled to make
+// room for the operators on the stores whose limits are exhausted. It returns nil
+// if the policy does not allow it or the exhausted limits cannot be released by
+// canceling the lower priority operators.
+func (oc *Controller) preemptVictims(ops []*Operator) []*Operator {
+	policy := oc.config.GetOperatorPreemptionPolicy()
+	if len(ops) == 0 || policy == PreemptNone {
+		return nil
+	}
+	op := ops[0]
+	exhausted := make(map[storeLimitKey]int64)
+	opInfluence := NewTotalOpInfluence(ops, oc.cluster)
+	for storeID := range opInfluence.StoresInfluence {
+		for _, v := range storelimit.TypeNameValue {
+			stepCost := opInfluence.GetStoreInfluence(storeID).GetStepCost(v)
+			if stepCost == 0 {
+				continue
+			}
+			limiter := oc.getOrCreateStoreLimit(storeID, v)
+			if limiter == nil || limiter.Available(stepCost, v, op.GetPriorityLevel()) {
+				continue
+			}
+			// Canceling the running operators is useless if the limit is not refunded.
+			if !isRefundable(limiter, v) {
+				return nil
+			}
+			exhausted[storeLimitKey{storeID: storeID, limitType: v}] = stepCost
+		}
+	}
+	if len(exhausted) == 0 {
+		return nil
+	}
+
+	var candidates []*Operator
+	oc.operators.Range(func(_, value any) bool {
+		running := value.(*Operator)
+		if running.RegionID() != op.RegionID() && canPreempt(policy, op, running) {
+			candidates = append(candidates, running)
+		}
+		return true
+	})
+	// Prefer the lowest priority operators, and the latest started ones which have made less progress.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].GetPriorityLevel() != candidates[j].GetPriorityLevel() {
+			return candidates[i].GetPriorityLevel() < candidates[j].GetPriorityLevel()
+		}
+		return candidates[i].GetStartTime().After(candidates[j].GetStartTime())
+	})
+
+	var victims []*Operator
+	for _, running := range candidates {
+		if len(exhausted) == 0 {
+			break
+		}
+		influence := NewTotalOpInfluence([]*Operator{running}, oc.cluster)
+		released := false
+		for key, stepCost := range exhausted {
+			cost := influence.GetStoreInfluence(key.storeID).GetStepCost(key.limitType)
+			if cost == 0 {
+				continue
+			}
+			released = true
+			if cost >= stepCost {
+				delete(exhausted, key)
+			} else {
+				exhausted[key] = stepCost - cost
+			}
+		}
+		if released {
+			victims = append(victims, running)
+		}
+	}
+	if len(exhausted) > 0 {
+		return nil
+	}
+	return victims
+}
+
+// CanPreempt returns true if the operators can be added by preempting the running
+// lower priority operators even though the store limit is exhausted. It is always
+// false if the exhausted limit is not refunded by canceling the operators.
+func (oc *Controller) CanPreempt(ops ...*Operator) bool {
+	return len(oc.preemptVictims(ops)) > 0
+}
+
+// preempt cancels the running operators to make room for the operators. It returns
+// false and cancels the operators if the store limit is still exhausted after that.
+func (oc *Controller) preempt(ops, victims []*Operator) bool {
+	if len(victims) == 0 {
+		return true
+	}
+	preemptor := ops[0]
+	for _, victim := range victims {
+		if oc.RemoveOperator(victim, Preempted) {
+			log.Info("operator is preempted",
+				zap.Uint64("region-id", victim.RegionID()),
+				zap.Reflect("operator", victim),
+				zap.Reflect("preemptor", preemptor))
+			operatorPreemptedCounter.WithLabelValues(victim.Desc(), preemptor.Desc()).Inc()
+		}
+	}
+	if oc.ExceedStoreLimit(ops...) {
+		for _, op := range ops {
+			operatorCounter.WithLabelValues(op.Desc(), "exceed-limit").Inc()
+			_ = op.Cancel(ExceedStoreLimit)
+			oc.buryOperator(op)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+)
+
+func TestPreemptOperator(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(ctx, opts)
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc, false /* no need to run */)
+	oc := NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderStore(3, 0)
+	tc.SetStoreLimit(2, storelimit.AddPeer, 600)
+	for i := uint64(1); i <= 20; i++ {
+		tc.AddLeaderRegion(i, 1)
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(50)))
+	}
+	setPolicy := func(policy string) {
+		scheduleCfg := opts.GetScheduleConfig().Clone()
+		scheduleCfg.OperatorPreemptionPolicy = policy
+		opts.SetScheduleConfig(scheduleCfg)
+	}
+	// The operator sends the snapshot from store 1 to store 2.
+	newOperator := func(regionID uint64, kind OpKind, level constant.PriorityLevel) *Operator {
+		op := NewTestOperator(regionID, &metapb.RegionEpoch{}, kind, AddLearner{ToStore: 2, PeerID: regionID + 100, SendStore: 1})
+		op.SetPriorityLevel(level)
+		return op
+	}
+
+	// Exhaust the snapshot windows of store 1, the windows of the low, medium and
+	// high priority are 100, 50 and 25.
+	limit := storelimit.NewSlidingWindows()
+	tc.PutStore(tc.GetStore(1).Clone(core.SetStoreLimit(limit)))
+	runningOps := map[uint64]*Operator{
+		1: newOperator(1, OpRegion, constant.Low),
+		2: newOperator(2, OpRegion, constant.Medium),
+		3: newOperator(3, OpRegion, constant.Medium),
+		4: newOperator(4, OpReplica, constant.High),
+	}
+	for i := uint64(1); i <= 4; i++ {
+		re.True(oc.AddOperator(runningOps[i]))
+	}
+	re.True(oc.ExceedStoreLimit(newOperator(6, OpReplica, constant.High)))
+
+	// The default policy never preempts the running operators.
+	re.Equal(PreemptNone, opts.GetOperatorPreemptionPolicy())
+	re.False(oc.CanPreempt(newOperator(6, OpReplica, constant.High)))
+	re.False(oc.AddOperator(newOperator(6, OpReplica, constant.High)))
+	re.Len(oc.GetOperators(), 4)
+
+	// Only the replica operators with high priority can preempt others.
+	setPolicy(PreemptByReplica)
+	re.False(oc.AddOperator(newOperator(6, OpRegion, constant.High)))
+	re.False(oc.AddOperator(newOperator(6, OpReplica, constant.Medium)))
+	op := newOperator(6, OpReplica, constant.High)
+	re.True(oc.CanPreempt(op))
+	re.True(oc.AddOperator(op))
+	// The lowest priority one is preempted first, and its snapshot size is refunded.
+	re.Nil(oc.GetOperator(1))
+	re.Equal(CANCELED, runningOps[1].Status())
+	re.Equal(string(Preempted), runningOps[1].GetCancelReason())
+	re.Equal(op, oc.GetOperator(6))
+	re.Len(oc.GetOperators(), 4)
+	re.Equal([]int64{100, 50, 50, 0}, limit.GetUsed())
+
+	// The waiting operators can preempt others when they are promoted, the latest
+	// started one is preempted first if the priorities are the same.
+	op = newOperator(7, OpReplica, constant.High)
+	re.Equal(1, oc.AddWaitingOperator(op))
+	re.Equal(op, oc.GetOperator(7))
+	re.Equal(CANCELED, runningOps[3].Status())
+	re.Len(oc.GetOperators(), 4)
+
+	// Any operator can preempt the lower priority operators.
+	setPolicy(PreemptByPriority)
+	re.False(oc.AddOperator(newOperator(8, OpRegion, constant.Medium)))
+	op = newOperator(8, OpRegion, constant.High)
+	re.True(oc.AddOperator(op))
+	re.Equal(op, oc.GetOperator(8))
+	re.Equal(CANCELED, runningOps[2].Status())
+	re.Len(oc.GetOperators(), 4)
+	// The operators with the same priority are not preempted.
+	re.False(oc.CanPreempt(newOperator(9, OpRegion, constant.High)))
+	re.False(oc.AddOperator(newOperator(9, OpRegion, constant.High)))
+	re.Len(oc.GetOperators(), 4)
+
+	// The v1 store limit is not refunded by canceling the operators, so they are
+	// never preempted.
+	v1Op := func(regionID uint64, level constant.PriorityLevel) *Operator {
+		op := NewTestOperator(regionID, &metapb.RegionEpoch{}, OpRegion, AddLearner{ToStore: 3, PeerID: regionID + 100})
+		op.SetPriorityLevel(level)
+		return op
+	}
+	var lowOps []*Operator
+	for i := uint64(11); i <= 20 && !oc.ExceedStoreLimit(v1Op(i, constant.High)); i++ {
+		lowOps = append(lowOps, v1Op(i, constant.Low))
+		re.True(oc.AddOperator(lowOps[len(lowOps)-1]))
+	}
+	re.NotEmpty(lowOps)
+	op = v1Op(10, constant.High)
+	re.True(oc.ExceedStoreLimit(op))
+	re.False(oc.CanPreempt(op))
+	re.False(oc.AddOperator(op))
+	for _, lowOp := range lowOps {
+		re.Equal(lowOp, oc.GetOperator(lowOp.RegionID()))
+	}
+
+	// The admin, merge and rollback operators are never preempted.
+	op = newOperator(10, OpReplica, constant.High)
+	re.True(canPreempt(PreemptByPriority, op, newOperator(1, OpRegion, constant.Low)))
+	re.False(canPreempt(PreemptByPriority, op, newOperator(1, OpAdmin, constant.Low)))
+	re.False(canPreempt(PreemptByPriority, op, newOperator(1, OpMerge, constant.Low)))
+	rollback := newOperator(1, OpReplica, constant.Low)
+	rollback.SetAdditionalInfo(RollbackReason, string(Timeout))
+	re.False(canPreempt(PreemptByPriority, op, rollback))
+	re.False(canPreempt(PreemptNone, op, newOperator(1, OpRegion, constant.Low)))
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"strconv"
+
+	"github.com/tikv/pd/pkg/core/storelimit"
+)
+
+// sourceQuotaRate returns the rate of the store limit quota of the operator source.
+// It returns false if the source has no quota.
+func (oc *Controller) sourceQuotaRate(op *Operator, storeID uint64, limitType storelimit.Type) (float64, bool) {
+	source := op.GetSource()
+	if source == "" {
+		return 0, false
+	}
+	quota := oc.config.GetSchedulerStoreLimitQuota(source)
+	if quota <= 0 {
+		return 0, false
+	}
+	return oc.config.GetStoreLimitByType(storeID, limitType) / StoreBalanceBaseTime * quota, true
+}
+
+func (oc *Controller) getOrCreateSourceLimit(storeID uint64) *storelimit.SourceRateLimit {
+	limit, _ := oc.sourceLimits.LoadOrStore(storeID, storelimit.NewSourceRateLimit())
+	return limit.(*storelimit.SourceRateLimit)
+}
+
+// exceedSourceQuota returns true if the operator source exceeds its store limit quota after adding the operator.
+func (oc *Controller) exceedSourceQuota(op *Operator, storeID uint64, limitType storelimit.Type, stepCost int64) bool {
+	rate, ok := oc.sourceQuotaRate(op, storeID, limitType)
+	if !ok {
+		return false
+	}
+	return !oc.getOrCreateSourceLimit(storeID).Available(op.GetSource(), rate, stepCost, limitType)
+}
+
+// takeSourceQuota takes the cost of the operator from the store limit quota of its source.
+func (oc *Controller) takeSourceQuota(op *Operator, storeID uint64, limitType storelimit.Type, stepCost int64) {
+	rate, ok := oc.sourceQuotaRate(op, storeID, limitType)
+	if !ok {
+		return
+	}
+	if oc.getOrCreateSourceLimit(storeID).Take(op.GetSource(), rate, stepCost, limitType) {
+		storeLimitQuotaTokensCounter.WithLabelValues(op.GetSource(), strconv.FormatUint(storeID, 10), limitType.String()).Add(float64(stepCost))
+	}
+}
+
+// GetSourceQuotaConsumed returns the store limit tokens taken by the scheduler on the store.
+func (oc *Controller) GetSourceQuotaConsumed(storeID uint64, source string, limitType storelimit.Type) int64 {
+	limit, ok := oc.sourceLimits.Load(storeID)
+	if !ok {
+		return 0
+	}
+	return limit.(*storelimit.SourceRateLimit).Consumed(source, limitType)
+}
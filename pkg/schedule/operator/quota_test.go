@@ -0,0 +1,78 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+	"github.com/tikv/pd/pkg/schedule/hbstream"
+)
+
+func TestSchedulerStoreLimitQuota(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(ctx, opts)
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc, false /* no need to run */)
+	oc := NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), stream)
+	tc.AddLeaderStore(1, 0)
+	tc.AddLeaderStore(2, 0)
+	for i := uint64(1); i <= 30; i++ {
+		tc.AddLeaderRegion(i, 1)
+		// make it small region
+		tc.PutRegion(tc.GetRegion(i).Clone(core.SetApproximateSize(10)))
+	}
+	newOperator := func(regionID uint64, source string) *Operator {
+		op := NewTestOperator(regionID, &metapb.RegionEpoch{}, OpRegion, AddPeer{ToStore: 2, PeerID: regionID + 100})
+		op.SetSource(source)
+		return op
+	}
+
+	// The store limit allows 50 small region operators, and the scheduler
+	// can only use 30% of them.
+	tc.SetStoreLimit(2, storelimit.AddPeer, 600)
+	scheduleCfg := opts.GetScheduleConfig().Clone()
+	scheduleCfg.SchedulerStoreLimitQuota = map[string]float64{"balance-hot-region-scheduler": 0.3}
+	opts.SetScheduleConfig(scheduleCfg)
+	for i := uint64(1); i <= 15; i++ {
+		re.True(oc.AddOperator(newOperator(i, "balance-hot-region-scheduler")))
+	}
+	re.True(oc.ExceedStoreLimit(newOperator(16, "balance-hot-region-scheduler")))
+	re.False(oc.AddOperator(newOperator(16, "balance-hot-region-scheduler")))
+	re.Equal(int64(15*storelimit.SmallRegionInfluence[storelimit.AddPeer]),
+		oc.GetSourceQuotaConsumed(2, "balance-hot-region-scheduler", storelimit.AddPeer))
+	re.Zero(oc.GetSourceQuotaConsumed(1, "balance-hot-region-scheduler", storelimit.AddPeer))
+
+	// The other schedulers and checkers are not limited by the quota.
+	re.True(oc.AddOperator(newOperator(16, "balance-region-scheduler")))
+	re.True(oc.AddOperator(newOperator(17, "")))
+	re.Zero(oc.GetSourceQuotaConsumed(2, "balance-region-scheduler", storelimit.AddPeer))
+
+	// The quota is removed.
+	scheduleCfg = opts.GetScheduleConfig().Clone()
+	scheduleCfg.SchedulerStoreLimitQuota = nil
+	opts.SetScheduleConfig(scheduleCfg)
+	re.True(oc.AddOperator(newOperator(18, "balance-hot-region-scheduler")))
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// The policies of rolling back the partially applied operators.
+const (
+	// RollbackNone never rolls back the operators.
+	RollbackNone = "none"
+	// RollbackOnTimeout only rolls back the operators which are timeout.
+	RollbackOnTimeout = "timeout"
+	// RollbackOnCancel rolls back the operators which are timeout or canceled
+	// while running, such as being stopped by the admin.
+	RollbackOnCancel = "cancel"
+)
+
+// RollbackReason is the additional info key of the rollback operator, which
+// records the cancel reason of the reverted operator.
+const RollbackReason = "rollback-reason"
+
+// rollbackReasons are the cancel reasons which trigger the rollback for each policy.
+// The reasons which mean the region itself is changed, like EpochNotMatch, are
+// not included since the checkers will handle the new region.
+var rollbackReasons = map[string][]CancelReasonType{
+	RollbackNone:      nil,
+	RollbackOnTimeout: {Timeout},
+	RollbackOnCancel:  {Timeout, AdminStop, NotInRunningState, StaleStatus, BatchCanceled, Preempted},
+}
+
+// ShouldRollback returns true if the operator canceled with the reason should
+// be rolled back under the policy.
+func ShouldRollback(policy string, reason CancelReasonType) bool {
+	return slices.Contains(rollbackReasons[policy], reason)
+}
+
+// RollbackStores returns the stores of the peers which are added by the
+// finished steps of the operator and should be removed to revert it. It
+// returns nil if nothing needs to be reverted, or the operator cannot be
+// reverted safely, e.g. some original peers are already removed or the
+// region is left in the joint state which is handled by the joint state checker.
+func (o *Operator) RollbackStores() []uint64 {
+	var added []uint64
+	finished := int(atomic.LoadInt32(&o.currentStep))
+	for i := 0; i < finished && i < len(o.steps); i++ {
+		switch step := o.steps[i].(type) {
+		case AddLearner:
+			added = append(added, step.ToStore)
+		case AddPeer:
+			added = append(added, step.ToStore)
+		case RemovePeer:
+			idx := slices.Index(added, step.FromStore)
+			if idx < 0 {
+				// An original peer is removed, the operator is almost finished
+				// and rolling it back will lose a replica.
+				return nil
+			}
+			added = slices.Delete(added, idx, idx+1)
+		case ChangePeerV2Enter:
+			// The region is still in the joint state, or some original voters
+			// are demoted.
+			if i == finished-1 || len(step.DemoteVoters) > 0 {
+				return nil
+			}
+		case MergeRegion, SplitRegion:
+			return nil
+		}
+	}
+	return added
+}
+
+// addRollbackOperator records the operator to be rolled back if it is ended
+// with a reason allowed by the rollback policy and has partially applied steps.
+func (oc *Controller) addRollbackOperator(op *Operator) {
+	if op.GetAdditionalInfo(RollbackReason) != "" {
+		// Never roll back a rollback operator.
+		return
+	}
+	reason := CancelReasonType(op.GetCancelReason())
+	if !ShouldRollback(oc.config.GetOperatorRollbackPolicy(), reason) {
+		return
+	}
+	if len(op.RollbackStores()) == 0 {
+		return
+	}
+	oc.rollbacks.Store(op.RegionID(), op)
+	operatorRollbackCounter.WithLabelValues(op.Desc(), string(reason), "pending").Inc()
+}
+
+// TakeRollbackOperators returns the operators which need to be rolled back and
+// removes them from the pending list.
+func (oc *Controller) TakeRollbackOperators() []*Operator {
+	var ops []*Operator
+	oc.rollbacks.Range(func(regionID, value any) bool {
+		oc.rollbacks.Delete(regionID)
+		ops = append(ops, value.(*Operator))
+		return true
+	})
+	return ops
+}
+
+// RecordRollback records the result of building the rollback operator.
+func RecordRollback(op *Operator, event string) {
+	operatorRollbackCounter.WithLabelValues(op.Desc(), op.GetCancelReason(), event).Inc()
+}
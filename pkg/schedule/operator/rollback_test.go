@@ -0,0 +1,145 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/mock/mockconfig"
+)
+
+func TestRollbackStores(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(ctx, mockconfig.NewTestOptions())
+	for id := uint64(1); id <= 3; id++ {
+		tc.AddRegionStore(id, 1)
+	}
+	region := tc.AddLeaderRegion(1, 1, 2)
+	withLearner := region.Clone(core.WithAddPeer(&metapb.Peer{Id: 10, StoreId: 3, Role: metapb.PeerRole_Learner}))
+	withoutPeer1 := region.Clone(
+		core.WithAddPeer(&metapb.Peer{Id: 10, StoreId: 3}),
+		core.WithLeader(region.GetStorePeer(2)),
+		core.WithRemoveStorePeer(1))
+
+	op := NewTestOperator(1, region.GetRegionEpoch(), OpRegion,
+		AddLearner{ToStore: 3, PeerID: 10},
+		PromoteLearner{ToStore: 3, PeerID: 10},
+		TransferLeader{FromStore: 1, ToStore: 2},
+		RemovePeer{FromStore: 1, PeerID: 1})
+	re.True(op.Start())
+	re.Empty(op.RollbackStores())
+	op.Check(withLearner)
+	re.Equal([]uint64{3}, op.RollbackStores())
+	// The original peer is removed, it cannot be rolled back.
+	op.Check(withoutPeer1)
+	re.Empty(op.RollbackStores())
+
+	// The original stores are recorded when the operator is built.
+	op, err := CreateAddPeerOperator("add-peer", tc, region, &metapb.Peer{StoreId: 3}, OpRegion)
+	re.NoError(err)
+	re.ElementsMatch([]uint64{1, 2}, op.originStores)
+}
+
+func TestRollbackOperator(t *testing.T) {
+	re := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opts := mockconfig.NewTestOptions()
+	tc := mockcluster.NewCluster(ctx, opts)
+	oc := NewController(ctx, tc.GetBasicCluster(), tc.GetSharedConfig(), nil)
+	for id := uint64(1); id <= 3; id++ {
+		tc.AddRegionStore(id, 1)
+	}
+	region := tc.AddLeaderRegion(1, 1, 2)
+	region = region.Clone(core.WithAddPeer(&metapb.Peer{Id: 10, StoreId: 3, Role: metapb.PeerRole_Learner}))
+	tc.PutRegion(region)
+
+	removeOperator := func(reason CancelReasonType) *Operator {
+		op := NewTestOperator(1, region.GetRegionEpoch(), OpRegion,
+			AddLearner{ToStore: 3, PeerID: 10},
+			RemovePeer{FromStore: 1, PeerID: 1})
+		op.originStores = []uint64{1, 2}
+		oc.SetOperator(op)
+		re.True(op.Start())
+		op.Check(region)
+		re.True(oc.RemoveOperator(op, reason))
+		return op
+	}
+
+	// The default policy never rolls back the operators.
+	re.Equal(RollbackNone, opts.GetOperatorRollbackPolicy())
+	removeOperator(Timeout)
+	re.Empty(oc.TakeRollbackOperators())
+
+	// Only the timeout operators are rolled back.
+	scheduleCfg := opts.GetScheduleConfig().Clone()
+	scheduleCfg.OperatorRollbackPolicy = RollbackOnTimeout
+	opts.SetScheduleConfig(scheduleCfg)
+	removeOperator(AdminStop)
+	re.Empty(oc.TakeRollbackOperators())
+	origin := removeOperator(Timeout)
+	re.Equal([]*Operator{origin}, oc.TakeRollbackOperators())
+	re.Empty(oc.TakeRollbackOperators())
+
+	scheduleCfg = opts.GetScheduleConfig().Clone()
+	scheduleCfg.OperatorRollbackPolicy = RollbackOnCancel
+	opts.SetScheduleConfig(scheduleCfg)
+	origin = removeOperator(AdminStop)
+	re.Equal([]*Operator{origin}, oc.TakeRollbackOperators())
+	// The region is changed by others.
+	removeOperator(EpochNotMatch)
+	re.Empty(oc.TakeRollbackOperators())
+
+	op, err := CreateRollbackOperator(tc, region, origin)
+	re.NoError(err)
+	re.Equal(1, op.Len())
+	re.Equal(uint64(3), op.Step(0).(RemovePeer).FromStore)
+	re.Equal(string(AdminStop), op.GetAdditionalInfo(RollbackReason))
+	// The rollback operator will not be rolled back again.
+	oc.SetOperator(op)
+	re.True(op.Start())
+	re.True(oc.RemoveOperator(op, AdminStop))
+	re.Empty(oc.TakeRollbackOperators())
+
+	// The added peer is already removed.
+	_, err = CreateRollbackOperator(tc, region.Clone(core.WithRemoveStorePeer(3)), origin)
+	re.Error(err)
+	// The original peer is removed by others.
+	_, err = CreateRollbackOperator(tc, region.Clone(core.WithRemoveStorePeer(2)), origin)
+	re.Error(err)
+	// Another peer is added by others.
+	tc.AddRegionStore(4, 1)
+	_, err = CreateRollbackOperator(tc, region.Clone(core.WithAddPeer(&metapb.Peer{Id: 11, StoreId: 4})), origin)
+	re.Error(err)
+	// The original stores are unknown.
+	origin.originStores = nil
+	_, err = CreateRollbackOperator(tc, region, origin)
+	re.Error(err)
+
+	scheduleCfg = opts.GetScheduleConfig().Clone()
+	scheduleCfg.OperatorRollbackPolicy = RollbackNone
+	opts.SetScheduleConfig(scheduleCfg)
+	removeOperator(Timeout)
+	re.Empty(oc.TakeRollbackOperators())
+}
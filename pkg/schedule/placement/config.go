@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"time"
+
+	"github.com/tikv/pd/pkg/utils/keyutil"
 )
 
 // ruleConfig contains rule and rule group configurations.
@@ -140,6 +142,39 @@ func (p *RuleConfigPatch) adjust() {
 	p.iterateRules(func(r *Rule) { r.group = p.getGroup(r.GroupID) })
 }
 
+// changedKeyRanges returns the key ranges affected by the patch. It contains the
+// ranges of both the old and the new versions of the changed rules, and the
+// ranges of all rules in the changed groups. It should be called after trim.
+func (p *RuleConfigPatch) changedKeyRanges() *keyutil.KeyRanges {
+	ranges := keyutil.NewKeyRangesWithSize(len(p.mut.rules))
+	added := make(map[[2]string]struct{})
+	appendRange := func(r *Rule) {
+		key := [2]string{string(r.StartKey), string(r.EndKey)}
+		if _, ok := added[key]; !ok {
+			added[key] = struct{}{}
+			ranges.Append(r.StartKey, r.EndKey)
+		}
+	}
+	for key, rule := range p.mut.rules {
+		if old := p.c.getRule(key); old != nil {
+			appendRange(old)
+		}
+		if rule != nil {
+			appendRange(rule)
+		}
+	}
+	for id := range p.mut.groups {
+		inGroup := func(r *Rule) {
+			if r.GroupID == id {
+				appendRange(r)
+			}
+		}
+		p.c.iterateRules(inGroup)
+		p.iterateRules(inGroup)
+	}
+	return ranges
+}
+
 // trim unnecessary updates. For example, remove a rule then insert the same rule.
 func (p *RuleConfigPatch) trim() {
 	for key, rule := range p.mut.rules {
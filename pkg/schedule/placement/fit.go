@@ -137,7 +137,10 @@ type RuleFit struct {
 	// IsolationScore indicates at which level of labeling these Peers are
 	// isolated. A larger value is better.
 	IsolationScore float64 `json:"isolation-score"`
-	WitnessScore   int     `json:"witness-score"`
+	// PreferenceScore is the sum of the weights of the soft label constraints
+	// matched by the stores of the Peers. A larger value is better.
+	PreferenceScore int `json:"preference-score"`
+	WitnessScore    int `json:"witness-score"`
 	// stores is the stores that the peers are placed in.
 	stores []*core.StoreInfo
 }
@@ -170,6 +173,10 @@ func compareRuleFit(a, b *RuleFit) int {
 		return -1
 	case a.IsolationScore > b.IsolationScore:
 		return 1
+	case a.PreferenceScore < b.PreferenceScore:
+		return -1
+	case a.PreferenceScore > b.PreferenceScore:
+		return 1
 	case a.WitnessScore > b.WitnessScore:
 		return -1
 	case a.WitnessScore < b.WitnessScore:
@@ -372,6 +379,7 @@ func newRuleFit(rule *Rule, peers []*fitPeer, supportWitness bool) *RuleFit {
 	for _, p := range peers {
 		rf.Peers = append(rf.Peers, p.Peer)
 		rf.stores = append(rf.stores, p.store)
+		rf.PreferenceScore += LabelPreferenceScore(p.store, rule.LabelConstraints)
 		if !p.matchRoleStrict(rule.Role) ||
 			(supportWitness && (p.IsWitness != rule.IsWitness)) ||
 			(!supportWitness && p.IsWitness) {
@@ -229,6 +229,31 @@ func TestFitRegion(t *testing.T) {
 	}
 }
 
+func TestFitRegionWithPreference(t *testing.T) {
+	re := require.New(t)
+	stores := makeStores()
+	region, err := makeRegion("1112,1113,1114,2111")
+	re.NoError(err)
+	rule, err := makeRule("3/voter//")
+	re.NoError(err)
+	rf := fitRegion(stores.GetStores(), region, []*Rule{rule}, false)
+	re.True(checkPeerMatch(rf.OrphanPeers, "2111"))
+
+	// Prefer the ssd stores.
+	rule.LabelConstraints = []LabelConstraint{{Key: "disk", Op: In, Values: []string{"ssd"}, Weight: 1}}
+	rf = fitRegion(stores.GetStores(), region, []*Rule{rule}, false)
+	re.True(checkPeerMatch(rf.RuleFits[0].Peers, "1112,1113,2111"))
+	re.Equal(1, rf.RuleFits[0].PreferenceScore)
+	re.True(checkPeerMatch(rf.OrphanPeers, "1114"))
+
+	// The soft constraint never makes the rule unsatisfied.
+	region, err = makeRegion("1112,1113,1114")
+	re.NoError(err)
+	rf = fitRegion(stores.GetStores(), region, []*Rule{rule}, false)
+	re.True(rf.IsSatisfied())
+	re.Zero(rf.RuleFits[0].PreferenceScore)
+}
+
 func TestIsolationScore(t *testing.T) {
 	as := assert.New(t)
 	stores := makeStores()
@@ -43,10 +43,19 @@ func validateOp(op LabelConstraintOp) bool {
 }
 
 // LabelConstraint is used to filter store when trying to place peer of a region.
+// A constraint with a positive weight is a soft one, it never filters stores
+// but makes the stores matching it preferred. The greater the weight is, the
+// more preferred the stores are, see PreferredScore.
 type LabelConstraint struct {
 	Key    string            `json:"key,omitempty"`
 	Op     LabelConstraintOp `json:"op,omitempty"`
 	Values []string          `json:"values,omitempty"`
+	Weight int               `json:"weight,omitempty"`
+}
+
+// IsSoft returns true if the constraint is a soft one.
+func (c *LabelConstraint) IsSoft() bool {
+	return c.Weight > 0
 }
 
 // MatchStore checks if a store matches the constraint.
@@ -79,6 +88,7 @@ func isExclusiveLabel(key string) bool {
 }
 
 // MatchLabelConstraints checks if a store matches label constraints list.
+// The soft constraints are ignored.
 func MatchLabelConstraints(store *core.StoreInfo, constraints []LabelConstraint) bool {
 	if store == nil {
 		return false
@@ -86,10 +96,37 @@ func MatchLabelConstraints(store *core.StoreInfo, constraints []LabelConstraint)
 
 	for _, l := range store.GetLabels() {
 		if isExclusiveLabel(l.GetKey()) &&
-			slice.NoneOf(constraints, func(i int) bool { return constraints[i].Key == l.GetKey() }) {
+			slice.NoneOf(constraints, func(i int) bool { return !constraints[i].IsSoft() && constraints[i].Key == l.GetKey() }) {
 			return false
 		}
 	}
 
-	return slice.AllOf(constraints, func(i int) bool { return constraints[i].MatchStore(store) })
+	return slice.AllOf(constraints, func(i int) bool { return constraints[i].IsSoft() || constraints[i].MatchStore(store) })
+}
+
+// labelPreferenceWeightRatio is how much a weight point of the matched soft
+// constraints lowers the score of a store, e.g. the score of a store matching
+// the constraints with a total weight of 10 is halved.
+const labelPreferenceWeightRatio = 0.1
+
+// LabelPreferenceScore returns the sum of the weights of the soft constraints
+// matched by the store.
+func LabelPreferenceScore(store *core.StoreInfo, constraints []LabelConstraint) int {
+	if store == nil {
+		return 0
+	}
+	var score int
+	for i := range constraints {
+		if constraints[i].IsSoft() && constraints[i].MatchStore(store) {
+			score += constraints[i].Weight
+		}
+	}
+	return score
+}
+
+// PreferredScore returns the score of the store discounted by the weights of the
+// soft constraints it matches, the store with the lower score is preferred. So the
+// preferred stores are chosen unless they are much more loaded than the others.
+func PreferredScore(score float64, store *core.StoreInfo, constraints []LabelConstraint) float64 {
+	return score / (1 + labelPreferenceWeightRatio*float64(LabelPreferenceScore(store, constraints)))
 }
@@ -79,6 +79,8 @@ func TestLabelConstraints(t *testing.T) {
 		{{Key: "k1", Op: "notExists"}, {Key: "k2", Op: "exists"}},
 		{{Key: "engine", Op: "exists"}, {Key: "k1", Op: "in", Values: []string{"v1", "v2"}}, {Key: "k2", Op: "notIn", Values: []string{"v3"}}},
 		{{Key: "$foo", Op: "in", Values: []string{"bar", "baz"}}},
+		{{Key: "k1", Op: "in", Values: []string{"v1"}, Weight: 1}, {Key: "engine", Op: "exists", Weight: 1}},
+		{{Key: "k2", Op: "exists"}, {Key: "k1", Op: "in", Values: []string{"v1"}, Weight: 1}},
 	}
 	expect := [][]int{
 		{1, 2, 3, 4, 5, 8},
@@ -86,6 +88,8 @@ func TestLabelConstraints(t *testing.T) {
 		{4, 5},
 		{10},
 		{11},
+		{1, 2, 3, 4, 5, 8},
+		{4, 5, 8},
 	}
 	for i, cs := range constraints {
 		var matched []int
@@ -97,3 +101,21 @@ func TestLabelConstraints(t *testing.T) {
 		re.Equal(expect[i], matched)
 	}
 }
+
+func TestLabelPreferenceScore(t *testing.T) {
+	re := require.New(t)
+	constraints := []LabelConstraint{
+		{Key: "zone", Op: "in", Values: []string{"z1"}},
+		{Key: "disk", Op: "in", Values: []string{"nvme"}, Weight: 2},
+		{Key: "host", Op: "notIn", Values: []string{"h1"}, Weight: 1},
+	}
+	re.Equal(3, LabelPreferenceScore(core.NewStoreInfoWithLabel(1, map[string]string{"zone": "z1", "disk": "nvme", "host": "h2"}), constraints))
+	re.Equal(2, LabelPreferenceScore(core.NewStoreInfoWithLabel(2, map[string]string{"zone": "z1", "disk": "nvme", "host": "h1"}), constraints))
+	re.Equal(1, LabelPreferenceScore(core.NewStoreInfoWithLabel(3, map[string]string{"zone": "z2"}), constraints))
+	re.Zero(LabelPreferenceScore(nil, constraints))
+
+	// A total weight of 10 halves the score.
+	constraints = []LabelConstraint{{Key: "disk", Op: "in", Values: []string{"nvme"}, Weight: 10}}
+	re.Equal(50.0, PreferredScore(100, core.NewStoreInfoWithLabel(1, map[string]string{"disk": "nvme"}), constraints))
+	re.Equal(100.0, PreferredScore(100, core.NewStoreInfoWithLabel(2, map[string]string{"disk": "ssd"}), constraints))
+}
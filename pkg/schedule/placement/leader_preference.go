@@ -0,0 +1,88 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"fmt"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+)
+
+// LeaderPreference is a set of label constraints which the leader prefers to
+// be placed on. A store matches the preference if it matches all the constraints.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type LeaderPreference struct {
+	LabelConstraints []LabelConstraint `json:"label_constraints"`
+}
+
+// GetLeaderPreferences returns the ordered leader preferences which take effect
+// on the rules. The preferences of the first voter or leader rule which has
+// them, or otherwise of its group, are used.
+func GetLeaderPreferences(rules []*Rule) []LeaderPreference {
+	for _, r := range rules {
+		if r.Role != Voter && r.Role != Leader {
+			continue
+		}
+		if len(r.LeaderPreferences) > 0 {
+			return r.LeaderPreferences
+		}
+		if r.group != nil && len(r.group.LeaderPreferences) > 0 {
+			return r.group.LeaderPreferences
+		}
+	}
+	return nil
+}
+
+// LeaderPreferenceRank returns the index of the first preference matched by
+// the store, a smaller rank is more preferred. It returns len(preferences) if
+// the store matches none of them.
+func LeaderPreferenceRank(store *core.StoreInfo, preferences []LeaderPreference) int {
+	for i := range preferences {
+		if MatchLabelConstraints(store, preferences[i].LabelConstraints) {
+			return i
+		}
+	}
+	return len(preferences)
+}
+
+func validateLeaderPreferences(preferences []LeaderPreference) error {
+	for _, p := range preferences {
+		if len(p.LabelConstraints) == 0 {
+			return errs.ErrRuleContent.FastGenByArgs("leader preference should have label constraints")
+		}
+		for _, c := range p.LabelConstraints {
+			if !validateOp(c.Op) {
+				return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid op %s", c.Op))
+			}
+			if c.IsSoft() {
+				return errs.ErrRuleContent.FastGenByArgs("leader preference can't have soft constraints")
+			}
+		}
+	}
+	return nil
+}
+
+func cloneLeaderPreferences(preferences []LeaderPreference) []LeaderPreference {
+	if preferences == nil {
+		return nil
+	}
+	res := make([]LeaderPreference, 0, len(preferences))
+	for _, p := range preferences {
+		res = append(res, LeaderPreference{LabelConstraints: append([]LabelConstraint(nil), p.LabelConstraints...)})
+	}
+	return res
+}
@@ -15,6 +15,8 @@
 package placement
 
 import (
+	"sync/atomic"
+
 	"github.com/pingcap/kvproto/pkg/metapb"
 
 	"github.com/tikv/pd/pkg/core"
@@ -43,6 +45,31 @@ type RegionRuleFitCacheManager struct {
 	mu           syncutil.RWMutex
 	regionCaches map[uint64]*regionRuleFitCache
 	storeCaches  map[uint64]*storeCache
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+	regionChanged atomic.Uint64
+	rulesChanged  atomic.Uint64
+	storesChanged atomic.Uint64
+}
+
+// RuleFitCacheStats is the statistics of the RegionRuleFitCacheManager.
+type RuleFitCacheStats struct {
+	// CachedRegions is the number of the regions in the cache.
+	CachedRegions int `json:"cached_regions"`
+	// Hits is the number of the checks which get the cached fit.
+	Hits uint64 `json:"hits"`
+	// Misses is the number of the checks which don't get the cached fit.
+	Misses uint64 `json:"misses"`
+	// Invalidations is the number of the caches invalidated by the rule checker.
+	Invalidations uint64 `json:"invalidations"`
+	// RegionChanged is the number of the misses caused by the changes of the region.
+	RegionChanged uint64 `json:"region_changed"`
+	// RulesChanged is the number of the misses caused by the changes of the rules.
+	RulesChanged uint64 `json:"rules_changed"`
+	// StoresChanged is the number of the misses caused by the changes of the stores.
+	StoresChanged uint64 `json:"stores_changed"`
 }
 
 // NewRegionRuleFitCacheManager returns RegionRuleFitCacheManager
@@ -57,7 +84,10 @@ func NewRegionRuleFitCacheManager() *RegionRuleFitCacheManager {
 func (manager *RegionRuleFitCacheManager) Invalid(regionID uint64) {
 	manager.mu.Lock()
 	defer manager.mu.Unlock()
-	delete(manager.regionCaches, regionID)
+	if _, ok := manager.regionCaches[regionID]; ok {
+		manager.invalidations.Add(1)
+		delete(manager.regionCaches, regionID)
+	}
 }
 
 // CheckAndGetCache checks whether the region and rules are changed for the stored cache
@@ -66,18 +96,44 @@ func (manager *RegionRuleFitCacheManager) CheckAndGetCache(region *core.RegionIn
 	rules []*Rule,
 	stores []*core.StoreInfo) (bool, *RegionFit) {
 	if !ValidateRegion(region) || !ValidateStores(stores) {
+		manager.misses.Add(1)
 		return false, nil
 	}
 	manager.mu.RLock()
 	defer manager.mu.RUnlock()
 	if cache, ok := manager.regionCaches[region.GetID()]; ok {
-		if cache.IsUnchanged(region, rules, stores) {
+		switch {
+		case !cache.isRegionUnchanged(region):
+			manager.regionChanged.Add(1)
+		case !rulesEqual(cache.rules, rules):
+			manager.rulesChanged.Add(1)
+		case !storesEqual(cache.regionStores, stores):
+			manager.storesChanged.Add(1)
+		default:
+			manager.hits.Add(1)
 			return true, cache.bestFit
 		}
 	}
+	manager.misses.Add(1)
 	return false, nil
 }
 
+// GetStats returns the statistics of the cache.
+func (manager *RegionRuleFitCacheManager) GetStats() RuleFitCacheStats {
+	manager.mu.RLock()
+	cachedRegions := len(manager.regionCaches)
+	manager.mu.RUnlock()
+	return RuleFitCacheStats{
+		CachedRegions: cachedRegions,
+		Hits:          manager.hits.Load(),
+		Misses:        manager.misses.Load(),
+		Invalidations: manager.invalidations.Load(),
+		RegionChanged: manager.regionChanged.Load(),
+		RulesChanged:  manager.rulesChanged.Load(),
+		StoresChanged: manager.storesChanged.Load(),
+	}
+}
+
 // SetCache stores RegionFit cache
 func (manager *RegionRuleFitCacheManager) SetCache(region *core.RegionInfo, fit *RegionFit) {
 	if !ValidateRegion(region) || !ValidateFit(fit) || !ValidateStores(fit.regionStores) {
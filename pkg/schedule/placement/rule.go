@@ -17,9 +17,12 @@ package placement
 import (
 	"encoding/hex"
 	"encoding/json"
+	"slices"
 	"sort"
 
 	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
 )
 
 // PeerRoleType is the expected peer type of the placement rule.
@@ -54,23 +57,24 @@ func (s PeerRoleType) MetaPeerRole() metapb.PeerRole {
 //
 // NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
 type Rule struct {
-	GroupID          string            `json:"group_id"`                    // mark the source that add the rule
-	ID               string            `json:"id"`                          // unique ID within a group
-	Index            int               `json:"index,omitempty"`             // rule apply order in a group, rule with less ID is applied first when indexes are equal
-	Override         bool              `json:"override,omitempty"`          // when it is true, all rules with less indexes are disabled
-	StartKey         []byte            `json:"-"`                           // range start key
-	StartKeyHex      string            `json:"start_key"`                   // hex format start key, for marshal/unmarshal
-	EndKey           []byte            `json:"-"`                           // range end key
-	EndKeyHex        string            `json:"end_key"`                     // hex format end key, for marshal/unmarshal
-	Role             PeerRoleType      `json:"role"`                        // expected role of the peers
-	IsWitness        bool              `json:"is_witness"`                  // when it is true, it means the role is also a witness
-	Count            int               `json:"count"`                       // expected count of the peers
-	LabelConstraints []LabelConstraint `json:"label_constraints,omitempty"` // used to select stores to place peers
-	LocationLabels   []string          `json:"location_labels,omitempty"`   // used to make peers isolated physically
-	IsolationLevel   string            `json:"isolation_level,omitempty"`   // used to isolate replicas explicitly and forcibly
-	Version          uint64            `json:"version,omitempty"`           // only set at runtime, add 1 each time rules updated, begin from 0.
-	CreateTimestamp  uint64            `json:"create_timestamp,omitempty"`  // only set at runtime, recorded rule create timestamp
-	group            *RuleGroup        // only set at runtime, no need to {,un}marshal or persist.
+	GroupID           string             `json:"group_id"`                     // mark the source that add the rule
+	ID                string             `json:"id"`                           // unique ID within a group
+	Index             int                `json:"index,omitempty"`              // rule apply order in a group, rule with less ID is applied first when indexes are equal
+	Override          bool               `json:"override,omitempty"`           // when it is true, all rules with less indexes are disabled
+	StartKey          []byte             `json:"-"`                            // range start key
+	StartKeyHex       string             `json:"start_key"`                    // hex format start key, for marshal/unmarshal
+	EndKey            []byte             `json:"-"`                            // range end key
+	EndKeyHex         string             `json:"end_key"`                      // hex format end key, for marshal/unmarshal
+	Role              PeerRoleType       `json:"role"`                         // expected role of the peers
+	IsWitness         bool               `json:"is_witness"`                   // when it is true, it means the role is also a witness
+	Count             int                `json:"count"`                        // expected count of the peers
+	LabelConstraints  []LabelConstraint  `json:"label_constraints,omitempty"`  // used to select stores to place peers
+	LocationLabels    []string           `json:"location_labels,omitempty"`    // used to make peers isolated physically
+	IsolationLevel    string             `json:"isolation_level,omitempty"`    // used to isolate replicas explicitly and forcibly
+	LeaderPreferences []LeaderPreference `json:"leader_preferences,omitempty"` // ordered preferences of the leader location
+	Version           uint64             `json:"version,omitempty"`            // only set at runtime, add 1 each time rules updated, begin from 0.
+	CreateTimestamp   uint64             `json:"create_timestamp,omitempty"`   // only set at runtime, recorded rule create timestamp
+	group             *RuleGroup         // only set at runtime, no need to {,un}marshal or persist.
 }
 
 // NewRuleFromJSON creates a rule from the JSON data.
@@ -106,6 +110,17 @@ func (r *Rule) StoreKey() string {
 	return hex.EncodeToString([]byte(r.GroupID)) + "-" + hex.EncodeToString([]byte(r.ID))
 }
 
+// IsTiFlash returns true if the rule places the peers on the TiFlash stores.
+// The soft constraints are ignored because they do not restrict the stores.
+func (r *Rule) IsTiFlash() bool {
+	for _, c := range r.LabelConstraints {
+		if !c.IsSoft() && c.Key == core.EngineKey && c.Op == In && slices.Contains(c.Values, core.EngineTiFlash) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Rule) groupIndex() int {
 	if r.group != nil {
 		return r.group.Index
@@ -119,6 +134,9 @@ type RuleGroup struct {
 	ID       string `json:"id,omitempty"`
 	Index    int    `json:"index,omitempty"`
 	Override bool   `json:"override,omitempty"`
+	// LeaderPreferences are used by the rules in the group which have no
+	// leader preferences.
+	LeaderPreferences []LeaderPreference `json:"leader_preferences,omitempty"`
 }
 
 // NewRuleGroupFromJSON creates a rule group from the JSON data.
@@ -131,7 +149,7 @@ func NewRuleGroupFromJSON(data []byte) (*RuleGroup, error) {
 }
 
 func (g *RuleGroup) isDefault() bool {
-	return g.Index == 0 && !g.Override
+	return g.Index == 0 && !g.Override && len(g.LeaderPreferences) == 0
 }
 
 func (g *RuleGroup) String() string {
@@ -142,9 +160,10 @@ func (g *RuleGroup) String() string {
 // Clone returns a copy of RuleGroup.
 func (g *RuleGroup) Clone() *RuleGroup {
 	return &RuleGroup{
-		ID:       g.ID,
-		Index:    g.Index,
-		Override: g.Override,
+		ID:                g.ID,
+		Index:             g.Index,
+		Override:          g.Override,
+		LeaderPreferences: cloneLeaderPreferences(g.LeaderPreferences),
 	}
 }
 
@@ -210,10 +229,11 @@ func prepareRulesForApply(rules []*Rule) []*Rule {
 // GroupBundle represents a rule group and all rules belong to the group.
 // NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
 type GroupBundle struct {
-	ID       string  `json:"group_id"`
-	Index    int     `json:"group_index"`
-	Override bool    `json:"group_override"`
-	Rules    []*Rule `json:"rules"`
+	ID                string             `json:"group_id"`
+	Index             int                `json:"group_index"`
+	Override          bool               `json:"group_override"`
+	LeaderPreferences []LeaderPreference `json:"group_leader_preferences,omitempty"`
+	Rules             []*Rule            `json:"rules"`
 }
 
 func (g GroupBundle) String() string {
@@ -0,0 +1,237 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+)
+
+// maxRuleHistories is the max number of the persisted rule histories, the
+// older ones are dropped.
+const maxRuleHistories = 100
+
+// RuleHistory is a persisted change of the rule group bundles.
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type RuleHistory struct {
+	Version   uint64               `json:"version"`
+	Timestamp int64                `json:"timestamp"`
+	Source    string               `json:"source,omitempty"`
+	Changes   []*GroupBundleChange `json:"changes"`
+}
+
+// GroupBundleChange is the change of a group bundle. Old is nil if the group
+// is created, and New is nil if the group is deleted.
+type GroupBundleChange struct {
+	GroupID string       `json:"group_id"`
+	Old     *GroupBundle `json:"old,omitempty"`
+	New     *GroupBundle `json:"new,omitempty"`
+}
+
+// WithHistorySource runs the update, and attributes the rule histories recorded by it to the source.
+// The updates with a source are serialized, so the update must not call WithHistorySource again.
+// The histories recorded by the other updates have no source, unless they are committed
+// concurrently with an update with a source.
+func (m *RuleManager) WithHistorySource(source string, update func()) {
+	m.historyMu.Lock()
+	defer m.historyMu.Unlock()
+	m.setHistorySource(source)
+	defer m.setHistorySource("")
+	update()
+}
+
+func (m *RuleManager) setHistorySource(source string) {
+	m.Lock()
+	defer m.Unlock()
+	m.historySource = source
+}
+
+// historyBatch returns the operations to persist the changes of the group bundles made by the patch
+// as a new history, and to drop the oldest histories beyond maxRuleHistories. It should be called
+// with the lock held after the patch is trimmed.
+func (m *RuleManager) historyBatch(p *RuleConfigPatch) ([]func(kv.Txn) error, error) {
+	if m.skipHistory {
+		return nil, nil
+	}
+	changes := p.groupBundleChanges()
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	histories, err := m.GetHistories()
+	if err != nil {
+		return nil, err
+	}
+	history := &RuleHistory{
+		Version:   1,
+		Timestamp: time.Now().Unix(),
+		Source:    m.historySource,
+		Changes:   changes,
+	}
+	if len(histories) > 0 {
+		history.Version = histories[0].Version + 1
+	}
+	batch := []func(kv.Txn) error{func(txn kv.Txn) error {
+		return m.storage.SaveRuleHistory(txn, history.Version, history)
+	}}
+	for i := maxRuleHistories - 1; i < len(histories); i++ {
+		version := histories[i].Version
+		batch = append(batch, func(txn kv.Txn) error {
+			return m.storage.DeleteRuleHistory(txn, version)
+		})
+	}
+	return batch, nil
+}
+
+// GetHistories returns the persisted rule histories, the latest one first.
+func (m *RuleManager) GetHistories() ([]*RuleHistory, error) {
+	var histories []*RuleHistory
+	err := m.storage.LoadRuleHistories(func(k, v string) {
+		history := &RuleHistory{}
+		if err := json.Unmarshal([]byte(v), history); err != nil {
+			log.Warn("failed to unmarshal rule history", zap.String("key", k), errs.ZapError(errs.ErrLoadRule, err))
+			return
+		}
+		histories = append(histories, history)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(histories, func(i, j int) bool { return histories[i].Version > histories[j].Version })
+	return histories, nil
+}
+
+// RollbackToVersion restores the group bundles to the state right after the
+// history of the version, by reverting all the later changes.
+func (m *RuleManager) RollbackToVersion(version uint64) error {
+	histories, err := m.GetHistories()
+	if err != nil {
+		return err
+	}
+	found := false
+	// The earliest change of each group after the version.
+	reverts := make(map[string]*GroupBundleChange)
+	for _, h := range histories {
+		if h.Version <= version {
+			found = h.Version == version
+			break
+		}
+		for _, c := range h.Changes {
+			reverts[c.GroupID] = c
+		}
+	}
+	if !found {
+		return errs.ErrRuleHistoryNotFound.FastGenByArgs(version)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	p := m.BeginPatch()
+	groups := make([]GroupBundle, 0, len(reverts))
+	for id, c := range reverts {
+		if c.Old != nil {
+			groups = append(groups, *c.Old)
+			continue
+		}
+		// The group is created after the version.
+		for k := range m.ruleConfig.rules {
+			if k[0] == id {
+				p.DeleteRule(k[0], k[1])
+			}
+		}
+		p.DeleteGroup(id)
+	}
+	if err := m.patchGroupBundles(p, groups, false); err != nil {
+		return err
+	}
+	if err := m.TryCommitPatchLocked(p); err != nil {
+		return err
+	}
+	log.Info("placement rules rolled back", zap.Uint64("version", version))
+	return nil
+}
+
+// groupBundleChanges returns the changed group bundles made by the patch, sorted by the group ID.
+// It should be called after the patch is trimmed.
+func (p *RuleConfigPatch) groupBundleChanges() []*GroupBundleChange {
+	ids := make(map[string]struct{})
+	for key := range p.mut.rules {
+		ids[key[0]] = struct{}{}
+	}
+	for id := range p.mut.groups {
+		ids[id] = struct{}{}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	newBundle := func(g *RuleGroup) *GroupBundle {
+		return &GroupBundle{
+			ID:                g.ID,
+			Index:             g.Index,
+			Override:          g.Override,
+			LeaderPreferences: g.LeaderPreferences,
+		}
+	}
+	before := make(map[string]*GroupBundle)
+	after := make(map[string]*GroupBundle)
+	for id := range ids {
+		if g, ok := p.c.groups[id]; ok {
+			before[id] = newBundle(g)
+		}
+		if g := p.getGroup(id); !g.isDefault() {
+			after[id] = newBundle(g)
+		}
+	}
+	p.c.iterateRules(func(r *Rule) {
+		if b, ok := before[r.GroupID]; ok {
+			b.Rules = append(b.Rules, r)
+		}
+	})
+	p.iterateRules(func(r *Rule) {
+		if _, ok := ids[r.GroupID]; !ok {
+			return
+		}
+		b, ok := after[r.GroupID]
+		if !ok {
+			// The group has no configuration but rules.
+			b = newBundle(p.getGroup(r.GroupID))
+			after[r.GroupID] = b
+		}
+		b.Rules = append(b.Rules, r)
+	})
+	changes := make([]*GroupBundleChange, 0, len(ids))
+	for id := range ids {
+		c := &GroupBundleChange{GroupID: id, Old: before[id], New: after[id]}
+		if c.Old != nil {
+			sortRules(c.Old.Rules)
+		}
+		if c.New != nil {
+			sortRules(c.New.Rules)
+		}
+		if jsonEquals(c.Old, c.New) {
+			continue
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].GroupID < changes[j].GroupID })
+	return changes
+}
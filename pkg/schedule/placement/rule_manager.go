@@ -39,6 +39,7 @@ import (
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keyutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
 )
 
@@ -57,6 +58,12 @@ type RuleManager struct {
 	ctx     context.Context
 	storage endpoint.RuleStorage
 	syncutil.RWMutex
+	// historyMu serializes the updates with a history source.
+	historyMu syncutil.Mutex
+	// historySource is the source which the rule histories are attributed to.
+	historySource string
+	// skipHistory is true if the rules are synced from PD, which records the rule histories.
+	skipHistory bool
 	initialized bool
 	ruleConfig  *ruleConfig
 	ruleList    ruleList
@@ -66,6 +73,9 @@ type RuleManager struct {
 	storeSetInformer core.StoreSetInformer
 	cache            *RegionRuleFitCacheManager
 	conf             config.SharedConfigProvider
+
+	// ruleChangeListener is notified with the affected key ranges after the rules are changed.
+	ruleChangeListener func(ranges []*keyutil.KeyRange)
 }
 
 // NewRuleManager creates a RuleManager instance.
@@ -94,6 +104,7 @@ func (m *RuleManager) Initialize(maxReplica int, locationLabels []string, isolat
 		m.ruleList = ruleList{
 			rangeList: rangelist.List{},
 		}
+		m.skipHistory = true
 		m.initialized = true
 		return nil
 	}
@@ -283,10 +294,21 @@ func (m *RuleManager) AdjustRule(r *Rule, groupID string) (err error) {
 		if !validateOp(c.Op) {
 			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid op %s", c.Op))
 		}
+		if c.Weight < 0 {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("invalid weight %d", c.Weight))
+		}
 		if r.IsWitness && c.Key == core.EngineKey && slices.Contains(c.Values, core.EngineTiFlash) {
 			return errs.ErrRuleContent.FastGenByArgs("witness can't combine with tiflash")
 		}
 	}
+	if len(r.LeaderPreferences) > 0 {
+		if r.Role != Voter && r.Role != Leader {
+			return errs.ErrRuleContent.FastGenByArgs(fmt.Sprintf("leader preferences can't be set for %s", r.Role))
+		}
+		if err := validateLeaderPreferences(r.LeaderPreferences); err != nil {
+			return err
+		}
+	}
 
 	if m.storeSetInformer != nil {
 		stores := m.storeSetInformer.GetStores()
@@ -457,6 +479,11 @@ func (m *RuleManager) InvalidCache(regionID uint64) {
 	m.cache.Invalid(regionID)
 }
 
+// GetRuleFitCacheStats returns the statistics of the region rule fit cache.
+func (m *RuleManager) GetRuleFitCacheStats() RuleFitCacheStats {
+	return m.cache.GetStats()
+}
+
 // SetPlaceholderRegionFitCache sets a placeholder region fit cache information
 // Only used for testing
 func (m *RuleManager) SetPlaceholderRegionFitCache(region *core.RegionInfo) {
@@ -490,9 +517,10 @@ func (m *RuleManager) TryCommitPatchLocked(patch *RuleConfigPatch) error {
 	}
 
 	patch.trim()
+	changedRanges := patch.changedKeyRanges()
 
-	// save updates
-	err = m.savePatch(patch.mut)
+	// save updates and the history of them
+	err = m.savePatch(patch)
 	if err != nil {
 		return err
 	}
@@ -500,10 +528,22 @@ func (m *RuleManager) TryCommitPatchLocked(patch *RuleConfigPatch) error {
 	// update in-memory state
 	patch.commit()
 	m.ruleList = ruleList
+	if m.ruleChangeListener != nil && !changedRanges.IsEmpty() {
+		m.ruleChangeListener(changedRanges.Ranges())
+	}
 	return nil
 }
 
-func (m *RuleManager) savePatch(p *ruleConfig) error {
+// SetRuleChangeListener sets the listener which is notified with the affected key ranges
+// after the rules are changed. The listener is called with the lock held, so it should not block.
+func (m *RuleManager) SetRuleChangeListener(listener func(ranges []*keyutil.KeyRange)) {
+	m.Lock()
+	defer m.Unlock()
+	m.ruleChangeListener = listener
+}
+
+func (m *RuleManager) savePatch(patch *RuleConfigPatch) error {
+	p := patch.mut
 	var batch []func(kv.Txn) error
 	// add rules to batch
 	for key, r := range p.rules {
@@ -532,6 +572,11 @@ func (m *RuleManager) savePatch(p *ruleConfig) error {
 			})
 		}
 	}
+	historyBatch, err := m.historyBatch(patch)
+	if err != nil {
+		return err
+	}
+	batch = append(batch, historyBatch...)
 	return endpoint.RunBatchOpInTxn(m.ctx, m.storage, batch)
 }
 
@@ -641,6 +686,9 @@ func (m *RuleManager) GetRuleGroups() []*RuleGroup {
 
 // SetRuleGroup updates a RuleGroup.
 func (m *RuleManager) SetRuleGroup(group *RuleGroup) error {
+	if err := validateLeaderPreferences(group.LeaderPreferences); err != nil {
+		return err
+	}
 	m.Lock()
 	defer m.Unlock()
 	p := m.BeginPatch()
@@ -673,9 +721,10 @@ func (m *RuleManager) GetAllGroupBundles() []GroupBundle {
 	bundles := make([]GroupBundle, 0, len(m.ruleConfig.groups))
 	for _, g := range m.ruleConfig.groups {
 		bundles = append(bundles, GroupBundle{
-			ID:       g.ID,
-			Index:    g.Index,
-			Override: g.Override,
+			ID:                g.ID,
+			Index:             g.Index,
+			Override:          g.Override,
+			LeaderPreferences: g.LeaderPreferences,
 		})
 	}
 	for _, r := range m.ruleConfig.rules {
@@ -701,7 +750,7 @@ func (m *RuleManager) GetGroupBundle(id string) (b GroupBundle) {
 	defer m.RUnlock()
 	b.ID = id
 	if g := m.ruleConfig.groups[id]; g != nil {
-		b.Index, b.Override = g.Index, g.Override
+		b.Index, b.Override, b.LeaderPreferences = g.Index, g.Override, g.LeaderPreferences
 		for _, r := range m.ruleConfig.rules {
 			if r.GroupID == id {
 				b.Rules = append(b.Rules, r)
@@ -717,6 +766,18 @@ func (m *RuleManager) SetAllGroupBundles(groups []GroupBundle, override bool) er
 	m.Lock()
 	defer m.Unlock()
 	p := m.BeginPatch()
+	if err := m.patchGroupBundles(p, groups, override); err != nil {
+		return err
+	}
+	if err := m.TryCommitPatchLocked(p); err != nil {
+		return err
+	}
+	log.Info("full config reset", zap.String("config", fmt.Sprint(groups)))
+	return nil
+}
+
+// patchGroupBundles records the changes of resetting the groups to the patch.
+func (m *RuleManager) patchGroupBundles(p *RuleConfigPatch, groups []GroupBundle, override bool) error {
 	matchID := func(a string) bool {
 		for _, g := range groups {
 			if g.ID == a {
@@ -725,21 +786,25 @@ func (m *RuleManager) SetAllGroupBundles(groups []GroupBundle, override bool) er
 		}
 		return false
 	}
-	for k := range m.ruleConfig.rules {
+	for k := range p.c.rules {
 		if override || matchID(k[0]) {
 			p.DeleteRule(k[0], k[1])
 		}
 	}
-	for id := range m.ruleConfig.groups {
+	for id := range p.c.groups {
 		if override || matchID(id) {
 			p.DeleteGroup(id)
 		}
 	}
 	for _, g := range groups {
+		if err := validateLeaderPreferences(g.LeaderPreferences); err != nil {
+			return err
+		}
 		p.SetGroup(&RuleGroup{
-			ID:       g.ID,
-			Index:    g.Index,
-			Override: g.Override,
+			ID:                g.ID,
+			Index:             g.Index,
+			Override:          g.Override,
+			LeaderPreferences: g.LeaderPreferences,
 		})
 		for _, r := range g.Rules {
 			if err := m.AdjustRule(r, g.ID); err != nil {
@@ -748,10 +813,6 @@ func (m *RuleManager) SetAllGroupBundles(groups []GroupBundle, override bool) er
 			p.SetRule(r)
 		}
 	}
-	if err := m.TryCommitPatchLocked(p); err != nil {
-		return err
-	}
-	log.Info("full config reset", zap.String("config", fmt.Sprint(groups)))
 	return nil
 }
 
@@ -768,10 +829,14 @@ func (m *RuleManager) SetGroupBundle(group GroupBundle) error {
 			}
 		}
 	}
+	if err := validateLeaderPreferences(group.LeaderPreferences); err != nil {
+		return err
+	}
 	p.SetGroup(&RuleGroup{
-		ID:       group.ID,
-		Index:    group.Index,
-		Override: group.Override,
+		ID:                group.ID,
+		Index:             group.Index,
+		Override:          group.Override,
+		LeaderPreferences: group.LeaderPreferences,
 	})
 	for _, r := range group.Rules {
 		if err := m.AdjustRule(r, group.ID); err != nil {
@@ -26,9 +26,11 @@ import (
 	"github.com/tikv/pd/pkg/codec"
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mock/mockconfig"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/keyutil"
 )
 
 func newTestManager(t *testing.T, enableWitness bool) (endpoint.RuleStorage, *RuleManager) {
@@ -500,8 +502,20 @@ func TestCacheManager(t *testing.T) {
 	re.NotNil(cache.bestFit)
 	// Cache invalidation after change
 	regionMeta.Peers[2] = &metapb.Peer{Id: 14, StoreId: 4111, Role: metapb.PeerRole_Voter}
+	regionMeta.RegionEpoch = &metapb.RegionEpoch{ConfVer: 1, Version: 0}
 	region = core.NewRegionInfo(regionMeta, regionMeta.Peers[0])
 	re.False(manager.IsRegionFitCached(stores, region))
+	stats := manager.GetRuleFitCacheStats()
+	re.Equal(1, stats.CachedRegions)
+	re.Equal(uint64(2*(minHitCountToCacheHit/2-1)+minHitCountToCacheHit), stats.Hits)
+	re.Equal(uint64(1), stats.RegionChanged)
+	re.Zero(stats.RulesChanged)
+	re.Zero(stats.Invalidations)
+	manager.InvalidCache(1)
+	manager.InvalidCache(1)
+	stats = manager.GetRuleFitCacheStats()
+	re.Zero(stats.CachedRegions)
+	re.Equal(uint64(1), stats.Invalidations)
 }
 
 func dhex(hk string) []byte {
@@ -511,3 +525,131 @@ func dhex(hk string) []byte {
 	}
 	return k
 }
+
+func TestLeaderPreferences(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	preferences := []LeaderPreference{
+		{LabelConstraints: []LabelConstraint{{Key: "zone", Op: In, Values: []string{"z1"}}}},
+		{LabelConstraints: []LabelConstraint{{Key: "zone", Op: In, Values: []string{"z2"}}}},
+	}
+	re.Error(manager.SetRule(&Rule{GroupID: "g", ID: "learner", Role: Learner, Count: 1, LeaderPreferences: preferences}))
+	re.Error(manager.SetRule(&Rule{GroupID: "g", ID: "voter", Role: Voter, Count: 3, LeaderPreferences: []LeaderPreference{{}}}))
+	re.Error(manager.SetRuleGroup(&RuleGroup{ID: "g", LeaderPreferences: []LeaderPreference{
+		{LabelConstraints: []LabelConstraint{{Key: "zone", Op: In, Values: []string{"z1"}, Weight: 1}}},
+	}}))
+
+	re.NoError(manager.SetGroupBundle(GroupBundle{ID: "g", LeaderPreferences: preferences, Rules: []*Rule{
+		{GroupID: "g", ID: "voter", Role: Voter, Count: 3},
+	}}))
+	re.Equal(preferences, manager.GetGroupBundle("g").LeaderPreferences)
+	rules := manager.GetRulesForApplyRange(nil, nil)
+	re.Equal(preferences, GetLeaderPreferences(rules))
+	re.Equal(1, LeaderPreferenceRank(core.NewStoreInfoWithLabel(1, map[string]string{"zone": "z2"}), preferences))
+	re.Equal(2, LeaderPreferenceRank(core.NewStoreInfoWithLabel(2, map[string]string{"zone": "z3"}), preferences))
+
+	// The preferences of the rule take precedence over the group.
+	re.NoError(manager.SetRule(&Rule{GroupID: "g", ID: "voter", Role: Voter, Count: 3, LeaderPreferences: preferences[1:]}))
+	rules = manager.GetRulesForApplyRange(nil, nil)
+	re.Equal(preferences[1:], GetLeaderPreferences(rules))
+	re.Zero(LeaderPreferenceRank(core.NewStoreInfoWithLabel(1, map[string]string{"zone": "z2"}), GetLeaderPreferences(rules)))
+}
+
+func TestRuleHistory(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	// Changes without updating the rules are not recorded.
+	manager.WithHistorySource("test", func() {
+		re.NoError(manager.SetRule(manager.GetRule(DefaultGroupID, DefaultRuleID).Clone()))
+	})
+	histories, err := manager.GetHistories()
+	re.NoError(err)
+	re.Empty(histories)
+
+	manager.WithHistorySource("user1", func() {
+		re.NoError(manager.SetRule(&Rule{GroupID: "g1", ID: "r1", Role: Voter, Count: 1}))
+	})
+	manager.WithHistorySource("user2", func() {
+		re.NoError(manager.SetRules([]*Rule{
+			{GroupID: "g1", ID: "r1", Role: Voter, Count: 2},
+			{GroupID: "g2", ID: "r1", Role: Voter, Count: 1},
+		}))
+	})
+	// The changes out of a source are recorded too.
+	re.NoError(manager.DeleteRule(DefaultGroupID, DefaultRuleID))
+	histories, err = manager.GetHistories()
+	re.NoError(err)
+	re.Len(histories, 3)
+	re.Equal(uint64(3), histories[0].Version)
+	re.Empty(histories[0].Source)
+	re.Len(histories[0].Changes, 1)
+	re.Equal(DefaultGroupID, histories[0].Changes[0].GroupID)
+	re.NotNil(histories[0].Changes[0].Old)
+	re.Nil(histories[0].Changes[0].New)
+	re.Equal("user2", histories[1].Source)
+	re.Len(histories[1].Changes, 2)
+	re.Equal("g1", histories[1].Changes[0].GroupID)
+	re.Equal(1, histories[1].Changes[0].Old.Rules[0].Count)
+	re.Equal(2, histories[1].Changes[0].New.Rules[0].Count)
+	re.Nil(histories[1].Changes[1].Old)
+	re.Equal("user1", histories[2].Source)
+
+	// Roll back to the state after the first change, the rollback is recorded as well.
+	re.NoError(manager.RollbackToVersion(1))
+	re.Equal(1, manager.GetRule("g1", "r1").Count)
+	re.Nil(manager.GetRule("g2", "r1"))
+	re.NotNil(manager.GetRule(DefaultGroupID, DefaultRuleID))
+	re.Error(manager.RollbackToVersion(10))
+	histories, err = manager.GetHistories()
+	re.NoError(err)
+	re.Len(histories, 4)
+	re.Len(histories[0].Changes, 3)
+
+	// The older histories are dropped.
+	for i := range maxRuleHistories {
+		re.NoError(manager.SetRule(&Rule{GroupID: "g1", ID: "r1", Role: Voter, Count: i + 2}))
+	}
+	histories, err = manager.GetHistories()
+	re.NoError(err)
+	re.Len(histories, maxRuleHistories)
+	re.Equal(uint64(maxRuleHistories+4), histories[0].Version)
+	re.True(errs.ErrRuleHistoryNotFound.Equal(manager.RollbackToVersion(1)))
+}
+
+func TestRuleChangeListener(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	var changed [][2]string
+	manager.SetRuleChangeListener(func(ranges []*keyutil.KeyRange) {
+		for _, r := range ranges {
+			changed = append(changed, [2]string{hex.EncodeToString(r.StartKey), hex.EncodeToString(r.EndKey)})
+		}
+	})
+
+	// Add a new rule.
+	rule := &Rule{GroupID: "g", ID: "1", StartKeyHex: "11", EndKeyHex: "22", Role: Voter, Count: 1}
+	re.NoError(manager.SetRule(rule))
+	re.Equal([][2]string{{"11", "22"}}, changed)
+
+	// Move the rule, both the old and the new ranges are affected.
+	changed = nil
+	rule = &Rule{GroupID: "g", ID: "1", StartKeyHex: "33", EndKeyHex: "44", Role: Voter, Count: 1}
+	re.NoError(manager.SetRule(rule))
+	re.ElementsMatch([][2]string{{"11", "22"}, {"33", "44"}}, changed)
+
+	// Set the same rule again, nothing is changed.
+	changed = nil
+	re.NoError(manager.SetRule(rule.Clone()))
+	re.Empty(changed)
+
+	// Update the group, all rules in the group are affected.
+	re.NoError(manager.SetRule(&Rule{GroupID: "g", ID: "2", StartKeyHex: "55", EndKeyHex: "66", Role: Voter, Count: 1}))
+	changed = nil
+	re.NoError(manager.SetRuleGroup(&RuleGroup{ID: "g", Index: 1, Override: true}))
+	re.ElementsMatch([][2]string{{"33", "44"}, {"55", "66"}}, changed)
+
+	// Delete the rule.
+	changed = nil
+	re.NoError(manager.DeleteRule("g", "2"))
+	re.Equal([][2]string{{"55", "66"}}, changed)
+}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core"
 )
 
 func TestPrepareRulesForApply(t *testing.T) {
@@ -213,3 +215,14 @@ func TestRuleKeyClone(t *testing.T) {
 	re.Equal([]byte{9, 2, 3}, r.StartKey)
 	re.Equal([]byte{9, 5, 6}, r.EndKey)
 }
+
+func TestRuleIsTiFlash(t *testing.T) {
+	re := require.New(t)
+	r := &Rule{LabelConstraints: []LabelConstraint{{Key: core.EngineKey, Op: In, Values: []string{core.EngineTiFlash}}}}
+	re.True(r.IsTiFlash())
+	// The soft constraint only prefers the TiFlash stores.
+	r.LabelConstraints[0].Weight = 1
+	re.False(r.IsTiFlash())
+	r.LabelConstraints[0] = LabelConstraint{Key: core.EngineKey, Op: NotIn, Values: []string{core.EngineTiFlash}}
+	re.False(r.IsTiFlash())
+}
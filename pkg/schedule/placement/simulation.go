@@ -0,0 +1,219 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"sort"
+
+	"github.com/docker/go-units"
+
+	"github.com/tikv/pd/pkg/core"
+)
+
+// maxUnsatisfiableSamples is the max number of the unsatisfiable region IDs
+// returned by the simulation.
+const maxUnsatisfiableSamples = 100
+
+// SimulationResult is the estimated result of applying a candidate rule set
+// to the current topology.
+type SimulationResult struct {
+	RegionCount int `json:"region-count"`
+	// UnsatisfiableCount is the number of regions which cannot be placed to
+	// satisfy the rules with the current stores.
+	UnsatisfiableCount int `json:"unsatisfiable-count"`
+	// UnsatisfiableRegions is a sample of the unsatisfiable regions.
+	UnsatisfiableRegions []uint64 `json:"unsatisfiable-regions,omitempty"`
+	// ChangedCount is the number of regions which need to be scheduled.
+	ChangedCount int   `json:"changed-count"`
+	AddPeers     int   `json:"add-peers"`
+	RemovePeers  int   `json:"remove-peers"`
+	RoleChanges  int   `json:"role-changes"`
+	MoveBytes    int64 `json:"move-bytes"`
+	// Stores are the estimated changes of each store, sorted by the store ID.
+	Stores []*StoreSimulation `json:"stores"`
+}
+
+// StoreSimulation is the estimated peer changes of a store.
+type StoreSimulation struct {
+	StoreID     uint64 `json:"store-id"`
+	AddPeers    int    `json:"add-peers"`
+	RemovePeers int    `json:"remove-peers"`
+	AddBytes    int64  `json:"add-bytes"`
+	RemoveBytes int64  `json:"remove-bytes"`
+}
+
+// TargetSelector returns the candidate stores to place a new peer of the rule
+// in the simulation. The ruleStores are the stores of the peers which fit the
+// rule, and the excluded stores already have a peer of the region.
+type TargetSelector func(region *core.RegionInfo, rule *Rule, ruleStores []*core.StoreInfo, excluded map[uint64]struct{}) []*core.StoreInfo
+
+// SimulateGroupBundles estimates the result of resetting the groups like
+// SetAllGroupBundles. It fits every region against the candidate rules and
+// the given stores without changing the rules in use.
+func (m *RuleManager) SimulateGroupBundles(storeSet StoreSet, regions []*core.RegionInfo, groups []GroupBundle, override bool, selector TargetSelector) (*SimulationResult, error) {
+	rl, err := m.buildCandidateRuleList(groups, override)
+	if err != nil {
+		return nil, err
+	}
+	s := newRuleSimulator(storeSet, m.conf.IsWitnessAllowed(), selector)
+	for _, region := range regions {
+		s.simulateRegion(region, rl.getRulesForApplyRange(region.GetStartKey(), region.GetEndKey()))
+	}
+	return s.result(), nil
+}
+
+// buildCandidateRuleList builds the rule list on a copy of the current rule
+// config patched by the groups.
+func (m *RuleManager) buildCandidateRuleList(groups []GroupBundle, override bool) (ruleList, error) {
+	m.RLock()
+	c := newRuleConfig()
+	for key, r := range m.ruleConfig.rules {
+		c.rules[key] = r.Clone()
+	}
+	for id, g := range m.ruleConfig.groups {
+		c.groups[id] = g.Clone()
+	}
+	m.RUnlock()
+
+	p := c.beginPatch()
+	if err := m.patchGroupBundles(p, groups, override); err != nil {
+		return ruleList{}, err
+	}
+	p.adjust()
+	return buildRuleList(p)
+}
+
+type ruleSimulator struct {
+	storeSet       StoreSet
+	selector       TargetSelector
+	supportWitness bool
+	// delta is the simulated region count change of each store, which is
+	// used to spread the added peers.
+	delta        map[uint64]int
+	res          *SimulationResult
+	storeResults map[uint64]*StoreSimulation
+}
+
+func newRuleSimulator(storeSet StoreSet, supportWitness bool, selector TargetSelector) *ruleSimulator {
+	return &ruleSimulator{
+		storeSet:       storeSet,
+		selector:       selector,
+		supportWitness: supportWitness,
+		delta:          make(map[uint64]int),
+		res:            &SimulationResult{},
+		storeResults:   make(map[uint64]*StoreSimulation),
+	}
+}
+
+func (s *ruleSimulator) simulateRegion(region *core.RegionInfo, rules []*Rule) {
+	s.res.RegionCount++
+	regionStores := getStoresByRegion(s.storeSet, region)
+	fit := fitRegion(regionStores, region, rules, s.supportWitness)
+	if fit.IsSatisfied() {
+		return
+	}
+	s.res.ChangedCount++
+	if len(fit.RuleFits) == 0 {
+		// No rule covers the region, all peers would be orphans.
+		s.markUnsatisfiable(region)
+		return
+	}
+
+	size := region.GetApproximateSize() * units.MiB
+	used := make(map[uint64]struct{}, len(region.GetPeers()))
+	for _, peer := range region.GetPeers() {
+		used[peer.GetStoreId()] = struct{}{}
+	}
+	satisfiable := true
+	for _, rf := range fit.RuleFits {
+		s.res.RoleChanges += len(rf.PeersWithDifferentRole)
+		var ruleStores []*core.StoreInfo
+		for _, peer := range rf.Peers {
+			if store := s.storeSet.GetStore(peer.GetStoreId()); store != nil {
+				ruleStores = append(ruleStores, store)
+			}
+		}
+		for i := len(rf.Peers); i < rf.Rule.Count; i++ {
+			target := s.selectTarget(region, rf.Rule, ruleStores, used)
+			if target == nil {
+				satisfiable = false
+				break
+			}
+			ruleStores = append(ruleStores, target)
+			used[target.GetID()] = struct{}{}
+			s.delta[target.GetID()]++
+			st := s.getStore(target.GetID())
+			st.AddPeers++
+			st.AddBytes += size
+			s.res.AddPeers++
+			s.res.MoveBytes += size
+		}
+	}
+	for _, peer := range fit.OrphanPeers {
+		s.delta[peer.GetStoreId()]--
+		st := s.getStore(peer.GetStoreId())
+		st.RemovePeers++
+		st.RemoveBytes += size
+		s.res.RemovePeers++
+	}
+	if !satisfiable {
+		s.markUnsatisfiable(region)
+	}
+}
+
+// selectTarget picks the store with the least regions among the candidates
+// given by the selector, the regions added by the simulation are counted. The
+// region count is discounted by the label preference of the rule.
+func (s *ruleSimulator) selectTarget(region *core.RegionInfo, rule *Rule, ruleStores []*core.StoreInfo, used map[uint64]struct{}) *core.StoreInfo {
+	var (
+		target      *core.StoreInfo
+		targetScore float64
+	)
+	for _, store := range s.selector(region, rule, ruleStores, used) {
+		count := store.GetRegionCount() + s.delta[store.GetID()]
+		score := PreferredScore(float64(count), store, rule.LabelConstraints)
+		if target == nil || score < targetScore {
+			target, targetScore = store, score
+		}
+	}
+	return target
+}
+
+func (s *ruleSimulator) markUnsatisfiable(region *core.RegionInfo) {
+	s.res.UnsatisfiableCount++
+	if len(s.res.UnsatisfiableRegions) < maxUnsatisfiableSamples {
+		s.res.UnsatisfiableRegions = append(s.res.UnsatisfiableRegions, region.GetID())
+	}
+}
+
+func (s *ruleSimulator) getStore(storeID uint64) *StoreSimulation {
+	st, ok := s.storeResults[storeID]
+	if !ok {
+		st = &StoreSimulation{StoreID: storeID}
+		s.storeResults[storeID] = st
+	}
+	return st
+}
+
+func (s *ruleSimulator) result() *SimulationResult {
+	s.res.Stores = make([]*StoreSimulation, 0, len(s.storeResults))
+	for _, st := range s.storeResults {
+		s.res.Stores = append(s.res.Stores, st)
+	}
+	sort.Slice(s.res.Stores, func(i, j int) bool {
+		return s.res.Stores[i].StoreID < s.res.Stores[j].StoreID
+	})
+	return s.res
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+)
+
+func TestSimulateGroupBundles(t *testing.T) {
+	re := require.New(t)
+	_, manager := newTestManager(t, false)
+	stores := core.NewStoresInfo()
+	for id := uint64(1); id <= 4; id++ {
+		stores.PutStore(core.NewStoreInfoWithLabel(id, map[string]string{"zone": fmt.Sprintf("z%d", id)}))
+	}
+	newRegion := func(id uint64, start, end string, size int64) *core.RegionInfo {
+		meta := &metapb.Region{Id: id, StartKey: []byte(start), EndKey: []byte(end)}
+		for storeID := uint64(1); storeID <= 3; storeID++ {
+			meta.Peers = append(meta.Peers, &metapb.Peer{Id: id*10 + storeID, StoreId: storeID})
+		}
+		return core.NewRegionInfo(meta, meta.Peers[0], core.SetApproximateSize(size))
+	}
+	regions := []*core.RegionInfo{newRegion(1, "", "a", 10), newRegion(2, "a", "", 20)}
+	selector := func(_ *core.RegionInfo, rule *Rule, _ []*core.StoreInfo, excluded map[uint64]struct{}) []*core.StoreInfo {
+		var candidates []*core.StoreInfo
+		for _, store := range stores.GetStores() {
+			if _, ok := excluded[store.GetID()]; !ok && MatchLabelConstraints(store, rule.LabelConstraints) {
+				candidates = append(candidates, store)
+			}
+		}
+		return candidates
+	}
+	bundle := func(count int) []GroupBundle {
+		return []GroupBundle{{
+			ID: "sim",
+			Rules: []*Rule{
+				{ID: "r1", Role: Voter, Count: 3, EndKeyHex: "61"},
+				{ID: "r2", Role: Voter, Count: count, StartKeyHex: "61", LabelConstraints: []LabelConstraint{
+					{Key: "zone", Op: In, Values: []string{"z1", "z4"}},
+				}},
+			},
+		}}
+	}
+
+	// Only 2 stores match the constraints of r2.
+	res, err := manager.SimulateGroupBundles(stores, regions, bundle(3), true, selector)
+	re.NoError(err)
+	re.Equal(2, res.RegionCount)
+	re.Equal(1, res.ChangedCount)
+	re.Equal(1, res.UnsatisfiableCount)
+	re.Equal([]uint64{2}, res.UnsatisfiableRegions)
+	re.Equal(1, res.AddPeers)
+	re.Equal(2, res.RemovePeers)
+	re.Equal(int64(20*units.MiB), res.MoveBytes)
+
+	res, err = manager.SimulateGroupBundles(stores, regions, bundle(2), true, selector)
+	re.NoError(err)
+	re.Equal(1, res.ChangedCount)
+	re.Zero(res.UnsatisfiableCount)
+	re.Equal([]*StoreSimulation{
+		{StoreID: 2, RemovePeers: 1, RemoveBytes: 20 * units.MiB},
+		{StoreID: 3, RemovePeers: 1, RemoveBytes: 20 * units.MiB},
+		{StoreID: 4, AddPeers: 1, AddBytes: 20 * units.MiB},
+	}, res.Stores)
+
+	// Without overriding, the default rule is kept and more replicas are needed.
+	res, err = manager.SimulateGroupBundles(stores, regions, bundle(2), false, selector)
+	re.NoError(err)
+	re.Equal(2, res.ChangedCount)
+	re.Equal(2, res.AddPeers)
+
+	// An invalid rule set is rejected.
+	_, err = manager.SimulateGroupBundles(stores, regions, []GroupBundle{{ID: "sim", Rules: []*Rule{{ID: "r", Role: Voter, Count: 1, StartKeyHex: "xx"}}}}, true, selector)
+	re.Error(err)
+
+	// The rules in use are not changed.
+	rules := manager.GetAllRules()
+	re.Len(rules, 1)
+	re.Equal(DefaultRuleID, rules[0].ID)
+	re.Len(manager.GetRulesForApplyRegion(regions[1]), 1)
+}
@@ -0,0 +1,464 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/plan"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/utils/keyutil"
+	"github.com/tikv/pd/pkg/utils/reflectutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+const defaultBalanceCostToleranceRatio = 0.05
+
+// The dimensions which make up the cost of a store.
+const (
+	costDimRegionSize = iota
+	costDimWriteBytes
+	costDimCPU
+	costDimIO
+	costDimCount
+)
+
+type balanceCostSchedulerParam struct {
+	Ranges []keyutil.KeyRange `json:"ranges"`
+	// The weights of the dimensions which make up the cost of a store. Each
+	// dimension is normalized by its average among the stores, so the weights
+	// are comparable with each other.
+	RegionSizeWeight float64 `json:"region-size-weight"`
+	WriteBytesWeight float64 `json:"write-bytes-weight"`
+	CPUWeight        float64 `json:"cpu-weight"`
+	IOWeight         float64 `json:"io-weight"`
+	// ToleranceRatio is the minimum cost difference between the source and
+	// target store to balance, the average cost of the stores is 1.
+	ToleranceRatio float64 `json:"tolerance-ratio"`
+}
+
+func (p *balanceCostSchedulerParam) weights() [costDimCount]float64 {
+	return [costDimCount]float64{p.RegionSizeWeight, p.WriteBytesWeight, p.CPUWeight, p.IOWeight}
+}
+
+func (p *balanceCostSchedulerParam) validate() bool {
+	var sum float64
+	for _, w := range p.weights() {
+		if w < 0 {
+			return false
+		}
+		sum += w
+	}
+	return sum > 0 && p.ToleranceRatio >= 0 && p.ToleranceRatio <= 1
+}
+
+type balanceCostSchedulerConfig struct {
+	syncutil.RWMutex
+	schedulerConfig
+	balanceCostSchedulerParam
+}
+
+func (conf *balanceCostSchedulerConfig) setDefaultWeights() {
+	conf.RegionSizeWeight = 1
+	conf.WriteBytesWeight = 1
+	conf.CPUWeight = 1
+	conf.IOWeight = 1
+	conf.ToleranceRatio = defaultBalanceCostToleranceRatio
+}
+
+func (conf *balanceCostSchedulerConfig) update(data []byte) (int, any) {
+	conf.Lock()
+	defer conf.Unlock()
+
+	param := &conf.balanceCostSchedulerParam
+	oldConfig, _ := json.Marshal(param)
+
+	if err := json.Unmarshal(data, param); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	newConfig, _ := json.Marshal(param)
+	if !bytes.Equal(oldConfig, newConfig) {
+		if !param.validate() {
+			if err := json.Unmarshal(oldConfig, param); err != nil {
+				return http.StatusInternalServerError, err.Error()
+			}
+			return http.StatusBadRequest, "invalid config, the weights should be non-negative and not all zero, and the tolerance ratio should be between 0 and 1"
+		}
+		if err := conf.save(); err != nil {
+			log.Warn("failed to save balance-cost-scheduler config", errs.ZapError(err))
+		}
+		log.Info("balance-cost-scheduler config is updated", zap.ByteString("old", oldConfig), zap.ByteString("new", newConfig))
+		return http.StatusOK, "Config is updated."
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	ok := reflectutil.FindSameFieldByJSON(param, m)
+	if ok {
+		return http.StatusOK, "Config is the same with origin, so do nothing."
+	}
+	return http.StatusBadRequest, "Config item is not found."
+}
+
+func (conf *balanceCostSchedulerConfig) clone() *balanceCostSchedulerParam {
+	conf.RLock()
+	defer conf.RUnlock()
+	param := conf.balanceCostSchedulerParam
+	param.Ranges = make([]keyutil.KeyRange, len(conf.Ranges))
+	copy(param.Ranges, conf.Ranges)
+	return &param
+}
+
+func (conf *balanceCostSchedulerConfig) encodeConfig() ([]byte, error) {
+	conf.RLock()
+	defer conf.RUnlock()
+	return EncodeConfig(conf)
+}
+
+type balanceCostHandler struct {
+	rd     *render.Render
+	config *balanceCostSchedulerConfig
+}
+
+func newBalanceCostHandler(conf *balanceCostSchedulerConfig) http.Handler {
+	handler := &balanceCostHandler{
+		config: conf,
+		rd:     render.New(render.Options{IndentJSON: true}),
+	}
+	router := mux.NewRouter()
+	router.HandleFunc("/config", handler.updateConfig).Methods(http.MethodPost)
+	router.HandleFunc("/list", handler.listConfig).Methods(http.MethodGet)
+	return router
+}
+
+func (handler *balanceCostHandler) updateConfig(w http.ResponseWriter, r *http.Request) {
+	data, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	httpCode, v := handler.config.update(data)
+	handler.rd.JSON(w, httpCode, v)
+}
+
+func (handler *balanceCostHandler) listConfig(w http.ResponseWriter, _ *http.Request) {
+	handler.rd.JSON(w, http.StatusOK, handler.config.clone())
+}
+
+type balanceCostScheduler struct {
+	*BaseScheduler
+	*retryQuota
+	conf          *balanceCostSchedulerConfig
+	handler       http.Handler
+	filters       []filter.Filter
+	filterCounter *filter.Counter
+}
+
+// newBalanceCostScheduler creates a scheduler that tends to keep the cost of
+// each store balanced, the cost is a weighted combination of the region size,
+// the written bytes, the CPU usage and the IO pressure of the store.
+func newBalanceCostScheduler(opController *operator.Controller, conf *balanceCostSchedulerConfig) Scheduler {
+	base := NewBaseScheduler(opController, types.BalanceCostScheduler, conf)
+	return &balanceCostScheduler{
+		BaseScheduler: base,
+		retryQuota:    newRetryQuota(),
+		conf:          conf,
+		handler:       newBalanceCostHandler(conf),
+		filters: []filter.Filter{
+			&filter.StoreStateFilter{ActionScope: base.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
+			filter.NewSpecialUseFilter(base.GetName()),
+		},
+		filterCounter: filter.NewCounter(base.GetName()),
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *balanceCostScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// EncodeConfig implements the Scheduler interface.
+func (s *balanceCostScheduler) EncodeConfig() ([]byte, error) {
+	return s.conf.encodeConfig()
+}
+
+// ReloadConfig implements the Scheduler interface.
+func (s *balanceCostScheduler) ReloadConfig() error {
+	s.conf.Lock()
+	defer s.conf.Unlock()
+	newCfg := &balanceCostSchedulerConfig{}
+	if err := s.conf.load(newCfg); err != nil {
+		return err
+	}
+	s.conf.balanceCostSchedulerParam = newCfg.balanceCostSchedulerParam
+	return nil
+}
+
+// IsScheduleAllowed implements the Scheduler interface.
+func (s *balanceCostScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpRegion) < cluster.GetSchedulerConfig().GetRegionScheduleLimit()
+	if !allowed {
+		operator.IncOperatorLimitCounter(s.GetType(), operator.OpRegion)
+	}
+	return allowed
+}
+
+// costModel calculates the cost of the stores. The value of each dimension
+// is divided by its average among the stores, and the cost is the weighted
+// average of them, so the average cost of the stores is 1.
+//
+// The CPU usage and the IO pressure are reported per store, they are assumed
+// to be proportional to the region size of the store, so that the pending
+// operators and the regions to move are also reflected in these dimensions.
+// Otherwise, the regions would be moved out of a CPU-hot store endlessly.
+type costModel struct {
+	weights   [costDimCount]float64
+	totalW    float64
+	averages  [costDimCount]float64
+	storeDims map[uint64][costDimCount]float64
+}
+
+func newCostModel(param *balanceCostSchedulerParam, stores []*core.StoreInfo,
+	loads map[uint64]statistics.StoreKindLoads, influence func(uint64) int64) *costModel {
+	m := &costModel{
+		weights:   param.weights(),
+		storeDims: make(map[uint64][costDimCount]float64, len(stores)),
+	}
+	for _, w := range m.weights {
+		m.totalW += w
+	}
+	for _, store := range stores {
+		id := store.GetID()
+		var dims [costDimCount]float64
+		if load, ok := loads[id]; ok {
+			dims[costDimWriteBytes] = load[utils.StoreWriteBytes]
+			dims[costDimCPU] = load[utils.StoreCPUUsage]
+		}
+		// The slow score is calculated by TiKV according to the latency of
+		// the disk IO, it is 1 for a healthy store and 100 at most.
+		dims[costDimIO] = float64(store.GetSlowScore())
+		size := float64(store.GetRegionSize())
+		dims[costDimRegionSize] = math.Max(size+float64(influence(id)), 0)
+		if size > 0 {
+			ratio := dims[costDimRegionSize] / size
+			dims[costDimWriteBytes] *= ratio
+			dims[costDimCPU] *= ratio
+			dims[costDimIO] *= ratio
+		}
+		m.storeDims[id] = dims
+		for i := range dims {
+			m.averages[i] += dims[i]
+		}
+	}
+	if len(stores) > 0 {
+		for i := range m.averages {
+			m.averages[i] /= float64(len(stores))
+		}
+	}
+	return m
+}
+
+func (m *costModel) cost(dims [costDimCount]float64) float64 {
+	if m.totalW == 0 {
+		return 0
+	}
+	var cost float64
+	for i := range dims {
+		if m.averages[i] > 0 {
+			cost += m.weights[i] * dims[i] / m.averages[i]
+		}
+	}
+	return cost / m.totalW
+}
+
+func (m *costModel) storeCost(storeID uint64) float64 {
+	return m.cost(m.storeDims[storeID])
+}
+
+// regionCost returns the cost that moving the region brings to a store. The share
+// of the CPU usage and the IO pressure of the source store is in proportion to the
+// region size.
+func (m *costModel) regionCost(region *core.RegionInfo, sourceID uint64) float64 {
+	var dims [costDimCount]float64
+	dims[costDimRegionSize] = float64(region.GetApproximateSize())
+	dims[costDimWriteBytes], _ = region.GetWriteRate()
+	source := m.storeDims[sourceID]
+	if source[costDimRegionSize] > 0 {
+		ratio := math.Min(dims[costDimRegionSize]/source[costDimRegionSize], 1)
+		dims[costDimCPU] = source[costDimCPU] * ratio
+		dims[costDimIO] = source[costDimIO] * ratio
+	}
+	return m.cost(dims)
+}
+
+// shouldBalance returns whether to move the region from the source store to the target store.
+// The difference of the cost should exceed the tolerance, and the source store should still
+// not be cheaper than the target store after moving the region, otherwise the region may be
+// moved back.
+func (m *costModel) shouldBalance(solver *solver, toleranceRatio float64) bool {
+	diff := solver.sourceScore - solver.targetScore
+	return diff > toleranceRatio && diff >= 2*m.regionCost(solver.Region, solver.sourceStoreID())
+}
+
+// Schedule implements the Scheduler interface.
+func (s *balanceCostScheduler) Schedule(cluster sche.SchedulerCluster, dryRun bool) ([]*operator.Operator, []plan.Plan) {
+	basePlan := plan.NewBalanceSchedulerPlan()
+	defer s.filterCounter.Flush()
+	var collector *plan.Collector
+	if dryRun {
+		collector = plan.NewCollector(basePlan)
+	}
+	balanceCostScheduleCounter.Inc()
+	stores := cluster.GetStores()
+	conf := cluster.GetSchedulerConfig()
+	param := s.conf.clone()
+	snapshotFilter := filter.NewSnapshotSendFilter(stores, constant.Medium)
+	faultTargets := filter.SelectUnavailableTargetStores(stores, s.filters, conf, collector, s.filterCounter)
+	sourceStores := filter.SelectSourceStores(stores, s.filters, conf, collector, s.filterCounter)
+	opInfluence := s.OpController.GetOpInfluence(cluster.GetBasicCluster())
+	s.OpController.GetFastOpInfluence(cluster.GetBasicCluster(), opInfluence)
+	kind := constant.NewScheduleKind(constant.RegionKind, constant.BySize)
+	solver := newSolver(basePlan, kind, cluster, opInfluence)
+
+	model := newCostModel(param, sourceStores, cluster.GetStoresLoads(), solver.getOpInfluence)
+	sort.Slice(sourceStores, func(i, j int) bool {
+		return model.storeCost(sourceStores[i].GetID()) > model.storeCost(sourceStores[j].GetID())
+	})
+
+	pendingFilter := filter.NewRegionPendingFilter()
+	downFilter := filter.NewRegionDownFilter()
+	replicaFilter := filter.NewRegionReplicatedFilter(cluster)
+	baseRegionFilters := []filter.RegionFilter{downFilter, replicaFilter, snapshotFilter, filter.NewRegionEmptyFilter(cluster)}
+
+	if collector != nil && len(sourceStores) > 0 {
+		collector.Collect(plan.SetResource(sourceStores[0]), plan.SetStatus(plan.NewStatus(plan.StatusStoreScoreDisallowed)))
+	}
+
+	solver.Step++
+	var sourceIndex int
+	// sourceStores is sorted by cost desc, so we pick the first store as source store.
+	for sourceIndex, solver.Source = range sourceStores {
+		retryLimit := s.getLimit(solver.Source)
+		solver.sourceScore = model.storeCost(solver.sourceStoreID())
+		if sourceIndex == len(sourceStores)-1 {
+			break
+		}
+		for range retryLimit {
+			solver.Region = selectRegionToMove(cluster, solver, collector, param.Ranges, baseRegionFilters, pendingFilter)
+			if solver.Region == nil {
+				balanceCostNoRegionCounter.Inc()
+				continue
+			}
+			log.Debug("select region", zap.String("scheduler", s.GetName()), zap.Uint64("region-id", solver.Region.GetID()))
+			// Skip hot regions.
+			if cluster.IsRegionHot(solver.Region) {
+				if collector != nil {
+					collector.Collect(plan.SetResource(solver.Region), plan.SetStatus(plan.NewStatus(plan.StatusRegionHot)))
+				}
+				balanceCostHotCounter.Inc()
+				continue
+			}
+			if solver.Region.GetLeader() == nil {
+				log.Warn("region have no leader", zap.String("scheduler", s.GetName()), zap.Uint64("region-id", solver.Region.GetID()))
+				if collector != nil {
+					collector.Collect(plan.SetResource(solver.Region), plan.SetStatus(plan.NewStatus(plan.StatusRegionNoLeader)))
+				}
+				balanceCostNoLeaderCounter.Inc()
+				continue
+			}
+			solver.Step++
+			solver.fit = replicaFilter.(*filter.RegionReplicatedFilter).GetFit()
+			if op := s.transferPeer(solver, model, param.ToleranceRatio, collector, sourceStores[sourceIndex+1:], faultTargets); op != nil {
+				s.resetLimit(solver.Source)
+				op.Counters = append(op.Counters, balanceCostNewOpCounter)
+				return []*operator.Operator{op}, collector.GetPlans()
+			}
+			solver.Step--
+		}
+		s.attenuate(solver.Source)
+	}
+	s.gc(stores)
+	return nil, collector.GetPlans()
+}
+
+// transferPeer selects the store with the lowest cost to create a new peer to replace the old peer.
+func (s *balanceCostScheduler) transferPeer(solver *solver, model *costModel, toleranceRatio float64,
+	collector *plan.Collector, dstStores []*core.StoreInfo, faultStores []*core.StoreInfo) *operator.Operator {
+	candidates := s.selectTransferPeerTargets(solver, collector, s.filterCounter, dstStores, faultStores)
+	if len(candidates.Stores) != 0 {
+		solver.Step++
+	}
+
+	// candidates are sorted by cost desc, so we pick the last store as target store.
+	for i := range candidates.Stores {
+		solver.Target = candidates.Stores[len(candidates.Stores)-i-1]
+		solver.targetScore = model.storeCost(solver.targetStoreID())
+		if !model.shouldBalance(solver, toleranceRatio) {
+			balanceCostSkipCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusStoreScoreDisallowed)))
+			}
+			continue
+		}
+
+		oldPeer := solver.Region.GetStorePeer(solver.sourceStoreID())
+		newPeer := &metapb.Peer{StoreId: solver.targetStoreID(), Role: oldPeer.Role}
+		solver.Step++
+		op, err := operator.CreateMovePeerOperator(s.GetName(), solver, solver.Region, operator.OpRegion, oldPeer.GetStoreId(), newPeer)
+		if err != nil {
+			balanceCostCreateOpFailCounter.Inc()
+			if collector != nil {
+				collector.Collect(plan.SetStatus(plan.NewStatus(plan.StatusCreateOperatorFailed)))
+			}
+			return nil
+		}
+		if collector != nil {
+			collector.Collect()
+		}
+		solver.Step--
+		op.FinishedCounters = append(op.FinishedCounters,
+			balanceDirectionCounter.WithLabelValues(s.GetName(), solver.sourceMetricLabel(), "out"),
+			balanceDirectionCounter.WithLabelValues(s.GetName(), solver.targetMetricLabel(), "in"),
+		)
+		op.SetAdditionalInfo("sourceCost", strconv.FormatFloat(solver.sourceScore, 'f', 2, 64))
+		op.SetAdditionalInfo("targetCost", strconv.FormatFloat(solver.targetScore, 'f', 2, 64))
+		return op
+	}
+
+	balanceCostNoReplacementCounter.Inc()
+	if len(candidates.Stores) != 0 {
+		solver.Step--
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core/storelimit"
+	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/operatorutil"
+	"github.com/tikv/pd/pkg/versioninfo"
+)
+
+func TestBalanceCostSchedule(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetEnablePlacementRules(false)
+	tc.SetMaxReplicasWithLabel(false, 1)
+	sb, err := CreateScheduler(types.BalanceCostScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceCostScheduler, []string{"", ""}))
+	re.NoError(err)
+	updateConfig := func(data string) int {
+		req, err := http.NewRequest(http.MethodPost, "/config", bytes.NewBufferString(data))
+		re.NoError(err)
+		resp := httptest.NewRecorder()
+		sb.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	// The region sizes are balanced, but the CPU usage of store 4 is high.
+	for id, cpu := range map[uint64]uint64{1: 10, 2: 20, 3: 30, 4: 100} {
+		tc.AddRegionStore(id, 10)
+		tc.UpdateStorageCPUUsage(id, cpu)
+	}
+	tc.AddLeaderRegion(1, 4)
+	ops, _ := sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferPeerWithLeaderTransfer(re, ops[0], operator.OpKind(0), 4, 1)
+
+	// Ignore the CPU usage.
+	re.Equal(http.StatusOK, updateConfig(`{"cpu-weight": 0}`))
+	ops, plans := sb.Schedule(tc, true)
+	re.Empty(ops)
+	re.NotEmpty(plans)
+
+	// Store 4 is slow.
+	for id := uint64(1); id <= 4; id++ {
+		tc.GetStore(id).GetStoreStats().SlowScore = 1
+	}
+	tc.GetStore(4).GetStoreStats().SlowScore = 100
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(uint64(4), ops[0].Step(ops[0].Len()-1).(operator.RemovePeer).FromStore)
+	re.Equal(http.StatusOK, updateConfig(`{"io-weight": 0}`))
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+
+	re.Equal(http.StatusBadRequest, updateConfig(`{"region-size-weight": -1}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"region-size-weight": 0, "write-bytes-weight": 0}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"tolerance-ratio": 2}`))
+	re.Equal(http.StatusBadRequest, updateConfig(`{"unknown": 1}`))
+	conf := sb.(*balanceCostScheduler).conf.clone()
+	re.Equal(1.0, conf.RegionSizeWeight)
+	re.Zero(conf.CPUWeight)
+	re.Equal(defaultBalanceCostToleranceRatio, conf.ToleranceRatio)
+}
+
+func TestBalanceCostConverge(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(false)
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetEnablePlacementRules(false)
+	tc.SetMaxReplicasWithLabel(false, 1)
+	sb, err := CreateScheduler(types.BalanceCostScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceCostScheduler, []string{"", ""}))
+	re.NoError(err)
+
+	// The region sizes are balanced, but the CPU usage of store 4 is high.
+	for id, cpu := range map[uint64]uint64{1: 10, 2: 20, 3: 30, 4: 100} {
+		tc.AddRegionStore(id, 10)
+		tc.UpdateStorageCPUUsage(id, cpu)
+	}
+	tc.SetAllStoresLimit(storelimit.AddPeer, 600)
+	tc.SetAllStoresLimit(storelimit.RemovePeer, 600)
+	for id := uint64(1); id <= 10; id++ {
+		tc.AddLeaderRegion(id, 4)
+	}
+	// The CPU usage is not changed by the operators, but the scheduler should stop
+	// before all the regions are moved out of store 4.
+	count := 0
+	converged := false
+	for range 100 {
+		ops, _ := sb.Schedule(tc, false)
+		if len(ops) == 0 {
+			converged = true
+			break
+		}
+		// The operator may fail to add if the region already has one.
+		count += oc.AddWaitingOperator(ops...)
+	}
+	re.True(converged)
+	re.Positive(count)
+	re.Less(count, 10)
+}
@@ -460,6 +460,9 @@ func (s *balanceLeaderScheduler) transferLeaderOut(solver *solver, collector *pl
 	if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, solver.GetBasicCluster(), solver.GetRuleManager(), solver.Region, solver.Source, false /*allowMoveLeader*/); leaderFilter != nil {
 		finalFilters = append(s.filters, leaderFilter)
 	}
+	if zoneFilter := newLeaderZoneFilter(s.GetName(), solver, solver.Region); zoneFilter != nil {
+		finalFilters = append(finalFilters, zoneFilter)
+	}
 	targets = filter.SelectTargetStores(targets, finalFilters, conf, collector, s.filterCounter)
 	leaderSchedulePolicy := conf.GetLeaderSchedulePolicy()
 	sort.Slice(targets, func(i, j int) bool {
@@ -525,6 +528,9 @@ func (s *balanceLeaderScheduler) transferLeaderIn(solver *solver, collector *pla
 	if leaderFilter := filter.NewPlacementLeaderSafeguard(s.GetName(), conf, solver.GetBasicCluster(), solver.GetRuleManager(), solver.Region, solver.Source, false /*allowMoveLeader*/); leaderFilter != nil {
 		finalFilters = append(s.filters, leaderFilter)
 	}
+	if zoneFilter := newLeaderZoneFilter(s.GetName(), solver, solver.Region); zoneFilter != nil {
+		finalFilters = append(finalFilters, zoneFilter)
+	}
 	target := filter.NewCandidates(s.R, []*core.StoreInfo{solver.Target}).
 		FilterTarget(conf, nil, s.filterCounter, finalFilters...).
 		PickFirst()
@@ -32,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -298,6 +299,37 @@ func (suite *balanceLeaderSchedulerTestSuite) TestBalanceFilter() {
 	re.Empty(suite.schedule())
 }
 
+func (suite *balanceLeaderSchedulerTestSuite) TestLeaderZone() {
+	re := suite.Require()
+	// Stores:     1    2    3
+	// Leaders:   16    1    3
+	// Zone:      z1   z2   z1
+	// Region1:    L    F    F
+	for id, count := range map[uint64]int{1: 16, 2: 1, 3: 3} {
+		suite.tc.AddLeaderStore(id, count)
+	}
+	suite.tc.SetStoreLabel(1, map[string]string{"zone": "z1", "dc": "d1"})
+	suite.tc.SetStoreLabel(2, map[string]string{"zone": "z2", "dc": "d1"})
+	suite.tc.SetStoreLabel(3, map[string]string{"zone": "z1", "dc": "d2"})
+	suite.tc.AddLeaderRegion(1, 1, 2, 3)
+	operatorutil.CheckTransferLeader(re, suite.schedule()[0], operator.OpKind(0), 1, 2)
+
+	// The leader is kept in the zone of the label policy.
+	re.NoError(suite.tc.GetRegionLabeler().SetLabelRule(&labeler.LabelRule{
+		ID:       "leader-zone",
+		Labels:   []labeler.RegionLabel{{Key: labeler.LeaderZoneLabel, Value: "z1"}},
+		RuleType: labeler.KeyRange,
+		Data:     labeler.MakeKeyRanges("", ""),
+	}))
+	operatorutil.CheckTransferLeader(re, suite.schedule()[0], operator.OpKind(0), 1, 3)
+
+	// The store label key of the zone is configurable.
+	cfg := suite.tc.GetScheduleConfig().Clone()
+	cfg.LeaderZoneLabel = "dc"
+	suite.tc.SetScheduleConfig(cfg)
+	re.Empty(suite.schedule())
+}
+
 func (suite *balanceLeaderSchedulerTestSuite) TestLeaderWeight() {
 	re := suite.Require()
 	// Stores:     1       2       3       4
@@ -33,6 +33,9 @@ import (
 	"github.com/tikv/pd/pkg/utils/keyutil"
 )
 
+// engineInfoKey is the additional info key of the operators to record the engine of the source store.
+const engineInfoKey = "engine"
+
 type balanceRegionSchedulerConfig struct {
 	baseDefaultSchedulerConfig
 
@@ -64,6 +67,7 @@ func newBalanceRegionScheduler(opController *operator.Controller, conf *balanceR
 	scheduler.filters = []filter.Filter{
 		&filter.StoreStateFilter{ActionScope: scheduler.GetName(), MoveRegion: true, OperatorLevel: constant.Medium},
 		filter.NewSpecialUseFilter(scheduler.GetName()),
+		filter.NewEngineFilter(scheduler.GetName(), filter.NotComputeEngines),
 	}
 	scheduler.filterCounter = filter.NewCounter(scheduler.GetName())
 	return scheduler
@@ -136,6 +140,7 @@ func (s *balanceRegionScheduler) Schedule(cluster sche.SchedulerCluster, dryRun
 
 	solver.Step++
 	var sourceIndex int
+	tiflashAllowed := s.isTiFlashScheduleAllowed(cluster)
 
 	rs := s.conf.Ranges
 	if s.GetName() == types.BalanceRegionScheduler.String() {
@@ -151,26 +156,12 @@ func (s *balanceRegionScheduler) Schedule(cluster sche.SchedulerCluster, dryRun
 		if sourceIndex == len(sourceStores)-1 {
 			break
 		}
+		if !tiflashAllowed && solver.Source.IsTiFlash() {
+			balanceRegionTiFlashLimitCounter.Inc()
+			continue
+		}
 		for range retryLimit {
-			// Priority pick the region that has a pending peer.
-			// Pending region may mean the disk is overload, remove the pending region firstly.
-			solver.Region = filter.SelectOneRegion(cluster.RandPendingRegions(solver.sourceStoreID(), rs), collector,
-				append(baseRegionFilters, filter.NewRegionWitnessFilter(solver.sourceStoreID()))...)
-			if solver.Region == nil {
-				// Then pick the region that has a follower in the source store.
-				solver.Region = filter.SelectOneRegion(cluster.RandFollowerRegions(solver.sourceStoreID(), rs), collector,
-					append(baseRegionFilters, filter.NewRegionWitnessFilter(solver.sourceStoreID()), pendingFilter)...)
-			}
-			if solver.Region == nil {
-				// Then pick the region has the leader in the source store.
-				solver.Region = filter.SelectOneRegion(cluster.RandLeaderRegions(solver.sourceStoreID(), rs), collector,
-					append(baseRegionFilters, filter.NewRegionWitnessFilter(solver.sourceStoreID()), pendingFilter)...)
-			}
-			if solver.Region == nil {
-				// Finally, pick learner.
-				solver.Region = filter.SelectOneRegion(cluster.RandLearnerRegions(solver.sourceStoreID(), rs), collector,
-					append(baseRegionFilters, filter.NewRegionWitnessFilter(solver.sourceStoreID()), pendingFilter)...)
-			}
+			solver.Region = selectRegionToMove(cluster, solver, collector, rs, baseRegionFilters, pendingFilter)
 			if solver.Region == nil {
 				balanceRegionNoRegionCounter.Inc()
 				continue
@@ -211,8 +202,52 @@ func (s *balanceRegionScheduler) Schedule(cluster sche.SchedulerCluster, dryRun
 	return nil, collector.GetPlans()
 }
 
-// transferPeer selects the best store to create a new peer to replace the old peer.
-func (s *balanceRegionScheduler) transferPeer(solver *solver, collector *plan.Collector, dstStores []*core.StoreInfo, faultStores []*core.StoreInfo) *operator.Operator {
+// isTiFlashScheduleAllowed returns false if the operators moving the peers on the TiFlash
+// stores reach the `tiflash-region-schedule-limit`.
+func (s *balanceRegionScheduler) isTiFlashScheduleAllowed(cluster sche.SchedulerCluster) bool {
+	limit := cluster.GetSchedulerConfig().GetTiFlashRegionScheduleLimit()
+	if limit == 0 {
+		return true
+	}
+	var count uint64
+	for _, op := range s.OpController.GetOperatorsOfKind(operator.OpRegion) {
+		if op.Desc() == s.GetName() && op.GetAdditionalInfo(engineInfoKey) == core.EngineTiFlash {
+			count++
+		}
+	}
+	return count < limit
+}
+
+// selectRegionToMove selects a region which has a peer in the source store of the solver to move.
+func selectRegionToMove(cluster sche.SchedulerCluster, solver *solver, collector *plan.Collector, ranges []keyutil.KeyRange,
+	baseRegionFilters []filter.RegionFilter, pendingFilter filter.RegionFilter) *core.RegionInfo {
+	sourceID := solver.sourceStoreID()
+	// Priority pick the region that has a pending peer.
+	// Pending region may mean the disk is overload, remove the pending region firstly.
+	region := filter.SelectOneRegion(cluster.RandPendingRegions(sourceID, ranges), collector,
+		append(baseRegionFilters, filter.NewRegionWitnessFilter(sourceID))...)
+	if region == nil {
+		// Then pick the region that has a follower in the source store.
+		region = filter.SelectOneRegion(cluster.RandFollowerRegions(sourceID, ranges), collector,
+			append(baseRegionFilters, filter.NewRegionWitnessFilter(sourceID), pendingFilter)...)
+	}
+	if region == nil {
+		// Then pick the region has the leader in the source store.
+		region = filter.SelectOneRegion(cluster.RandLeaderRegions(sourceID, ranges), collector,
+			append(baseRegionFilters, filter.NewRegionWitnessFilter(sourceID), pendingFilter)...)
+	}
+	if region == nil {
+		// Finally, pick learner.
+		region = filter.SelectOneRegion(cluster.RandLearnerRegions(sourceID, ranges), collector,
+			append(baseRegionFilters, filter.NewRegionWitnessFilter(sourceID), pendingFilter)...)
+	}
+	return region
+}
+
+// selectTransferPeerTargets selects the stores which are able to place the new peer
+// to replace the peer of the region in the source store of the solver.
+func (s *BaseScheduler) selectTransferPeerTargets(solver *solver, collector *plan.Collector, counter *filter.Counter,
+	dstStores []*core.StoreInfo, faultStores []*core.StoreInfo) *filter.StoreCandidates {
 	excludeTargets := solver.Region.GetStoreIDs()
 	for _, store := range faultStores {
 		excludeTargets[store.GetID()] = struct{}{}
@@ -225,7 +260,12 @@ func (s *balanceRegionScheduler) transferPeer(solver *solver, collector *plan.Co
 		filter.NewPlacementSafeguard(s.GetName(), conf, solver.GetBasicCluster(), solver.GetRuleManager(),
 			solver.Region, solver.Source, solver.fit),
 	}
-	candidates := filter.NewCandidates(s.R, dstStores).FilterTarget(conf, collector, s.filterCounter, filters...)
+	return filter.NewCandidates(s.R, dstStores).FilterTarget(conf, collector, counter, filters...)
+}
+
+// transferPeer selects the best store to create a new peer to replace the old peer.
+func (s *balanceRegionScheduler) transferPeer(solver *solver, collector *plan.Collector, dstStores []*core.StoreInfo, faultStores []*core.StoreInfo) *operator.Operator {
+	candidates := s.selectTransferPeerTargets(solver, collector, s.filterCounter, dstStores, faultStores)
 	if len(candidates.Stores) != 0 {
 		solver.Step++
 	}
@@ -268,6 +308,9 @@ func (s *balanceRegionScheduler) transferPeer(solver *solver, collector *plan.Co
 		)
 		op.SetAdditionalInfo("sourceScore", strconv.FormatFloat(solver.sourceScore, 'f', 2, 64))
 		op.SetAdditionalInfo("targetScore", strconv.FormatFloat(solver.targetScore, 'f', 2, 64))
+		if solver.Source.IsTiFlash() {
+			op.SetAdditionalInfo(engineInfoKey, core.EngineTiFlash)
+		}
 		return op
 	}
 
@@ -24,8 +24,10 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/core/storelimit"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
 	"github.com/tikv/pd/pkg/schedule/operator"
+	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/storage"
@@ -580,6 +582,51 @@ func checkBalanceRegionOpInfluence(re *require.Assertions, enablePlacementRules
 	operatorutil.CheckTransferPeerWithLeaderTransfer(re, op, operator.OpKind(0), 2, 1)
 }
 
+func TestBalanceRegionTiFlashLimit(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest(false)
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetEnablePlacementRules(true)
+	re.NoError(tc.RuleManager.SetRule(&placement.Rule{
+		GroupID: "tiflash",
+		ID:      "learner",
+		Role:    placement.Learner,
+		Count:   1,
+		LabelConstraints: []placement.LabelConstraint{
+			{Key: core.EngineKey, Op: placement.In, Values: []string{core.EngineTiFlash}},
+		},
+	}))
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	for i := uint64(1); i <= 3; i++ {
+		tc.AddLabelsStore(i, 4, map[string]string{})
+	}
+	tc.AddLabelsStore(4, 16, map[string]string{core.EngineKey: core.EngineTiFlash})
+	tc.AddLabelsStore(5, 0, map[string]string{core.EngineKey: core.EngineTiFlash})
+	tc.AddLabelsStore(6, 0, map[string]string{core.EngineKey: core.EngineTiFlashCompute})
+	for i := uint64(1); i <= 8; i++ {
+		tc.AddRegionWithLearner(i, 1, []uint64{2, 3}, []uint64{4})
+	}
+
+	tc.SetAllStoresLimit(storelimit.AddPeer, 600)
+	tc.SetAllStoresLimit(storelimit.RemovePeer, 600)
+	tc.SetTiFlashRegionScheduleLimit(1)
+	ops, _ := sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(uint64(5), ops[0].Step(0).(operator.AddLearner).ToStore)
+	re.Equal(core.EngineTiFlash, ops[0].GetAdditionalInfo(engineInfoKey))
+	re.Equal(1, oc.AddWaitingOperator(ops...))
+
+	// the TiFlash operators reach the dedicated limit.
+	ops, _ = sb.Schedule(tc, false)
+	re.Empty(ops)
+	tc.SetTiFlashRegionScheduleLimit(2)
+	ops, _ = sb.Schedule(tc, false)
+	re.Len(ops, 1)
+	re.Equal(uint64(5), ops[0].Step(0).(operator.AddLearner).ToStore)
+}
+
 func TestBalanceRegionReplacePendingRegion(t *testing.T) {
 	re := require.New(t)
 	checkReplacePendingRegion(re, false /* disable placement rules */)
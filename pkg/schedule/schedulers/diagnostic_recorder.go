@@ -37,8 +37,13 @@ const (
 	Paused = "paused"
 	// Halted means the current scheduler is halted
 	Halted = "halted"
+	// OutOfWindow means the current scheduler is out of its scheduling time windows
+	OutOfWindow = "out-of-window"
 	// Scheduling means the current scheduler is generating.
 	Scheduling = "scheduling"
+	// Denied means the operators of the region are dropped because it is labeled with `schedule=deny`.
+	// It is only used to explain a region.
+	Denied = "denied"
 	// Pending means the current scheduler cannot generate scheduling operator
 	Pending = "pending"
 	// Normal means that there is no need to create operators since everything is fine.
@@ -50,6 +55,7 @@ const (
 var DiagnosableSummaryFunc = map[types.CheckerSchedulerType]plan.Summary{
 	types.BalanceRegionScheduler: plan.BalancePlanSummary,
 	types.BalanceLeaderScheduler: plan.BalancePlanSummary,
+	types.BalanceCostScheduler:   plan.BalancePlanSummary,
 }
 
 // DiagnosticRecorder is used to manage diagnostic for one scheduler.
@@ -173,7 +179,7 @@ func (d *DiagnosticRecorder) analyze(ops []*operator.Operator, plans []plan.Plan
 	}
 	// TODO: support more schedulers and checkers
 	switch d.schedulerType {
-	case types.BalanceRegionScheduler, types.BalanceLeaderScheduler:
+	case types.BalanceRegionScheduler, types.BalanceLeaderScheduler, types.BalanceCostScheduler:
 		if len(ops) != 0 {
 			res.Status = Scheduling
 			return res
@@ -316,6 +316,11 @@ type evictLeaderStoresConf interface {
 	getBatch() int
 }
 
+// evictLeaderRegionFiltersConf is implemented by the configs which restrict the regions to evict leaders.
+type evictLeaderRegionFiltersConf interface {
+	getRegionFilters() []filter.RegionFilter
+}
+
 func scheduleEvictLeaderBatch(r *rand.Rand, name string, cluster sche.SchedulerCluster, conf evictLeaderStoresConf) []*operator.Operator {
 	var ops []*operator.Operator
 	batchSize := conf.getBatch()
@@ -337,6 +342,10 @@ func scheduleEvictLeaderBatch(r *rand.Rand, name string, cluster sche.SchedulerC
 func scheduleEvictLeaderOnce(r *rand.Rand, name string, cluster sche.SchedulerCluster, conf evictLeaderStoresConf) []*operator.Operator {
 	stores := conf.getStores()
 	ops := make([]*operator.Operator, 0, len(stores))
+	var regionFilters []filter.RegionFilter
+	if c, ok := conf.(evictLeaderRegionFiltersConf); ok {
+		regionFilters = c.getRegionFilters()
+	}
 	for _, storeID := range stores {
 		ranges := conf.getKeyRangesByID(storeID)
 		if len(ranges) == 0 {
@@ -345,10 +354,10 @@ func scheduleEvictLeaderOnce(r *rand.Rand, name string, cluster sche.SchedulerCl
 		var filters []filter.Filter
 		pendingFilter := filter.NewRegionPendingFilter()
 		downFilter := filter.NewRegionDownFilter()
-		region := filter.SelectOneRegion(cluster.RandLeaderRegions(storeID, ranges), nil, pendingFilter, downFilter)
+		region := filter.SelectOneRegion(cluster.RandLeaderRegions(storeID, ranges), nil, append([]filter.RegionFilter{pendingFilter, downFilter}, regionFilters...)...)
 		if region == nil {
 			// try to pick unhealthy region
-			region = filter.SelectOneRegion(cluster.RandLeaderRegions(storeID, ranges), nil)
+			region = filter.SelectOneRegion(cluster.RandLeaderRegions(storeID, ranges), nil, regionFilters...)
 			if region == nil {
 				evictLeaderNoLeaderCounter.Inc()
 				continue
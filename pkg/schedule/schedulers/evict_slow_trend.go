@@ -16,6 +16,8 @@ package schedulers
 
 import (
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
 	"time"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -44,6 +47,8 @@ const (
 	// We use 1800 seconds as the default gap for recovery, which is 30 minutes.
 	// This is based on the SLA level reflected by AWS EBS. And we can adjust it later if needed.
 	defaultRecoverySec = 1800 // default gap for recovery, unit: s.
+	// defaultMaxEvictedStores is the default max number of the stores evicted concurrently.
+	defaultMaxEvictedStores = 1
 )
 
 type slowCandidate struct {
@@ -55,8 +60,11 @@ type slowCandidate struct {
 type evictSlowTrendSchedulerParam struct {
 	// Duration gap for recovering the candidate, unit: s.
 	RecoverySec uint64 `json:"recovery-duration"`
-	// Only evict one store for now
+	// The stores whose leaders are being evicted.
 	EvictedStores []uint64 `json:"evict-by-trend-stores"`
+	// The max number of the stores to evict leaders concurrently. It's also limited
+	// by `max-replicas - 1`, so the evicted stores never hold all replicas of a region.
+	MaxEvictedStores uint64 `json:"max-evicted-stores"`
 }
 
 type evictSlowTrendSchedulerConfig struct {
@@ -69,6 +77,8 @@ type evictSlowTrendSchedulerConfig struct {
 	evictCandidate slowCandidate
 	// Last chosen candidate for eviction.
 	lastEvictCandidate slowCandidate
+	// Candidates of the evicted stores, which are used to track the recovery of each store.
+	evictedCandidates map[uint64]slowCandidate
 }
 
 func initEvictSlowTrendSchedulerConfig() *evictSlowTrendSchedulerConfig {
@@ -76,9 +86,11 @@ func initEvictSlowTrendSchedulerConfig() *evictSlowTrendSchedulerConfig {
 		schedulerConfig:    &baseSchedulerConfig{},
 		evictCandidate:     slowCandidate{},
 		lastEvictCandidate: slowCandidate{},
+		evictedCandidates:  make(map[uint64]slowCandidate),
 		evictSlowTrendSchedulerParam: evictSlowTrendSchedulerParam{
-			RecoverySec:   defaultRecoverySec,
-			EvictedStores: make([]uint64, 0),
+			RecoverySec:      defaultRecoverySec,
+			EvictedStores:    make([]uint64, 0),
+			MaxEvictedStores: defaultMaxEvictedStores,
 		},
 	}
 }
@@ -87,23 +99,52 @@ func (conf *evictSlowTrendSchedulerConfig) clone() *evictSlowTrendSchedulerParam
 	conf.RLock()
 	defer conf.RUnlock()
 	return &evictSlowTrendSchedulerParam{
-		RecoverySec: conf.RecoverySec,
+		RecoverySec:      conf.RecoverySec,
+		MaxEvictedStores: conf.MaxEvictedStores,
 	}
 }
 
 func (conf *evictSlowTrendSchedulerConfig) getStores() []uint64 {
 	conf.RLock()
 	defer conf.RUnlock()
-	return conf.EvictedStores
+	return slices.Clone(conf.EvictedStores)
+}
+
+func (conf *evictSlowTrendSchedulerConfig) isEvicted(id uint64) bool {
+	conf.RLock()
+	defer conf.RUnlock()
+	return slices.Contains(conf.EvictedStores, id)
 }
 
 func (conf *evictSlowTrendSchedulerConfig) getKeyRangesByID(id uint64) []keyutil.KeyRange {
-	if conf.evictedStore() != id {
+	if !conf.isEvicted(id) {
 		return nil
 	}
 	return []keyutil.KeyRange{keyutil.NewKeyRange("", "")}
 }
 
+// getRegionFilters returns the filters which skip the regions whose majority of
+// voters are on the evicted stores, the leaders of them have nowhere better to go.
+func (conf *evictSlowTrendSchedulerConfig) getRegionFilters() []filter.RegionFilter {
+	stores := conf.getStores()
+	if len(stores) <= 1 {
+		return nil
+	}
+	return []filter.RegionFilter{filter.NewRegionVoterMajorityFilter(stores...)}
+}
+
+// getMaxEvictedStores returns the max number of the stores to evict concurrently.
+func (conf *evictSlowTrendSchedulerConfig) getMaxEvictedStores(maxReplicas int) int {
+	conf.RLock()
+	defer conf.RUnlock()
+	limit := int(conf.MaxEvictedStores)
+	if limit == 0 {
+		// It's persisted by the old version which only evicts one store.
+		limit = defaultMaxEvictedStores
+	}
+	return max(min(limit, maxReplicas-1), 1)
+}
+
 func (*evictSlowTrendSchedulerConfig) getBatch() int {
 	return EvictLeaderBatchSize
 }
@@ -154,15 +195,22 @@ func (conf *evictSlowTrendSchedulerConfig) lastCandidateCapturedSecs() uint64 {
 	return DurationSinceAsSecs(conf.lastEvictCandidate.captureTS)
 }
 
-// readyForRecovery checks whether the last captured candidate is ready for recovery.
-func (conf *evictSlowTrendSchedulerConfig) readyForRecovery() bool {
+// readyForRecovery checks whether the evicted store is ready for recovery.
+func (conf *evictSlowTrendSchedulerConfig) readyForRecovery(id uint64) bool {
 	conf.RLock()
 	defer conf.RUnlock()
 	recoverySec := conf.RecoverySec
 	failpoint.Inject("transientRecoveryGap", func() {
 		recoverySec = 0
 	})
-	return conf.lastCandidateCapturedSecs() >= recoverySec
+	if cand, ok := conf.evictedCandidates[id]; ok {
+		return DurationSinceAsSecs(cand.captureTS) >= recoverySec
+	}
+	if conf.lastEvictCandidate.storeID == id {
+		return conf.lastCandidateCapturedSecs() >= recoverySec
+	}
+	// The store is evicted before the restart, there is no record of it.
+	return true
 }
 
 func (conf *evictSlowTrendSchedulerConfig) captureCandidate(id uint64) {
@@ -184,41 +232,50 @@ func (conf *evictSlowTrendSchedulerConfig) popCandidate(updLast bool) uint64 {
 	id := conf.evictCandidate.storeID
 	if updLast {
 		conf.lastEvictCandidate = conf.evictCandidate
+		conf.evictedCandidates[id] = conf.evictCandidate
 	}
 	conf.evictCandidate = slowCandidate{}
 	return id
 }
 
-func (conf *evictSlowTrendSchedulerConfig) markCandidateRecovered() {
+func (conf *evictSlowTrendSchedulerConfig) markCandidateRecovered(id uint64) {
 	conf.Lock()
 	defer conf.Unlock()
-	if conf.lastEvictCandidate != (slowCandidate{}) {
-		conf.lastEvictCandidate.recoverTS = time.Now()
+	now := time.Now()
+	if conf.lastEvictCandidate != (slowCandidate{}) && conf.lastEvictCandidate.storeID == id {
+		conf.lastEvictCandidate.recoverTS = now
 	}
+	delete(conf.evictedCandidates, id)
 }
 
-func (conf *evictSlowTrendSchedulerConfig) setStoreAndPersist(id uint64) error {
+func (conf *evictSlowTrendSchedulerConfig) addStoreAndPersist(id uint64) error {
 	conf.Lock()
 	defer conf.Unlock()
-	conf.EvictedStores = []uint64{id}
-	return conf.save()
+	if slices.Contains(conf.EvictedStores, id) {
+		return nil
+	}
+	conf.EvictedStores = append(slices.Clone(conf.EvictedStores), id)
+	if err := conf.save(); err != nil {
+		conf.EvictedStores = slices.DeleteFunc(conf.EvictedStores, func(evicted uint64) bool { return evicted == id })
+		return err
+	}
+	return nil
 }
 
-func (conf *evictSlowTrendSchedulerConfig) clearAndPersist(cluster sche.SchedulerCluster) (oldID uint64, err error) {
-	oldID = conf.evictedStore()
-	if oldID == 0 {
-		return
-	}
-	address := "?"
-	store := cluster.GetStore(oldID)
-	if store != nil {
-		address = store.GetAddress()
+func (conf *evictSlowTrendSchedulerConfig) removeAndPersist(cluster sche.SchedulerCluster, ids ...uint64) error {
+	for _, id := range ids {
+		address := "?"
+		if store := cluster.GetStore(id); store != nil {
+			address = store.GetAddress()
+		}
+		storeSlowTrendEvictedStatusGauge.WithLabelValues(address, strconv.FormatUint(id, 10)).Set(0)
 	}
-	storeSlowTrendEvictedStatusGauge.WithLabelValues(address, strconv.FormatUint(oldID, 10)).Set(0)
 	conf.Lock()
 	defer conf.Unlock()
-	conf.EvictedStores = []uint64{}
-	return oldID, conf.save()
+	conf.EvictedStores = slices.DeleteFunc(slices.Clone(conf.EvictedStores), func(evicted uint64) bool {
+		return slices.Contains(ids, evicted)
+	})
+	return conf.save()
 }
 
 type evictSlowTrendHandler struct {
@@ -242,22 +299,33 @@ func (handler *evictSlowTrendHandler) updateConfig(w http.ResponseWriter, r *htt
 	if err := apiutil.ReadJSONRespondError(handler.rd, w, r.Body, &input); err != nil {
 		return
 	}
-	recoveryDurationGapFloat, ok := input["recovery-duration"].(float64)
-	if !ok {
+	recoveryDurationGapFloat, hasRecoverySec := input["recovery-duration"].(float64)
+	maxEvictedStoresFloat, hasMaxEvictedStores := input["max-evicted-stores"].(float64)
+	if !hasRecoverySec && !hasMaxEvictedStores {
 		handler.rd.JSON(w, http.StatusInternalServerError, errors.New("invalid argument for 'recovery-duration'").Error())
 		return
 	}
+	if hasMaxEvictedStores && maxEvictedStoresFloat < 1 {
+		handler.rd.JSON(w, http.StatusBadRequest, errors.New("invalid argument for 'max-evicted-stores'").Error())
+		return
+	}
 	handler.config.Lock()
 	defer handler.config.Unlock()
-	prevRecoverySec := handler.config.RecoverySec
-	recoverySec := uint64(recoveryDurationGapFloat)
-	handler.config.RecoverySec = recoverySec
+	prevRecoverySec, prevMaxEvictedStores := handler.config.RecoverySec, handler.config.MaxEvictedStores
+	if hasRecoverySec {
+		handler.config.RecoverySec = uint64(recoveryDurationGapFloat)
+	}
+	if hasMaxEvictedStores {
+		handler.config.MaxEvictedStores = uint64(maxEvictedStoresFloat)
+	}
 	if err := handler.config.save(); err != nil {
 		handler.rd.JSON(w, http.StatusInternalServerError, err.Error())
-		handler.config.RecoverySec = prevRecoverySec
+		handler.config.RecoverySec, handler.config.MaxEvictedStores = prevRecoverySec, prevMaxEvictedStores
 		return
 	}
-	log.Info("evict-slow-trend-scheduler update 'recovery-duration' - unit: s", zap.Uint64("prev", prevRecoverySec), zap.Uint64("cur", recoverySec))
+	log.Info("evict-slow-trend-scheduler update config - unit of 'recovery-duration': s",
+		zap.Uint64("prev-recovery-duration", prevRecoverySec), zap.Uint64("cur-recovery-duration", handler.config.RecoverySec),
+		zap.Uint64("prev-max-evicted-stores", prevMaxEvictedStores), zap.Uint64("cur-max-evicted-stores", handler.config.MaxEvictedStores))
 	handler.rd.JSON(w, http.StatusOK, "Config updated.")
 }
 
@@ -316,16 +384,18 @@ func (s *evictSlowTrendScheduler) ReloadConfig() error {
 	pauseAndResumeLeaderTransfer(s.conf.cluster, constant.In, old, new)
 	s.conf.RecoverySec = newCfg.RecoverySec
 	s.conf.EvictedStores = newCfg.EvictedStores
+	s.conf.MaxEvictedStores = newCfg.MaxEvictedStores
 	return nil
 }
 
 // PrepareConfig implements the Scheduler interface.
 func (s *evictSlowTrendScheduler) PrepareConfig(cluster sche.SchedulerCluster) error {
-	evictedStoreID := s.conf.evictedStore()
-	if evictedStoreID == 0 {
-		return nil
+	for _, storeID := range s.conf.getStores() {
+		if err := cluster.SlowTrendEvicted(storeID); err != nil {
+			return err
+		}
 	}
-	return cluster.SlowTrendEvicted(evictedStoreID)
+	return nil
 }
 
 // CleanConfig implements the Scheduler interface.
@@ -334,7 +404,7 @@ func (s *evictSlowTrendScheduler) CleanConfig(cluster sche.SchedulerCluster) {
 }
 
 func (s *evictSlowTrendScheduler) prepareEvictLeader(cluster sche.SchedulerCluster, storeID uint64) error {
-	err := s.conf.setStoreAndPersist(storeID)
+	err := s.conf.addStoreAndPersist(storeID)
 	if err != nil {
 		log.Info("evict-slow-trend-scheduler persist config failed", zap.Uint64("store-id", storeID))
 		return err
@@ -342,30 +412,41 @@ func (s *evictSlowTrendScheduler) prepareEvictLeader(cluster sche.SchedulerClust
 	return cluster.SlowTrendEvicted(storeID)
 }
 
-func (s *evictSlowTrendScheduler) cleanupEvictLeader(cluster sche.SchedulerCluster) {
-	evictedStoreID, err := s.conf.clearAndPersist(cluster)
-	if err != nil {
-		log.Info("evict-slow-trend-scheduler persist config failed", zap.Uint64("store-id", evictedStoreID))
+func (s *evictSlowTrendScheduler) cleanupEvictLeader(cluster sche.SchedulerCluster, storeIDs ...uint64) {
+	if len(storeIDs) == 0 {
+		storeIDs = s.conf.getStores()
+		if len(storeIDs) == 0 {
+			return
+		}
 	}
-	if evictedStoreID != 0 {
-		// Assertion: evictStoreID == s.conf.LastEvictCandidate.storeID
-		s.conf.markCandidateRecovered()
-		cluster.SlowTrendRecovered(evictedStoreID)
+	if err := s.conf.removeAndPersist(cluster, storeIDs...); err != nil {
+		log.Info("evict-slow-trend-scheduler persist config failed", zap.Uint64s("store-ids", storeIDs))
+	}
+	for _, storeID := range storeIDs {
+		s.conf.markCandidateRecovered(storeID)
+		cluster.SlowTrendRecovered(storeID)
 	}
 }
 
 func (s *evictSlowTrendScheduler) scheduleEvictLeader(cluster sche.SchedulerCluster) []*operator.Operator {
-	store := cluster.GetStore(s.conf.evictedStore())
-	if store == nil {
+	evicted := false
+	for _, storeID := range s.conf.getStores() {
+		store := cluster.GetStore(storeID)
+		if store == nil {
+			continue
+		}
+		evicted = true
+		storeSlowTrendEvictedStatusGauge.WithLabelValues(store.GetAddress(), strconv.FormatUint(store.GetID(), 10)).Set(1)
+	}
+	if !evicted {
 		return nil
 	}
-	storeSlowTrendEvictedStatusGauge.WithLabelValues(store.GetAddress(), strconv.FormatUint(store.GetID(), 10)).Set(1)
 	return scheduleEvictLeaderBatch(s.R, s.GetName(), cluster, s.conf)
 }
 
 // IsScheduleAllowed implements the Scheduler interface.
 func (s *evictSlowTrendScheduler) IsScheduleAllowed(cluster sche.SchedulerCluster) bool {
-	if s.conf.evictedStore() == 0 {
+	if !s.conf.hasEvictedStores() {
 		return true
 	}
 	allowed := s.OpController.OperatorCount(operator.OpLeader) < cluster.GetSchedulerConfig().GetLeaderScheduleLimit()
@@ -381,27 +462,49 @@ func (s *evictSlowTrendScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 
 	var ops []*operator.Operator
 
-	if s.conf.evictedStore() != 0 {
-		store := cluster.GetStore(s.conf.evictedStore())
-		if store == nil || store.IsRemoved() {
-			// Previous slow store had been removed, remove the scheduler and check
-			// slow node next time.
-			log.Info("store evicted by slow trend has been removed", zap.Uint64("store-id", store.GetID()))
-			storeSlowTrendActionStatusGauge.WithLabelValues("evict", "stop_removed").Inc()
-		} else if checkStoreCanRecover(cluster, store) && s.conf.readyForRecovery() {
-			log.Info("store evicted by slow trend has been recovered", zap.Uint64("store-id", store.GetID()))
-			storeSlowTrendActionStatusGauge.WithLabelValues("evict", "stop_recovered").Inc()
-		} else {
-			storeSlowTrendActionStatusGauge.WithLabelValues("evict", "continue").Inc()
+	if s.conf.hasEvictedStores() {
+		// Each evicted store is recovered separately.
+		var recovered []uint64
+		for _, storeID := range s.conf.getStores() {
+			store := cluster.GetStore(storeID)
+			if store == nil || store.IsRemoved() {
+				// Previous slow store had been removed, remove it and check
+				// slow node next time.
+				log.Info("store evicted by slow trend has been removed", zap.Uint64("store-id", storeID))
+				storeSlowTrendActionStatusGauge.WithLabelValues("evict", "stop_removed").Inc()
+			} else if checkStoreCanRecover(cluster, store) && s.conf.readyForRecovery(storeID) {
+				log.Info("store evicted by slow trend has been recovered", zap.Uint64("store-id", storeID))
+				storeSlowTrendActionStatusGauge.WithLabelValues("evict", "stop_recovered").Inc()
+			} else {
+				storeSlowTrendActionStatusGauge.WithLabelValues("evict", "continue").Inc()
+				continue
+			}
+			recovered = append(recovered, storeID)
+		}
+		if len(recovered) > 0 {
+			s.cleanupEvictLeader(cluster, recovered...)
+			// Check slow node next time.
+			return s.scheduleEvictLeader(cluster), nil
+		}
+		maxEvictedStores := s.conf.getMaxEvictedStores(cluster.GetSharedConfig().GetMaxReplicas())
+		if len(s.conf.getStores()) >= maxEvictedStores {
 			return s.scheduleEvictLeader(cluster), nil
 		}
-		s.cleanupEvictLeader(cluster)
+	}
+
+	if !s.captureAndEvictCandidate(cluster) && !s.conf.hasEvictedStores() {
 		return ops, nil
 	}
+	return s.scheduleEvictLeader(cluster), nil
+}
 
+// captureAndEvictCandidate captures a slow store as the candidate, and starts to evict
+// its leaders after it's confirmed. It returns true if a new store is evicted.
+func (s *evictSlowTrendScheduler) captureAndEvictCandidate(cluster sche.SchedulerCluster) bool {
 	candFreshCaptured := false
 	if s.conf.candidate() == 0 {
-		candidate := chooseEvictCandidate(cluster, s.conf.lastCapturedCandidate())
+		maxEvictedStores := s.conf.getMaxEvictedStores(cluster.GetSharedConfig().GetMaxReplicas())
+		candidate := chooseEvictCandidate(cluster, s.conf.lastCapturedCandidate(), s.conf.getStores(), maxEvictedStores)
 		if candidate != nil {
 			storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "captured").Inc()
 			s.conf.captureCandidate(candidate.GetID())
@@ -414,20 +517,20 @@ func (s *evictSlowTrendScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 	slowStoreID := s.conf.candidate()
 	if slowStoreID == 0 {
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none").Inc()
-		return ops, nil
+		return false
 	}
 
 	slowStore := cluster.GetStore(slowStoreID)
-	if !candFreshCaptured && checkStoreFasterThanOthers(cluster, slowStore) {
+	if slowStore == nil || s.conf.isEvicted(slowStoreID) || (!candFreshCaptured && checkStoreFasterThanOthers(cluster, slowStore)) {
 		s.conf.popCandidate(false)
 		log.Info("slow store candidate by trend has been cancel", zap.Uint64("store-id", slowStoreID))
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "canceled_too_faster").Inc()
-		return ops, nil
+		return false
 	}
 	if slowStoreRecordTS := s.conf.captureTS(); !checkStoresAreUpdated(cluster, slowStoreID, slowStoreRecordTS) {
 		log.Info("slow store candidate waiting for other stores to update heartbeats", zap.Uint64("store-id", slowStoreID))
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "wait").Inc()
-		return ops, nil
+		return false
 	}
 
 	candCapturedSecs := s.conf.candidateCapturedSecs()
@@ -438,10 +541,10 @@ func (s *evictSlowTrendScheduler) Schedule(cluster sche.SchedulerCluster, _ bool
 	if err := s.prepareEvictLeader(cluster, s.conf.popCandidate(true)); err != nil {
 		log.Info("prepare for evicting leader by slow trend failed", zap.Error(err), zap.Uint64("store-id", slowStoreID))
 		storeSlowTrendActionStatusGauge.WithLabelValues("evict", "prepare_err").Inc()
-		return ops, nil
+		return false
 	}
 	storeSlowTrendActionStatusGauge.WithLabelValues("evict", "start").Inc()
-	return s.scheduleEvictLeader(cluster), nil
+	return true
 }
 
 func newEvictSlowTrendScheduler(opController *operator.Controller, conf *evictSlowTrendSchedulerConfig) Scheduler {
@@ -454,7 +557,9 @@ func newEvictSlowTrendScheduler(opController *operator.Controller, conf *evictSl
 	return sche
 }
 
-func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slowCandidate) (slowStore *core.StoreInfo) {
+// chooseEvictCandidate chooses the slowest store as the candidate except the evicted stores,
+// there should be no more than `maxEvictedStores` slow stores including the evicted ones.
+func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slowCandidate, evictedStores []uint64, maxEvictedStores int) (slowStore *core.StoreInfo) {
 	isRaftKV2 := cluster.GetStoreConfig().IsRaftKV2()
 	failpoint.Inject("mockRaftKV2", func() {
 		isRaftKV2 = true
@@ -478,6 +583,9 @@ func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slo
 			if slowTrend.ResultRate < -alterEpsilon {
 				affectedStoreCount += 1
 			}
+			if slices.Contains(evictedStores, store.GetID()) {
+				continue
+			}
 			// For the cases of disk io jitters.
 			// Normally, if there exists jitters on disk io or network io, the slow store must have a descending
 			// trend on QPS and ascending trend on duration. So, the slowTrend must match the following pattern.
@@ -515,22 +623,31 @@ func chooseEvictCandidate(cluster sche.SchedulerCluster, lastEvictCandidate *slo
 		return
 	}
 	// TODO: Calculate to judge if one store is way slower than the others
-	if len(candidates) > 1 {
+	if len(candidates)+len(evictedStores) > maxEvictedStores {
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_too_many").Inc()
 		return
 	}
 
-	store := candidates[0]
-
 	affectedStoreThreshold := int(float64(len(stores)) * cluster.GetSchedulerConfig().GetSlowStoreEvictingAffectedStoreRatioThreshold())
 	if affectedStoreCount < affectedStoreThreshold {
-		log.Info("evict-slow-trend-scheduler failed to confirm candidate: it only affect a few stores", zap.Uint64("store-id", store.GetID()))
+		log.Info("evict-slow-trend-scheduler failed to confirm candidate: it only affect a few stores", zap.Uint64("store-id", candidates[0].GetID()))
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_affect_a_few").Inc()
 		return
 	}
 
-	if !checkStoreSlowerThanOthers(cluster, store) {
-		log.Info("evict-slow-trend-scheduler failed to confirm candidate: it's not slower than others", zap.Uint64("store-id", store.GetID()))
+	// Greater `CauseValue` means slower, the slowest store is evicted first.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].GetSlowTrend().CauseValue > candidates[j].GetSlowTrend().CauseValue
+	})
+	var store *core.StoreInfo
+	for _, candidate := range candidates {
+		if checkStoreSlowerThanOthers(cluster, candidate) {
+			store = candidate
+			break
+		}
+		log.Info("evict-slow-trend-scheduler failed to confirm candidate: it's not slower than others", zap.Uint64("store-id", candidate.GetID()))
+	}
+	if store == nil {
 		storeSlowTrendActionStatusGauge.WithLabelValues("candidate", "none_not_slower").Inc()
 		return
 	}
@@ -97,14 +97,14 @@ func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendBasicFuncs() {
 	re.Equal(*lastCapturedCandidate, es2.conf.evictCandidate)
 	re.Zero(es2.conf.candidateCapturedSecs())
 	re.Zero(es2.conf.lastCandidateCapturedSecs())
-	re.False(es2.conf.readyForRecovery())
+	re.False(es2.conf.readyForRecovery(store.GetID()))
 	recoverTS := lastCapturedCandidate.recoverTS
 	re.True(recoverTS.After(lastCapturedCandidate.captureTS))
 	// Pop captured store 1 and mark it has recovered.
 	time.Sleep(50 * time.Millisecond)
 	re.Equal(es2.conf.popCandidate(true), store.GetID())
 	re.Equal(slowCandidate{}, es2.conf.evictCandidate)
-	es2.conf.markCandidateRecovered()
+	es2.conf.markCandidateRecovered(store.GetID())
 	lastCapturedCandidate = es2.conf.lastCapturedCandidate()
 	re.Positive(lastCapturedCandidate.recoverTS.Compare(recoverTS))
 	re.Equal(lastCapturedCandidate.storeID, store.GetID())
@@ -247,6 +247,88 @@ func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendV2() {
 	re.NoError(failpoint.Disable("github.com/tikv/pd/pkg/schedule/schedulers/transientRecoveryGap"))
 }
 
+func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendMultiStores() {
+	re := suite.Require()
+	es2, ok := suite.es.(*evictSlowTrendScheduler)
+	re.True(ok)
+	es2.conf.MaxEvictedStores = 2
+	es2.conf.RecoverySec = 0
+
+	now := time.Now()
+	for i := uint64(4); i <= 6; i++ {
+		suite.tc.AddLeaderStore(i, 10)
+		suite.tc.PutStore(suite.tc.GetStore(i).Clone(func(store *core.StoreInfo) {
+			store.GetStoreStats().SlowTrend = &pdpb.SlowTrend{CauseValue: 5.0e6, ResultValue: 5.0e3}
+		}, core.SetLastHeartbeatTS(now)))
+	}
+	// The majority of the voters of region 4 are on the slow stores 1 and 4.
+	suite.tc.AddLeaderRegion(4, 1, 4, 2)
+	suite.tc.AddLeaderRegion(5, 4, 5, 6)
+	setSlow := func(storeID uint64, causeValue float64) {
+		suite.tc.PutStore(suite.tc.GetStore(storeID).Clone(func(store *core.StoreInfo) {
+			store.GetStoreStats().SlowTrend = &pdpb.SlowTrend{
+				CauseValue:  causeValue,
+				CauseRate:   1e7,
+				ResultValue: 3.0e3,
+				ResultRate:  -1e7,
+			}
+		}))
+	}
+	updateHeartbeats := func() {
+		for _, storeID := range []uint64{2, 3, 5, 6} {
+			suite.tc.PutStore(suite.tc.GetStore(storeID).Clone(core.SetLastHeartbeatTS(time.Now())))
+		}
+	}
+
+	// The slowest store is evicted first.
+	setSlow(1, 6.0e8)
+	setSlow(4, 5.0e8)
+	ops, _ := suite.es.Schedule(suite.tc, false)
+	re.Empty(ops)
+	re.Equal(uint64(1), es2.conf.candidate())
+	updateHeartbeats()
+	ops, _ = suite.es.Schedule(suite.tc, false)
+	re.NotEmpty(ops)
+	re.Equal([]uint64{1}, es2.conf.getStores())
+
+	// Then the other slow store is captured and evicted.
+	suite.es.Schedule(suite.tc, false)
+	re.Equal(uint64(4), es2.conf.candidate())
+	updateHeartbeats()
+	suite.es.Schedule(suite.tc, false)
+	re.Equal([]uint64{1, 4}, es2.conf.getStores())
+	re.True(suite.tc.GetStore(4).IsEvictedAsSlowTrend())
+
+	// The leader of region 4 is never evicted, since the leader has nowhere better to go.
+	for range 10 {
+		ops, _ = suite.es.Schedule(suite.tc, false)
+		re.NotEmpty(ops)
+		for _, op := range ops {
+			re.NotEqual(uint64(4), op.RegionID())
+		}
+	}
+
+	// The number of the evicted stores is capped.
+	setSlow(5, 4.0e8)
+	suite.es.Schedule(suite.tc, false)
+	re.Zero(es2.conf.candidate())
+	re.Equal([]uint64{1, 4}, es2.conf.getStores())
+
+	// Each store is recovered separately.
+	suite.tc.PutStore(suite.tc.GetStore(4).Clone(func(store *core.StoreInfo) {
+		store.GetStoreStats().SlowTrend = &pdpb.SlowTrend{CauseValue: 5.0e6, ResultValue: 5.0e3}
+	}))
+	suite.es.Schedule(suite.tc, false)
+	re.Equal([]uint64{1}, es2.conf.getStores())
+	re.False(suite.tc.GetStore(4).IsEvictedAsSlowTrend())
+
+	// The evicted stores are persisted.
+	var persistValue evictSlowTrendSchedulerConfig
+	re.NoError(es2.conf.load(&persistValue))
+	re.Equal([]uint64{1}, persistValue.EvictedStores)
+	re.Equal(uint64(2), persistValue.MaxEvictedStores)
+}
+
 func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendPrepare() {
 	re := suite.Require()
 	es2, ok := suite.es.(*evictSlowTrendScheduler)
@@ -256,7 +338,7 @@ func (suite *evictSlowTrendTestSuite) TestEvictSlowTrendPrepare() {
 	err := suite.es.PrepareConfig(suite.tc)
 	re.NoError(err)
 
-	err = es2.conf.setStoreAndPersist(1)
+	err = es2.conf.addStoreAndPersist(1)
 	re.NoError(err)
 	re.Equal(uint64(1), es2.conf.evictedStore())
 	// prepare with evict store.
@@ -15,6 +15,7 @@
 package schedulers
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/pingcap/log"
 
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/core/constant"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/operator"
@@ -44,6 +46,11 @@ const (
 	defaultPendingAmpFactor = 2.0
 	defaultStddevThreshold  = 0.1
 	defaultTopnPosition     = 10
+	// hotBucketsConcentratedRatio is the min ratio of the load of the adjacent hot buckets to the total load
+	// of the region to isolate them into a sub-region.
+	hotBucketsConcentratedRatio = 0.8
+	// hotSplitGroupDuration is how long the sub-regions isolated from a hot region are kept apart as a group.
+	hotSplitGroupDuration = 10 * time.Minute
 )
 
 var (
@@ -68,6 +75,11 @@ type baseHotScheduler struct {
 	// stHistoryLoads stores the history `stLoadInfos`
 	// Every time `Schedule()` will rolling update it.
 	stHistoryLoads *statistics.StoreHistoryLoads
+	// forecast forecasts the store loads to balance the expected hotspots, nil means the forecast is disabled.
+	forecast *hotLoadForecast
+	// stForecastInfos contain the store statistics information raised to the forecast loads by resource type.
+	// It is only used by the balance solver, nil means there is no forecast.
+	stForecastInfos [resourceTypeLen]map[uint64]*statistics.StoreLoadDetail
 	// regionPendings stores regionID -> pendingInfluence,
 	// this records regionID which have pending Operator by operation type. During filterHotPeers, the hot peers won't
 	// be selected if its owner region is tracked in this attribute.
@@ -115,6 +127,10 @@ func (s *baseHotScheduler) prepareForBalance(typ resourceType, cluster sche.Sche
 			regionStats,
 			isTraceRegionFlow,
 			rw, resource)
+		s.stForecastInfos[ty] = nil
+		if s.forecast != nil {
+			s.stForecastInfos[ty] = s.forecast.apply(cluster, s.stLoadInfos[ty], rw, resource, time.Now())
+		}
 	}
 	switch typ {
 	case readLeader, readPeer:
@@ -189,6 +205,8 @@ type hotScheduler struct {
 	// config of hot scheduler
 	conf                *hotRegionSchedulerConfig
 	searchRevertRegions [resourceTypeLen]bool // Whether to search revert regions.
+	// hotSplitGroups stores regionID -> hotSplitGroup of the hot regions isolated by the scheduler recently.
+	hotSplitGroups map[uint64]*hotSplitGroup
 }
 
 func newHotScheduler(opController *operator.Controller, conf *hotRegionSchedulerConfig) *hotScheduler {
@@ -197,6 +215,7 @@ func newHotScheduler(opController *operator.Controller, conf *hotRegionScheduler
 	ret := &hotScheduler{
 		baseHotScheduler: base,
 		conf:             conf,
+		hotSplitGroups:   make(map[uint64]*hotSplitGroup),
 	}
 	for ty := resourceType(0); ty < resourceTypeLen; ty++ {
 		ret.searchRevertRegions[ty] = false
@@ -221,11 +240,13 @@ func (s *hotScheduler) ReloadConfig() error {
 	s.conf.MinHotByteRate = newCfg.MinHotByteRate
 	s.conf.MinHotKeyRate = newCfg.MinHotKeyRate
 	s.conf.MinHotQueryRate = newCfg.MinHotQueryRate
+	s.conf.MinHotCPURate = newCfg.MinHotCPURate
 	s.conf.MaxZombieRounds = newCfg.MaxZombieRounds
 	s.conf.MaxPeerNum = newCfg.MaxPeerNum
 	s.conf.ByteRateRankStepRatio = newCfg.ByteRateRankStepRatio
 	s.conf.KeyRateRankStepRatio = newCfg.KeyRateRankStepRatio
 	s.conf.QueryRateRankStepRatio = newCfg.QueryRateRankStepRatio
+	s.conf.CPURateRankStepRatio = newCfg.CPURateRankStepRatio
 	s.conf.CountRankStepRatio = newCfg.CountRankStepRatio
 	s.conf.GreatDecRatio = newCfg.GreatDecRatio
 	s.conf.MinorDecRatio = newCfg.MinorDecRatio
@@ -241,6 +262,10 @@ func (s *hotScheduler) ReloadConfig() error {
 	s.conf.SplitThresholds = newCfg.SplitThresholds
 	s.conf.HistorySampleDuration = newCfg.HistorySampleDuration
 	s.conf.HistorySampleInterval = newCfg.HistorySampleInterval
+	s.conf.ForecastModel = newCfg.ForecastModel
+	s.conf.ForecastPeriod = newCfg.ForecastPeriod
+	s.conf.ForecastSeasons = newCfg.ForecastSeasons
+	s.conf.ForecastHorizon = newCfg.ForecastHorizon
 	return nil
 }
 
@@ -279,6 +304,8 @@ func (s *hotScheduler) dispatch(typ resourceType, cluster sche.SchedulerCluster)
 	s.Lock()
 	defer s.Unlock()
 	s.updateHistoryLoadConfig(s.conf.getHistorySampleDuration(), s.conf.getHistorySampleInterval())
+	s.updateForecastConfig(s.conf.getForecastModel(), s.conf.getForecastConfig(), s.conf.getForecastHorizon())
+	s.gcHotSplitGroups()
 	s.prepareForBalance(typ, cluster)
 	// isForbidRWType can not be move earlier to support to use api and metrics.
 	switch typ {
@@ -321,6 +348,52 @@ func (s *hotScheduler) tryAddPendingInfluence(op *operator.Operator, srcStore []
 	return true
 }
 
+// hotSplitGroup records the key range of a hot region split by the scheduler to isolate its hot buckets.
+// The hot sub-regions within the range are not actively scattered, but when the scheduler moves one of
+// them, the stores holding the hot peers of the others are avoided, so they are not moved together.
+type hotSplitGroup struct {
+	startKey   []byte
+	endKey     []byte
+	expireTime time.Time
+}
+
+func (g *hotSplitGroup) contains(region *core.RegionInfo) bool {
+	if bytes.Compare(region.GetStartKey(), g.startKey) < 0 {
+		return false
+	}
+	if len(g.endKey) == 0 {
+		return true
+	}
+	return len(region.GetEndKey()) > 0 && bytes.Compare(region.GetEndKey(), g.endKey) <= 0
+}
+
+func (s *hotScheduler) addHotSplitGroup(region *core.RegionInfo) {
+	s.hotSplitGroups[region.GetID()] = &hotSplitGroup{
+		startKey:   region.GetStartKey(),
+		endKey:     region.GetEndKey(),
+		expireTime: time.Now().Add(hotSplitGroupDuration),
+	}
+}
+
+// getHotSplitGroup returns the group which the region is split from, or nil if there is no such group.
+func (s *hotScheduler) getHotSplitGroup(region *core.RegionInfo) *hotSplitGroup {
+	for _, group := range s.hotSplitGroups {
+		if group.contains(region) {
+			return group
+		}
+	}
+	return nil
+}
+
+func (s *hotScheduler) gcHotSplitGroups() {
+	now := time.Now()
+	for id, group := range s.hotSplitGroups {
+		if now.After(group.expireTime) {
+			delete(s.hotSplitGroups, id)
+		}
+	}
+}
+
 func (s *hotScheduler) balanceHotReadRegions(cluster sche.SchedulerCluster) []*operator.Operator {
 	leaderSolver := newBalanceSolver(s, cluster, utils.Read, transferLeader)
 	leaderOps := leaderSolver.solve()
@@ -513,6 +586,17 @@ func (bs *balanceSolver) tooHotNeedSplit(store *statistics.StoreLoadDetail, regi
 	})
 }
 
+// getSplitThresholds returns the split thresholds of the region.
+// The `hot_split_thresholds` label policy of the region takes precedence over the config.
+func (bs *balanceSolver) getSplitThresholds(region *core.RegionInfo, splitThresholds float64) float64 {
+	if l := bs.GetRegionLabeler(); l != nil {
+		if thresholds := l.GetSchedulingPolicy(region).HotSplitThresholds; thresholds > 0 {
+			return thresholds
+		}
+	}
+	return splitThresholds
+}
+
 type splitStrategy int
 
 const (
@@ -41,6 +41,8 @@ const (
 	// Scheduling has a bigger impact on TiFlash, so it needs to be corrected in configuration items
 	// In the default config, the TiKV difference is 1.05*1.05-1 = 0.1025, and the TiFlash difference is 1.15*1.15-1 = 0.3225
 	tiflashToleranceRatioCorrection = 0.1
+
+	defaultForecastHorizon = 10 * time.Minute
 )
 
 var defaultPrioritiesConfig = prioritiesConfig{
@@ -64,11 +66,13 @@ func initHotRegionScheduleConfig() *hotRegionSchedulerConfig {
 			MinHotByteRate:         100,
 			MinHotKeyRate:          10,
 			MinHotQueryRate:        10,
+			MinHotCPURate:          1,
 			MaxZombieRounds:        3,
 			MaxPeerNum:             1000,
 			ByteRateRankStepRatio:  0.05,
 			KeyRateRankStepRatio:   0.05,
 			QueryRateRankStepRatio: 0.05,
+			CPURateRankStepRatio:   0.05,
 			CountRankStepRatio:     0.01,
 			GreatDecRatio:          0.95,
 			MinorDecRatio:          0.99,
@@ -81,6 +85,10 @@ func initHotRegionScheduleConfig() *hotRegionSchedulerConfig {
 			SplitThresholds:        0.2,
 			HistorySampleDuration:  typeutil.NewDuration(statistics.DefaultHistorySampleDuration),
 			HistorySampleInterval:  typeutil.NewDuration(statistics.DefaultHistorySampleInterval),
+			ForecastModel:          noneForecastModel,
+			ForecastPeriod:         typeutil.NewDuration(statistics.DefaultForecastPeriod),
+			ForecastSeasons:        statistics.DefaultForecastSeasons,
+			ForecastHorizon:        typeutil.NewDuration(defaultForecastHorizon),
 		},
 	}
 	cfg.applyPrioritiesConfig(defaultPrioritiesConfig)
@@ -92,11 +100,13 @@ func (conf *hotRegionSchedulerConfig) getValidConf() *hotRegionSchedulerParam {
 		MinHotByteRate:         conf.MinHotByteRate,
 		MinHotKeyRate:          conf.MinHotKeyRate,
 		MinHotQueryRate:        conf.MinHotQueryRate,
+		MinHotCPURate:          conf.MinHotCPURate,
 		MaxZombieRounds:        conf.MaxZombieRounds,
 		MaxPeerNum:             conf.MaxPeerNum,
 		ByteRateRankStepRatio:  conf.ByteRateRankStepRatio,
 		KeyRateRankStepRatio:   conf.KeyRateRankStepRatio,
 		QueryRateRankStepRatio: conf.QueryRateRankStepRatio,
+		CPURateRankStepRatio:   conf.CPURateRankStepRatio,
 		CountRankStepRatio:     conf.CountRankStepRatio,
 		GreatDecRatio:          conf.GreatDecRatio,
 		MinorDecRatio:          conf.MinorDecRatio,
@@ -112,6 +122,10 @@ func (conf *hotRegionSchedulerConfig) getValidConf() *hotRegionSchedulerParam {
 		SplitThresholds:        conf.SplitThresholds,
 		HistorySampleDuration:  conf.HistorySampleDuration,
 		HistorySampleInterval:  conf.HistorySampleInterval,
+		ForecastModel:          conf.ForecastModel,
+		ForecastPeriod:         conf.ForecastPeriod,
+		ForecastSeasons:        conf.ForecastSeasons,
+		ForecastHorizon:        conf.ForecastHorizon,
 	}
 }
 
@@ -119,6 +133,8 @@ type hotRegionSchedulerParam struct {
 	MinHotByteRate  float64 `json:"min-hot-byte-rate"`
 	MinHotKeyRate   float64 `json:"min-hot-key-rate"`
 	MinHotQueryRate float64 `json:"min-hot-query-rate"`
+	// MinHotCPURate is the min read CPU usage of a hot peer, 1 means 1% of a CPU core.
+	MinHotCPURate   float64 `json:"min-hot-cpu-rate"`
 	MaxZombieRounds int     `json:"max-zombie-rounds"`
 	MaxPeerNum      int     `json:"max-peer-number"`
 
@@ -127,6 +143,7 @@ type hotRegionSchedulerParam struct {
 	ByteRateRankStepRatio  float64 `json:"byte-rate-rank-step-ratio"`
 	KeyRateRankStepRatio   float64 `json:"key-rate-rank-step-ratio"`
 	QueryRateRankStepRatio float64 `json:"query-rate-rank-step-ratio"`
+	CPURateRankStepRatio   float64 `json:"cpu-rate-rank-step-ratio"`
 	CountRankStepRatio     float64 `json:"count-rank-step-ratio"`
 	GreatDecRatio          float64 `json:"great-dec-ratio"`
 	MinorDecRatio          float64 `json:"minor-dec-ratio"` // only for v1
@@ -154,6 +171,18 @@ type hotRegionSchedulerParam struct {
 
 	HistorySampleDuration typeutil.Duration `json:"history-sample-duration"`
 	HistorySampleInterval typeutil.Duration `json:"history-sample-interval"`
+
+	// ForecastModel is the model to forecast the store load with the history samples,
+	// "none" means the forecast is disabled. The samples are seeded from the history hot regions
+	// persisted by PD. The scheduling service has no history hot region storage, so the forecast
+	// in it starts without the seeded samples and is available after a whole period is observed.
+	ForecastModel string `json:"forecast-model"`
+	// ForecastPeriod is the periodicity of the load, e.g. 24h for daily and 168h for weekly.
+	ForecastPeriod typeutil.Duration `json:"forecast-period"`
+	// ForecastSeasons is the number of the previous periods used to forecast.
+	ForecastSeasons int `json:"forecast-seasons"`
+	// ForecastHorizon is how long ahead the store load is forecast to balance the hotspots before the peak.
+	ForecastHorizon typeutil.Duration `json:"forecast-horizon"`
 }
 
 type hotRegionSchedulerConfig struct {
@@ -229,6 +258,12 @@ func (conf *hotRegionSchedulerConfig) getQueryRateRankStepRatio() float64 {
 	return conf.QueryRateRankStepRatio
 }
 
+func (conf *hotRegionSchedulerConfig) getCPURateRankStepRatio() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.CPURateRankStepRatio
+}
+
 func (conf *hotRegionSchedulerConfig) getCountRankStepRatio() float64 {
 	conf.RLock()
 	defer conf.RUnlock()
@@ -283,6 +318,12 @@ func (conf *hotRegionSchedulerConfig) getMinHotQueryRate() float64 {
 	return conf.MinHotQueryRate
 }
 
+func (conf *hotRegionSchedulerConfig) getMinHotCPURate() float64 {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.MinHotCPURate
+}
+
 func (conf *hotRegionSchedulerConfig) getReadPriorities() []string {
 	conf.RLock()
 	defer conf.RUnlock()
@@ -345,6 +386,35 @@ func (conf *hotRegionSchedulerConfig) setHistorySampleInterval(d time.Duration)
 	conf.HistorySampleInterval = typeutil.NewDuration(d)
 }
 
+func (conf *hotRegionSchedulerConfig) getForecastModel() string {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ForecastModel
+}
+
+func (conf *hotRegionSchedulerConfig) getForecastConfig() statistics.LoadForecastConfig {
+	conf.RLock()
+	defer conf.RUnlock()
+	return statistics.LoadForecastConfig{
+		Period:  conf.ForecastPeriod.Duration,
+		Slot:    statistics.DefaultForecastSlot,
+		Seasons: conf.ForecastSeasons,
+	}
+}
+
+func (conf *hotRegionSchedulerConfig) getForecastHorizon() time.Duration {
+	conf.RLock()
+	defer conf.RUnlock()
+	return conf.ForecastHorizon.Duration
+}
+
+// nolint: unused
+func (conf *hotRegionSchedulerConfig) setForecastModel(model string) {
+	conf.Lock()
+	defer conf.Unlock()
+	conf.ForecastModel = model
+}
+
 func (conf *hotRegionSchedulerConfig) getRankFormulaVersionLocked() string {
 	switch conf.RankFormulaVersion {
 	case "v2":
@@ -393,7 +463,7 @@ func (conf *hotRegionSchedulerConfig) handleGetConfig(w http.ResponseWriter, _ *
 func isPriorityValid(priorities []string) (map[string]bool, error) {
 	priorityMap := map[string]bool{}
 	for _, p := range priorities {
-		if p != utils.BytePriority && p != utils.KeyPriority && p != utils.QueryPriority {
+		if p != utils.BytePriority && p != utils.KeyPriority && p != utils.QueryPriority && p != utils.CPUPriority {
 			return nil, errs.ErrSchedulerConfig.FastGenByArgs("invalid scheduling dimensions")
 		}
 		priorityMap[p] = true
@@ -411,13 +481,17 @@ func (conf *hotRegionSchedulerParam) validateLocked() error {
 	if _, err := isPriorityValid(conf.ReadPriorities); err != nil {
 		return err
 	}
-	if _, err := isPriorityValid(conf.WriteLeaderPriorities); err != nil {
+	if pm, err := isPriorityValid(conf.WriteLeaderPriorities); err != nil {
 		return err
+	} else if pm[utils.CPUPriority] {
+		return errs.ErrSchedulerConfig.FastGenByArgs("cpu is not allowed to be set in priorities for write-leader-priorities")
 	}
 	if pm, err := isPriorityValid(conf.WritePeerPriorities); err != nil {
 		return err
 	} else if pm[utils.QueryPriority] {
 		return errs.ErrSchedulerConfig.FastGenByArgs("query is not allowed to be set in priorities for write-peer-priorities")
+	} else if pm[utils.CPUPriority] {
+		return errs.ErrSchedulerConfig.FastGenByArgs("cpu is not allowed to be set in priorities for write-peer-priorities")
 	}
 
 	if conf.RankFormulaVersion != "" && conf.RankFormulaVersion != "v1" && conf.RankFormulaVersion != "v2" {
@@ -431,6 +505,19 @@ func (conf *hotRegionSchedulerParam) validateLocked() error {
 	if conf.SplitThresholds < 0.01 || conf.SplitThresholds > 1.0 {
 		return errs.ErrSchedulerConfig.FastGenByArgs("invalid split-thresholds, should be in range [0.01, 1.0]")
 	}
+	if conf.ForecastModel != noneForecastModel && conf.ForecastModel != "" &&
+		!statistics.IsLoadForecastModelRegistered(conf.ForecastModel) {
+		return errs.ErrSchedulerConfig.FastGenByArgs("invalid forecast-model")
+	}
+	if conf.ForecastPeriod.Duration < statistics.DefaultForecastSlot {
+		return errs.ErrSchedulerConfig.FastGenByArgs("invalid forecast-period, should be at least " + statistics.DefaultForecastSlot.String())
+	}
+	if conf.ForecastSeasons < 1 {
+		return errs.ErrSchedulerConfig.FastGenByArgs("invalid forecast-seasons, should be at least 1")
+	}
+	if conf.ForecastHorizon.Duration < 0 || conf.ForecastHorizon.Duration > conf.ForecastPeriod.Duration {
+		return errs.ErrSchedulerConfig.FastGenByArgs("invalid forecast-horizon, should be in range [0, forecast-period]")
+	}
 	return nil
 }
 
@@ -539,7 +626,7 @@ func adjustPrioritiesConfig(querySupport bool, origins []string, getPriorities f
 
 	defaults := getPriorities(&defaultPrioritiesConfig)
 	isLegal := slice.AllOf(origins, func(i int) bool {
-		return origins[i] == utils.BytePriority || origins[i] == utils.KeyPriority || origins[i] == utils.QueryPriority
+		return origins[i] == utils.BytePriority || origins[i] == utils.KeyPriority || origins[i] == utils.QueryPriority || origins[i] == utils.CPUPriority
 	})
 	if len(defaults) == len(origins) && isLegal && origins[0] != origins[1] {
 		return origins
@@ -0,0 +1,357 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/errs"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/statistics"
+	"github.com/tikv/pd/pkg/statistics/utils"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/logutil"
+)
+
+const (
+	noneForecastModel = "none"
+	// maxForecastSeedRecords is the max number of the history hot regions of each read/write type
+	// loaded to seed the forecast, so that the seeding of a large cluster is bounded.
+	maxForecastSeedRecords = 1000000
+)
+
+// hotLoadForecast forecasts the store loads with the history samples so that the hot scheduler
+// can balance the expected hotspots before the peak. It also compares the forecast loads with
+// the observed loads when the forecast time comes to measure the accuracy of the model.
+// The samples are kept in memory, and they are seeded from the history hot regions persisted
+// by PD when the forecast is created, so the forecast is available after PD restarts.
+// The history is loaded in the background, and observed by the scheduler once it is loaded.
+type hotLoadForecast struct {
+	model      string
+	cfg        statistics.LoadForecastConfig
+	horizon    time.Duration
+	forecaster statistics.LoadForecaster
+	seeded     bool
+	// seedCh receives the samples loaded from the history hot regions, nil means there is no seeding in progress.
+	seedCh chan []forecastSample
+	// predictions stores resource type -> store id -> the forecast loads in time order which are not verified yet.
+	predictions [resourceTypeLen]map[uint64][]*forecastPoint
+	// verified stores resource type -> the stores whose loads are reported by hotForecastStoreLoadGauge.
+	verified [resourceTypeLen]map[uint64]struct{}
+}
+
+type forecastSample struct {
+	storeID uint64
+	rw      utils.RWType
+	kind    constant.ResourceKind
+	ts      time.Time
+	loads   statistics.Loads
+}
+
+type forecastPoint struct {
+	at    time.Time
+	loads statistics.Loads
+}
+
+// newHotLoadForecast creates a hotLoadForecast, it returns nil if the model is not registered.
+func newHotLoadForecast(model string, cfg statistics.LoadForecastConfig, horizon time.Duration) *hotLoadForecast {
+	forecaster := statistics.NewLoadForecaster(model, cfg)
+	if forecaster == nil {
+		return nil
+	}
+	f := &hotLoadForecast{
+		model:      model,
+		cfg:        cfg,
+		horizon:    horizon,
+		forecaster: forecaster,
+	}
+	for ty := resourceType(0); ty < resourceTypeLen; ty++ {
+		f.predictions[ty] = make(map[uint64][]*forecastPoint)
+		f.verified[ty] = make(map[uint64]struct{})
+	}
+	return f
+}
+
+// historyHotRegionStorage is implemented by the cluster which persists the history hot regions, e.g. the cluster of PD.
+type historyHotRegionStorage interface {
+	GetHistoryHotRegionStorage() *storage.HotRegionStorage
+}
+
+// historyHotRegionIterator iterates over the history hot regions in time order.
+type historyHotRegionIterator interface {
+	Next() (*storage.HistoryHotRegion, error)
+	Release()
+}
+
+// apply observes the current loads of the stores and returns a copy of them raised to the loads
+// forecast after the horizon. It returns nil if there is no forecast.
+func (f *hotLoadForecast) apply(cluster sche.SchedulerCluster, details map[uint64]*statistics.StoreLoadDetail,
+	rw utils.RWType, kind constant.ResourceKind, now time.Time) map[uint64]*statistics.StoreLoadDetail {
+	if !f.seeded {
+		f.seed(cluster, now)
+	}
+	f.observeSeed()
+	ty := buildResourceType(rw, kind)
+	f.removeStores(ty, details)
+	at := now.Add(f.horizon)
+	forecasts := make(map[uint64]statistics.Loads, len(details))
+	for id, detail := range details {
+		observed := detail.LoadPred.Current.Loads
+		f.verify(ty, id, now, observed)
+		f.forecaster.Observe(id, rw, kind, now, observed)
+		loads, ok := f.forecaster.Forecast(id, rw, kind, at)
+		if !ok {
+			continue
+		}
+		forecasts[id] = loads
+		// Only keep one prediction in a slot to verify.
+		points := f.predictions[ty][id]
+		if len(points) == 0 || at.Sub(points[len(points)-1].at) >= f.cfg.Slot {
+			f.predictions[ty][id] = append(points, &forecastPoint{at: at, loads: loads})
+		}
+	}
+	if len(forecasts) == 0 {
+		return nil
+	}
+	return statistics.ForecastStoresLoad(details, forecasts)
+}
+
+// seed starts to load the store loads summed from the history hot regions in the previous seasons
+// in the background, so that the scheduler is not blocked by scanning the history.
+// The history only contains the hot peers, so the seeded loads may be lower than the store loads,
+// and they are replaced by the observed ones season by season.
+func (f *hotLoadForecast) seed(cluster sche.SchedulerCluster, now time.Time) {
+	f.seeded = true
+	hs, ok := cluster.(historyHotRegionStorage)
+	if !ok || hs.GetHistoryHotRegionStorage() == nil {
+		return
+	}
+	hotRegionStorage := hs.GetHistoryHotRegionStorage()
+	seedCh := make(chan []forecastSample, 1)
+	f.seedCh = seedCh
+	go func() {
+		defer logutil.LogPanic()
+		seedCh <- f.loadHistory(hotRegionStorage, now)
+	}()
+}
+
+// observeSeed observes the samples loaded from the history if the loading is finished.
+func (f *hotLoadForecast) observeSeed() {
+	if f.seedCh == nil {
+		return
+	}
+	select {
+	case samples := <-f.seedCh:
+		f.seedCh = nil
+		for _, sample := range samples {
+			f.forecaster.Observe(sample.storeID, sample.rw, sample.kind, sample.ts, sample.loads)
+		}
+	default:
+	}
+}
+
+// loadHistory loads the samples from the history hot regions season by season from the latest one,
+// until maxForecastSeedRecords history hot regions of a read/write type are loaded.
+// It runs in the background, so it only reads the immutable fields of the forecast.
+func (f *hotLoadForecast) loadHistory(hotRegionStorage *storage.HotRegionStorage, now time.Time) []forecastSample {
+	var samples []forecastSample
+	for _, rw := range []utils.RWType{utils.Read, utils.Write} {
+		records := 0
+		end := now
+		for range f.cfg.Seasons {
+			if records >= maxForecastSeedRecords {
+				log.Warn("the history hot regions to seed the load forecast exceed the limit, the earlier ones are skipped",
+					zap.String("type", rw.String()), zap.Int("limit", maxForecastSeedRecords), zap.Time("before", end))
+				break
+			}
+			start := end.Add(-f.cfg.Period)
+			// The end of the iterator is inclusive.
+			iter := hotRegionStorage.NewIterator([]string{rw.String()}, start.UnixMilli(), end.UnixMilli()-1)
+			seasonSamples, n, err := f.collectHistory(&iter, rw, maxForecastSeedRecords-records)
+			iter.Release()
+			if err != nil {
+				log.Warn("failed to seed the load forecast with the history hot regions",
+					zap.String("type", rw.String()), errs.ZapError(err))
+				break
+			}
+			samples = append(samples, seasonSamples...)
+			records += n
+			end = start
+		}
+	}
+	return samples
+}
+
+// collectHistory sums the loads of the hot peers of each store by the slot as the samples.
+// The latest load of a hot peer in a slot is used if it is recorded several times.
+// It stops after reading maxRecords history hot regions, and returns the number of the read ones.
+func (f *hotLoadForecast) collectHistory(iter historyHotRegionIterator, rw utils.RWType, maxRecords int) ([]forecastSample, int, error) {
+	var (
+		samples []forecastSample
+		records int
+		slot    = int64(-1)
+		// peerLoads stores resource kind -> store id -> region id -> the loads of the hot peer.
+		peerLoads [constant.ResourceKindLen]map[uint64]map[uint64]statistics.Loads
+	)
+	reset := func() {
+		for kind := range peerLoads {
+			peerLoads[kind] = make(map[uint64]map[uint64]statistics.Loads)
+		}
+	}
+	observe := func() {
+		ts := time.Unix(0, slot*int64(f.cfg.Slot))
+		for kind := range peerLoads {
+			for storeID, peers := range peerLoads[kind] {
+				var sum statistics.Loads
+				for _, loads := range peers {
+					for dim := range sum {
+						sum[dim] += loads[dim]
+					}
+				}
+				samples = append(samples, forecastSample{
+					storeID: storeID,
+					rw:      rw,
+					kind:    constant.ResourceKind(kind),
+					ts:      ts,
+					loads:   sum,
+				})
+			}
+		}
+		reset()
+	}
+	add := func(kind constant.ResourceKind, region *storage.HistoryHotRegion, loads statistics.Loads) {
+		peers, ok := peerLoads[kind][region.StoreID]
+		if !ok {
+			peers = make(map[uint64]statistics.Loads)
+			peerLoads[kind][region.StoreID] = peers
+		}
+		peers[region.RegionID] = loads
+	}
+
+	reset()
+	for records < maxRecords {
+		region, err := iter.Next()
+		if err != nil {
+			return nil, records, err
+		}
+		if region == nil {
+			break
+		}
+		records++
+		if s := time.UnixMilli(region.UpdateTime).UnixNano() / int64(f.cfg.Slot); s != slot {
+			if slot >= 0 {
+				observe()
+			}
+			slot = s
+		}
+		var loads statistics.Loads
+		loads[utils.ByteDim] = region.FlowBytes
+		loads[utils.KeyDim] = region.KeyRate
+		loads[utils.QueryDim] = region.QueryRate
+		add(constant.RegionKind, region, loads)
+		if region.IsLeader {
+			add(constant.LeaderKind, region, loads)
+		}
+	}
+	if slot >= 0 {
+		observe()
+	}
+	return samples, records, nil
+}
+
+// verify compares the latest due prediction of the store with the observed loads.
+func (f *hotLoadForecast) verify(ty resourceType, storeID uint64, now time.Time, observed statistics.Loads) {
+	points := f.predictions[ty][storeID]
+	i := 0
+	for i < len(points) && !points[i].at.After(now) {
+		i++
+	}
+	if i == 0 {
+		return
+	}
+	predicted := points[i-1].loads
+	if i == len(points) {
+		delete(f.predictions[ty], storeID)
+	} else {
+		f.predictions[ty][storeID] = points[i:]
+	}
+	f.verified[ty][storeID] = struct{}{}
+	storeLabel := strconv.FormatUint(storeID, 10)
+	for dim := range observed {
+		dimLabel := utils.DimToString(dim)
+		hotForecastStoreLoadGauge.WithLabelValues(storeLabel, ty.String(), dimLabel, "predicted").Set(predicted[dim])
+		hotForecastStoreLoadGauge.WithLabelValues(storeLabel, ty.String(), dimLabel, "observed").Set(observed[dim])
+		if observed[dim] > 0 {
+			hotForecastErrorRatioHist.WithLabelValues(ty.String(), dimLabel).Observe(math.Abs(predicted[dim]-observed[dim]) / observed[dim])
+		}
+	}
+}
+
+// removeStores drops the predictions and deletes the metrics of the stores which are removed.
+func (f *hotLoadForecast) removeStores(ty resourceType, details map[uint64]*statistics.StoreLoadDetail) {
+	for storeID := range f.predictions[ty] {
+		if _, ok := details[storeID]; !ok {
+			delete(f.predictions[ty], storeID)
+		}
+	}
+	for storeID := range f.verified[ty] {
+		if _, ok := details[storeID]; !ok {
+			f.deleteStoreMetrics(ty, storeID)
+		}
+	}
+}
+
+func (f *hotLoadForecast) deleteStoreMetrics(ty resourceType, storeID uint64) {
+	delete(f.verified[ty], storeID)
+	storeLabel := strconv.FormatUint(storeID, 10)
+	for dim := range utils.DimLen {
+		dimLabel := utils.DimToString(dim)
+		hotForecastStoreLoadGauge.DeleteLabelValues(storeLabel, ty.String(), dimLabel, "predicted")
+		hotForecastStoreLoadGauge.DeleteLabelValues(storeLabel, ty.String(), dimLabel, "observed")
+	}
+}
+
+// clear deletes the metrics of all the stores, it is called when the forecast is replaced or disabled.
+func (f *hotLoadForecast) clear() {
+	for ty := resourceType(0); ty < resourceTypeLen; ty++ {
+		for storeID := range f.verified[ty] {
+			f.deleteStoreMetrics(ty, storeID)
+		}
+	}
+}
+
+func (s *baseHotScheduler) updateForecastConfig(model string, cfg statistics.LoadForecastConfig, horizon time.Duration) {
+	if s.forecast != nil && (s.forecast.model != model || s.forecast.cfg != cfg) {
+		s.forecast.clear()
+	}
+	if model == noneForecastModel || model == "" {
+		s.forecast = nil
+		s.stForecastInfos = [resourceTypeLen]map[uint64]*statistics.StoreLoadDetail{}
+		return
+	}
+	if s.forecast != nil && s.forecast.model == model && s.forecast.cfg == cfg {
+		s.forecast.horizon = horizon
+		return
+	}
+	s.forecast = newHotLoadForecast(model, cfg, horizon)
+	s.stForecastInfos = [resourceTypeLen]map[uint64]*statistics.StoreLoadDetail{}
+}
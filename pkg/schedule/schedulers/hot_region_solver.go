@@ -15,6 +15,7 @@
 package schedulers
 
 import (
+	"bytes"
 	"math"
 	"sort"
 	"strconv"
@@ -54,6 +55,8 @@ type balanceSolver struct {
 
 	best *solution
 	ops  []*operator.Operator
+	// isolateOp is the split operator isolating the concentrated hot buckets of a region, if any.
+	isolateOp *operator.Operator
 
 	// maxSrc and minDst are used to calculate the rank.
 	maxSrc   *statistics.StoreLoad
@@ -61,7 +64,7 @@ type balanceSolver struct {
 	rankStep *statistics.StoreLoad
 
 	// firstPriority and secondPriority indicate priority of hot schedule
-	// they may be byte(0), key(1), query(2), and always less than dimLen
+	// they may be byte(0), key(1), query(2), cpu(3), and always less than dimLen
 	firstPriority  int
 	secondPriority int
 
@@ -87,8 +90,11 @@ func (bs *balanceSolver) init() {
 		bs.rank = initRankV2(bs)
 	}
 
-	// Init store load detail according to the type.
+	// Init store load detail according to the type, the forecast loads are preferred if there are.
 	bs.stLoadDetail = bs.sche.stLoadInfos[bs.resourceTy]
+	if forecast := bs.sche.stForecastInfos[bs.resourceTy]; forecast != nil {
+		bs.stLoadDetail = forecast
+	}
 
 	bs.maxSrc = &statistics.StoreLoad{}
 	bs.minDst = &statistics.StoreLoad{HotPeerCount: math.MaxFloat64}
@@ -110,7 +116,8 @@ func (bs *balanceSolver) init() {
 	rankStepRatios := []float64{
 		utils.ByteDim:  bs.sche.conf.getByteRankStepRatio(),
 		utils.KeyDim:   bs.sche.conf.getKeyRankStepRatio(),
-		utils.QueryDim: bs.sche.conf.getQueryRateRankStepRatio()}
+		utils.QueryDim: bs.sche.conf.getQueryRateRankStepRatio(),
+		utils.CPUDim:   bs.sche.conf.getCPURateRankStepRatio()}
 	var stepLoads statistics.Loads
 	for i := range stepLoads {
 		stepLoads[i] = maxCur.Loads[i] * rankStepRatios[i]
@@ -206,7 +213,7 @@ func (bs *balanceSolver) solve() []*operator.Operator {
 				}
 			}
 			bs.cur.mainPeerStat = mainPeerStat
-			if bs.GetStoreConfig().IsEnableRegionBucket() && bs.tooHotNeedSplit(srcStore, mainPeerStat, splitThresholds) {
+			if bs.GetStoreConfig().IsEnableRegionBucket() && bs.tooHotNeedSplit(srcStore, mainPeerStat, bs.getSplitThresholds(bs.cur.region, splitThresholds)) {
 				hotSchedulerRegionTooHotNeedSplitCounter.Inc()
 				ops := bs.createSplitOperator([]*core.RegionInfo{bs.cur.region}, byLoad)
 				if len(ops) > 0 {
@@ -255,6 +262,8 @@ func (bs *balanceSolver) skipCounter(label string) prometheus.Counter {
 			return readSkipKeyDimUniformStoreCounter
 		case "query":
 			return readSkipQueryDimUniformStoreCounter
+		case "cpu":
+			return readSkipCPUDimUniformStoreCounter
 		default:
 			return readSkipAllDimUniformStoreCounter
 		}
@@ -287,6 +296,7 @@ func (bs *balanceSolver) tryAddPendingInfluence() bool {
 
 	srcStoreIDs := make([]uint64, 0)
 	dstStoreID := uint64(0)
+	var splitRegion *core.RegionInfo
 	if isSplit {
 		region := bs.GetRegion(bs.ops[0].RegionID())
 		if region == nil {
@@ -295,6 +305,7 @@ func (bs *balanceSolver) tryAddPendingInfluence() bool {
 		for id := range region.GetStoreIDs() {
 			srcStoreIDs = append(srcStoreIDs, id)
 		}
+		splitRegion = region
 	} else {
 		srcStoreIDs = append(srcStoreIDs, bs.best.srcStore.GetID())
 		dstStoreID = bs.best.dstStore.GetID()
@@ -304,6 +315,11 @@ func (bs *balanceSolver) tryAddPendingInfluence() bool {
 		return false
 	}
 	if isSplit {
+		// Only the hot sub-regions isolated from the hot buckets are kept apart, the
+		// regions split by size or at the half point of the loads are not grouped.
+		if bs.ops[0] == bs.isolateOp {
+			bs.sche.addHotSplitGroup(splitRegion)
+		}
 		return true
 	}
 	// revert peers
@@ -493,6 +509,11 @@ func (bs *balanceSolver) isRegionAvailable(region *core.RegionInfo) bool {
 		return false
 	}
 
+	if l := bs.GetRegionLabeler(); l != nil && l.GetSchedulingPolicy(region).HotScheduleDenied {
+		hotSchedulerLabelDeniedCounter.Inc()
+		return false
+	}
+
 	return true
 }
 
@@ -591,7 +612,62 @@ func (bs *balanceSolver) filterDstStores() map[uint64]*statistics.StoreLoadDetai
 	default:
 		return nil
 	}
-	return bs.pickDstStores(filters, candidates)
+	return bs.pickDstStores(filters, bs.filterLeaderZone(bs.filterHotSplitSiblings(candidates)))
+}
+
+// filterHotSplitSiblings drops the candidates which already hold the hot peers of the other
+// sub-regions isolated from the same hot region, so that the hot sub-regions are not moved to
+// the same store. If all candidates hold siblings, they are kept.
+func (bs *balanceSolver) filterHotSplitSiblings(candidates []*statistics.StoreLoadDetail) []*statistics.StoreLoadDetail {
+	group := bs.sche.getHotSplitGroup(bs.cur.region)
+	if group == nil {
+		return candidates
+	}
+	siblingStores := make(map[uint64]struct{})
+	for _, region := range bs.ScanRegions(group.startKey, group.endKey, -1) {
+		if region.GetID() == bs.cur.region.GetID() {
+			continue
+		}
+		for storeID := range region.GetStoreIDs() {
+			if bs.GetHotPeerStat(bs.rwTy, region.GetID(), storeID) != nil {
+				siblingStores[storeID] = struct{}{}
+			}
+		}
+	}
+	ret := make([]*statistics.StoreLoadDetail, 0, len(candidates))
+	for _, detail := range candidates {
+		if _, ok := siblingStores[detail.GetID()]; !ok {
+			ret = append(ret, detail)
+		}
+	}
+	if len(ret) == 0 {
+		return candidates
+	}
+	if len(ret) < len(candidates) {
+		hotSchedulerSplitSiblingAvoidedCounter.Inc()
+	}
+	return ret
+}
+
+// filterLeaderZone drops the candidates which violate the `leader_zone` label
+// policy of the current region if the leader would be placed on them.
+func (bs *balanceSolver) filterLeaderZone(candidates []*statistics.StoreLoadDetail) []*statistics.StoreLoadDetail {
+	l := bs.GetRegionLabeler()
+	if l == nil || !bs.cur.mainPeerStat.IsLeader() {
+		return candidates
+	}
+	policy := l.GetSchedulingPolicy(bs.cur.region)
+	if policy.LeaderZone == "" {
+		return candidates
+	}
+	zoneLabel := bs.GetSchedulerConfig().GetLeaderZoneLabel()
+	ret := candidates[:0]
+	for _, detail := range candidates {
+		if policy.AllowLeaderOnStore(detail.StoreInfo, zoneLabel) {
+			ret = append(ret, detail)
+		}
+	}
+	return ret
 }
 
 func (bs *balanceSolver) pickDstStores(filters []filter.Filter, candidates []*statistics.StoreLoadDetail) map[uint64]*statistics.StoreLoadDetail {
@@ -727,6 +803,8 @@ func (bs *balanceSolver) getMinRate(dim int) float64 {
 		return bs.sche.conf.getMinHotByteRate()
 	case utils.QueryDim:
 		return bs.sche.conf.getMinHotQueryRate()
+	case utils.CPUDim:
+		return bs.sche.conf.getMinHotCPURate()
 	}
 	return -1
 }
@@ -735,6 +813,7 @@ var dimToStep = [utils.DimLen]float64{
 	utils.ByteDim:  100,
 	utils.KeyDim:   10,
 	utils.QueryDim: 10,
+	utils.CPUDim:   1,
 }
 
 // compareSrcStore compares the source store of detail1, detail2, the result is:
@@ -891,7 +970,7 @@ func (bs *balanceSolver) buildOperators() (ops []*operator.Operator) {
 }
 
 // bucketFirstStat returns the first priority statistics of the bucket.
-// if the first priority is query rate, it will return the second priority .
+// if the first priority is query rate or cpu, it will return the second priority .
 func (bs *balanceSolver) bucketFirstStat() utils.RegionStatKind {
 	base := utils.RegionReadBytes
 	if bs.rwTy == utils.Write {
@@ -899,12 +978,20 @@ func (bs *balanceSolver) bucketFirstStat() utils.RegionStatKind {
 	}
 	offset := bs.firstPriority
 	// todo: remove it if bucket's qps has been supported.
-	if bs.firstPriority == utils.QueryDim {
+	if !isBucketDim(offset) {
 		offset = bs.secondPriority
 	}
+	// The bucket doesn't report the cpu usage, use the byte rate instead.
+	if !isBucketDim(offset) {
+		offset = utils.ByteDim
+	}
 	return base + utils.RegionStatKind(offset)
 }
 
+func isBucketDim(dim int) bool {
+	return dim == utils.ByteDim || dim == utils.KeyDim
+}
+
 func (bs *balanceSolver) splitBucketsOperator(region *core.RegionInfo, keys [][]byte) *operator.Operator {
 	splitKeys := make([][]byte, 0, len(keys))
 	for _, key := range keys {
@@ -948,17 +1035,39 @@ func (bs *balanceSolver) splitBucketsByLoad(region *core.RegionInfo, bucketStats
 		return nil
 	}
 
-	// if this region has only one buckets, we can't split it into two hot region, so skip it.
-	if len(stats) == 1 {
-		hotSchedulerOnlyOneBucketsHotCounter.Inc()
-		return nil
-	}
 	totalLoads := uint64(0)
 	dim := bs.bucketFirstStat()
 	for _, stat := range stats {
 		totalLoads += stat.Loads[dim]
 	}
 
+	// If the hot load is concentrated in a few adjacent buckets, split at both sides of them
+	// to isolate the hot key range, so that the hot sub-region can be scheduled on its own.
+	if start, end := concentratedHotBuckets(stats, dim, totalLoads); end > start {
+		keys := [][]byte{stats[start].StartKey, stats[end-1].EndKey}
+		if slice.AnyOf(keys, func(i int) bool {
+			return keyutil.Between(region.GetStartKey(), region.GetEndKey(), keys[i])
+		}) {
+			hotLoads := uint64(0)
+			for _, stat := range stats[start:end] {
+				hotLoads += stat.Loads[dim]
+			}
+			op := bs.splitBucketsOperator(region, keys)
+			if op != nil {
+				op.SetAdditionalInfo("hotLoads", strconv.FormatUint(hotLoads, 10))
+				op.SetAdditionalInfo("totalLoads", strconv.FormatUint(totalLoads, 10))
+				bs.isolateOp = op
+			}
+			return op
+		}
+	}
+
+	// if this region has only one buckets, we can't split it into two hot region, so skip it.
+	if len(stats) == 1 {
+		hotSchedulerOnlyOneBucketsHotCounter.Inc()
+		return nil
+	}
+
 	// find the half point of the total loads.
 	acc, splitIdx := uint64(0), 0
 	for ; acc < totalLoads/2 && splitIdx < len(stats); splitIdx++ {
@@ -981,6 +1090,46 @@ func (bs *balanceSolver) splitBucketsByLoad(region *core.RegionInfo, bucketStats
 	return op
 }
 
+// concentratedHotBuckets returns the adjacent buckets [start, end) which the hot load is concentrated in.
+// The range grows from the hottest bucket to the neighbors whose load is at least half of the hottest one.
+// It returns start == end if the range holds too little of the total loads, or if it covers all the
+// hot buckets of a region which has more than one hot bucket, then splitting at the half point is better.
+func concentratedHotBuckets(stats []*buckets.BucketStat, dim utils.RegionStatKind, totalLoads uint64) (start, end int) {
+	if totalLoads == 0 {
+		return 0, 0
+	}
+	hottest := 0
+	for i, stat := range stats {
+		if stat.Loads[dim] > stats[hottest].Loads[dim] {
+			hottest = i
+		}
+	}
+	isAdjacent := func(i, j int) bool {
+		return bytes.Equal(stats[i].EndKey, stats[j].StartKey)
+	}
+	isHot := func(i int) bool {
+		return stats[i].Loads[dim]*2 >= stats[hottest].Loads[dim]
+	}
+	start, end = hottest, hottest+1
+	for start > 0 && isAdjacent(start-1, start) && isHot(start-1) {
+		start--
+	}
+	for end < len(stats) && isAdjacent(end-1, end) && isHot(end) {
+		end++
+	}
+	if len(stats) > 1 && end-start == len(stats) {
+		return 0, 0
+	}
+	hotLoads := uint64(0)
+	for _, stat := range stats[start:end] {
+		hotLoads += stat.Loads[dim]
+	}
+	if float64(hotLoads) < float64(totalLoads)*hotBucketsConcentratedRatio {
+		return 0, 0
+	}
+	return start, end
+}
+
 // splitBucketBySize splits the region order by bucket count if the region is too big.
 func (bs *balanceSolver) splitBucketBySize(region *core.RegionInfo) *operator.Operator {
 	splitKeys := make([][]byte, 0)
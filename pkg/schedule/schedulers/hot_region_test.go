@@ -28,7 +28,9 @@ import (
 	"github.com/pingcap/kvproto/pkg/pdpb"
 
 	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/mock/mockcluster"
+	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/types"
@@ -254,6 +256,8 @@ func TestSplitIfRegionTooHot(t *testing.T) {
 	re.NoError(err)
 	re.Equal(expectOp.Brief(), ops[0].Brief())
 	re.Equal(expectOp.Kind(), ops[0].Kind())
+	// The region split at the half point of the loads is not grouped.
+	re.Empty(hb.(*hotScheduler).hotSplitGroups)
 
 	ops, _ = hb.Schedule(tc, false)
 	re.Empty(ops)
@@ -281,6 +285,161 @@ func TestSplitIfRegionTooHot(t *testing.T) {
 	re.Empty(ops)
 }
 
+func TestSplitHotBucketsRange(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	hb, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb.(*hotScheduler).conf.setHistorySampleDuration(0)
+	b := &metapb.Buckets{
+		RegionId:   1,
+		PeriodInMs: 1000,
+		Keys: [][]byte{
+			[]byte(fmt.Sprintf("%21d", 11)),
+			[]byte(fmt.Sprintf("%21d", 12)),
+			[]byte(fmt.Sprintf("%21d", 13)),
+			[]byte(fmt.Sprintf("%21d", 14)),
+			[]byte(fmt.Sprintf("%21d", 15)),
+		},
+		Stats: &metapb.BucketStats{
+			ReadBytes:  []uint64{1 * units.KiB, 100 * units.KiB, 1 * units.KiB, 1 * units.KiB},
+			ReadKeys:   []uint64{10, 1000, 10, 10},
+			ReadQps:    []uint64{0, 0, 0, 0},
+			WriteBytes: []uint64{0, 0, 0, 0},
+			WriteQps:   []uint64{0, 0, 0, 0},
+			WriteKeys:  []uint64{0, 0, 0, 0},
+		},
+	}
+	task := buckets.NewCheckPeerTask(b)
+	re.True(tc.CheckAsync(task))
+	time.Sleep(time.Millisecond * 10)
+
+	tc.AddRegionStore(1, 3)
+	tc.AddRegionStore(2, 2)
+	tc.AddRegionStore(3, 2)
+	tc.UpdateStorageReadBytes(1, 6*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(2, 1*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadBytes(3, 1*units.MiB*utils.StoreHeartBeatReportInterval)
+	addRegionInfo(tc, utils.Read, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 4 * units.MiB, 0, 0},
+	})
+	tc.SetRegionBucketEnabled(true)
+
+	// The region is not too hot for the split thresholds of the label rule.
+	rule := &labeler.LabelRule{
+		ID:       "table-1",
+		Labels:   []labeler.RegionLabel{{Key: labeler.HotSplitThresholdsLabel, Value: "1"}},
+		RuleType: labeler.KeyRange,
+		Data:     labeler.MakeKeyRanges("", ""),
+	}
+	re.NoError(tc.GetRegionLabeler().SetLabelRule(rule))
+	ops, _ := hb.Schedule(tc, false)
+	for _, op := range ops {
+		re.NotEqual(operator.OpSplit, op.Kind())
+	}
+	re.NoError(tc.GetRegionLabeler().DeleteLabelRule(rule.ID))
+	clearPendingInfluence(hb.(*hotScheduler))
+
+	// The hot load is concentrated in one bucket, so the region is split at both sides of it.
+	ops, _ = hb.Schedule(tc, false)
+	re.Len(ops, 1)
+	expectOp, err := operator.CreateSplitRegionOperator(splitHotReadBuckets, tc.GetRegion(1), operator.OpSplit,
+		pdpb.CheckPolicy_USEKEY, [][]byte{[]byte(fmt.Sprintf("%21d", 12)), []byte(fmt.Sprintf("%21d", 13))})
+	re.NoError(err)
+	re.Equal(expectOp.Brief(), ops[0].Brief())
+	re.Equal(operator.OpSplit, ops[0].Kind())
+	re.NotNil(hb.(*hotScheduler).getHotSplitGroup(tc.GetRegion(1)))
+}
+
+func TestHotSplitSiblings(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	hb, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb.(*hotScheduler).conf.setHistorySampleDuration(0)
+	hb.(*hotScheduler).conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+	hb.(*hotScheduler).types = []resourceType{readLeader}
+
+	tc.AddRegionStore(1, 20)
+	tc.AddRegionStore(2, 20)
+	tc.AddRegionStore(3, 20)
+	tc.UpdateStorageReadStats(1, 6*units.MiB*utils.StoreHeartBeatReportInterval, 6*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadStats(2, 1*units.MiB*utils.StoreHeartBeatReportInterval, 1*units.MiB*utils.StoreHeartBeatReportInterval)
+	tc.UpdateStorageReadStats(3, 2*units.MiB*utils.StoreHeartBeatReportInterval, 2*units.MiB*utils.StoreHeartBeatReportInterval)
+	// Region 1 and 2 are adjacent, and the leader of region 2 is on store 2.
+	addRegionLeaderReadInfo(tc, []testRegionInfo{
+		{1, []uint64{1, 2, 3}, 2 * units.MiB, 2 * units.MiB, 0},
+		{2, []uint64{2, 1, 3}, 1 * units.MiB, 1 * units.MiB, 0},
+	})
+
+	// The least loaded store is preferred.
+	ops, _ := hb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpHotRegion, 1, 2)
+	clearPendingInfluence(hb.(*hotScheduler))
+
+	// Region 1 and 2 are isolated from the same hot region, they are not moved to the same store.
+	origin := core.NewTestRegionInfo(1, 1, tc.GetRegion(1).GetStartKey(), tc.GetRegion(2).GetEndKey())
+	hb.(*hotScheduler).addHotSplitGroup(origin)
+	ops, _ = hb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpHotRegion, 1, 3)
+	clearPendingInfluence(hb.(*hotScheduler))
+
+	// The group is expired.
+	hb.(*hotScheduler).hotSplitGroups[1].expireTime = time.Now().Add(-time.Second)
+	ops, _ = hb.Schedule(tc, false)
+	re.Len(ops, 1)
+	operatorutil.CheckTransferLeader(re, ops[0], operator.OpHotRegion, 1, 2)
+	re.Empty(hb.(*hotScheduler).hotSplitGroups)
+}
+
+func TestConcentratedHotBuckets(t *testing.T) {
+	re := require.New(t)
+	newStats := func(loads ...uint64) []*buckets.BucketStat {
+		stats := make([]*buckets.BucketStat, 0, len(loads))
+		for i, load := range loads {
+			stats = append(stats, &buckets.BucketStat{
+				StartKey: []byte{byte(i)},
+				EndKey:   []byte{byte(i + 1)},
+				Loads:    []uint64{load},
+			})
+		}
+		return stats
+	}
+	testCases := []struct {
+		loads      []uint64
+		start, end int
+	}{
+		{[]uint64{1, 100, 1, 1}, 1, 2},
+		{[]uint64{1, 100, 80, 1}, 1, 3},
+		{[]uint64{100}, 0, 1},
+		// the load is not concentrated enough.
+		{[]uint64{100, 10, 10, 10}, 0, 0},
+		// all buckets are hot, split at the half point instead.
+		{[]uint64{10, 11, 11, 10}, 0, 0},
+		{[]uint64{0, 0}, 0, 0},
+	}
+	for _, tc := range testCases {
+		stats := newStats(tc.loads...)
+		total := uint64(0)
+		for _, load := range tc.loads {
+			total += load
+		}
+		start, end := concentratedHotBuckets(stats, 0, total)
+		re.Equal(tc.start, start, "%v", tc.loads)
+		re.Equal(tc.end, end, "%v", tc.loads)
+	}
+
+	// The buckets are not adjacent.
+	stats := newStats(100, 80)
+	stats[1].StartKey = []byte{2}
+	start, end := concentratedHotBuckets(stats, 0, 180)
+	re.Zero(end - start)
+}
+
 func TestHotWriteRegionScheduleByteRateOnly(t *testing.T) {
 	re := require.New(t)
 	checkHotWriteRegionScheduleByteRateOnly(re, false /* disable placement rules */)
@@ -1302,6 +1461,222 @@ func TestHotReadRegionScheduleWithQuery(t *testing.T) {
 	}
 }
 
+func TestHotReadRegionScheduleWithCPU(t *testing.T) {
+	re := require.New(t)
+
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	hb, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb.(*hotScheduler).conf.setSrcToleranceRatio(1)
+	hb.(*hotScheduler).conf.setDstToleranceRatio(1)
+	hb.(*hotScheduler).conf.setHistorySampleDuration(0)
+	hb.(*hotScheduler).conf.ReadPriorities = []string{utils.CPUPriority, utils.BytePriority}
+
+	tc.AddRegionStore(1, 20)
+	tc.AddRegionStore(2, 20)
+	tc.AddRegionStore(3, 20)
+
+	// The read bytes of the stores are the same, but the coprocessor requests of the regions on store 1 read little data and are expensive.
+	reportInterval := uint64(utils.StoreHeartBeatReportInterval)
+	for storeID := uint64(1); storeID <= 3; storeID++ {
+		tc.UpdateStorageReadBytes(storeID, 20*units.KiB*reportInterval)
+	}
+	tc.AddRegionLeaderWithReadCPU(1, 1, 0, 200, reportInterval, []uint64{2, 3})
+	tc.AddRegionLeaderWithReadCPU(2, 1, 0, 200, reportInterval, []uint64{2, 3})
+	tc.AddRegionLeaderWithReadCPU(3, 1, 0, 200, reportInterval, []uint64{2, 3})
+	tc.AddRegionLeaderWithReadCPU(4, 2, 20*units.KiB*reportInterval, 50, reportInterval, []uint64{1, 3})
+	tc.AddRegionLeaderWithReadCPU(5, 3, 20*units.KiB*reportInterval, 50, reportInterval, []uint64{1, 2})
+
+	// Only the leader reports the cpu usage.
+	stat := tc.GetHotPeerStat(utils.Read, 1, 1)
+	re.NotNil(stat)
+	re.Equal(200.0, stat.GetLoad(utils.CPUDim))
+	re.Nil(tc.GetHotPeerStat(utils.Read, 1, 2))
+
+	// The read bytes of the stores are not checked since they are balanced.
+	hb.(*hotScheduler).conf.StrictPickingStore = false
+	for _, version := range []string{"v2", "v1"} {
+		hb.(*hotScheduler).conf.RankFormulaVersion = version
+		for range 20 {
+			clearPendingInfluence(hb.(*hotScheduler))
+			ops, _ := hb.Schedule(tc, false)
+			re.Len(ops, 1)
+			re.Equal(uint64(1), ops[0].Step(0).(operator.TransferLeader).FromStore)
+			re.Contains([]uint64{2, 3}, ops[0].Step(0).(operator.TransferLeader).ToStore)
+		}
+	}
+
+	// The read bytes are balanced, so there is nothing to do with the default priorities.
+	hb.(*hotScheduler).conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+	clearPendingInfluence(hb.(*hotScheduler))
+	ops, _ := hb.Schedule(tc, false)
+	re.Empty(ops)
+}
+
+func TestHotReadRegionScheduleWithForecast(t *testing.T) {
+	re := require.New(t)
+
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	sche, err := CreateScheduler(readType, oc, storage.NewStorageWithMemoryBackend(), nil)
+	re.NoError(err)
+	hb := sche.(*hotScheduler)
+	hb.conf.setSrcToleranceRatio(1)
+	hb.conf.setDstToleranceRatio(1)
+	hb.conf.setHistorySampleDuration(0)
+	hb.conf.setStrictPickingStore(false)
+	hb.conf.ReadPriorities = []string{utils.BytePriority, utils.KeyPriority}
+
+	tc.AddRegionStore(1, 20)
+	tc.AddRegionStore(2, 20)
+	tc.AddRegionStore(3, 20)
+	// The read load of the stores is balanced now.
+	reportInterval := uint64(utils.StoreHeartBeatReportInterval)
+	for storeID := uint64(1); storeID <= 3; storeID++ {
+		tc.UpdateStorageReadStats(storeID, 10*units.MiB*reportInterval, 10*units.MiB*reportInterval)
+	}
+	tc.AddRegionLeaderWithReadInfo(1, 1, 2*units.MiB*reportInterval, 2*units.MiB*reportInterval, 0, reportInterval, []uint64{2, 3})
+	tc.AddRegionLeaderWithReadInfo(2, 1, 2*units.MiB*reportInterval, 2*units.MiB*reportInterval, 0, reportInterval, []uint64{2, 3})
+	tc.AddRegionLeaderWithReadInfo(3, 2, 2*units.MiB*reportInterval, 2*units.MiB*reportInterval, 0, reportInterval, []uint64{1, 3})
+	tc.AddRegionLeaderWithReadInfo(4, 3, 2*units.MiB*reportInterval, 2*units.MiB*reportInterval, 0, reportInterval, []uint64{1, 2})
+	ops, _ := hb.Schedule(tc, false)
+	re.Empty(ops)
+	re.Nil(hb.forecast)
+
+	// Store 1 was hot at the same time yesterday, so it is expected to be hot soon.
+	hb.conf.setForecastModel(statistics.PeriodicForecastModel)
+	hb.updateForecastConfig(hb.conf.getForecastModel(), hb.conf.getForecastConfig(), hb.conf.getForecastHorizon())
+	re.NotNil(hb.forecast)
+	forecaster := hb.forecast.forecaster
+	yesterday := time.Now().Add(hb.conf.getForecastHorizon()).AddDate(0, 0, -1)
+	for storeID := uint64(1); storeID <= 3; storeID++ {
+		load := 10.0 * units.MiB
+		if storeID == 1 {
+			load = 30.0 * units.MiB
+		}
+		for _, kind := range []constant.ResourceKind{constant.LeaderKind, constant.RegionKind} {
+			forecaster.Observe(storeID, utils.Read, kind, yesterday, statistics.Loads{load, load})
+		}
+	}
+	for range 10 {
+		clearPendingInfluence(hb)
+		ops, _ = hb.Schedule(tc, false)
+		re.Len(ops, 1)
+		re.Contains([]uint64{1, 2}, ops[0].RegionID())
+		// The load is moved out of store 1.
+		for i := range ops[0].Len() {
+			switch step := ops[0].Step(i).(type) {
+			case operator.TransferLeader:
+				re.Equal(uint64(1), step.FromStore)
+			case operator.RemovePeer:
+				re.Equal(uint64(1), step.FromStore)
+			}
+		}
+	}
+	// The forecast loads are only used by the balance solver, the observed loads are not changed.
+	details := hb.stLoadInfos[readLeader]
+	re.Less(details[1].LoadPred.Current.Loads[utils.ByteDim], 30.0*units.MiB)
+	re.Equal(30.0*units.MiB, hb.stForecastInfos[readLeader][1].LoadPred.Current.Loads[utils.ByteDim])
+
+	// The forecast is verified with the observed load when the time comes.
+	re.Len(hb.forecast.predictions[readLeader][1], 1)
+	hb.forecast.apply(tc, details, utils.Read, constant.LeaderKind, time.Now().Add(hb.conf.getForecastHorizon()+time.Second))
+	re.Empty(hb.forecast.predictions[readLeader])
+	re.Contains(hb.forecast.verified[readLeader], uint64(1))
+	// The metrics of the removed store are deleted.
+	remained := make(map[uint64]*statistics.StoreLoadDetail)
+	for id, detail := range details {
+		if id != 1 {
+			remained[id] = detail
+		}
+	}
+	hb.forecast.apply(tc, remained, utils.Read, constant.LeaderKind, time.Now())
+	re.NotContains(hb.forecast.verified[readLeader], uint64(1))
+
+	// Disable the forecast.
+	hb.conf.setForecastModel(noneForecastModel)
+	clearPendingInfluence(hb)
+	ops, _ = hb.Schedule(tc, false)
+	re.Empty(ops)
+	re.Nil(hb.forecast)
+	re.Nil(hb.stForecastInfos[readLeader])
+}
+
+type mockHistoryHotRegionIterator []*storage.HistoryHotRegion
+
+func (it *mockHistoryHotRegionIterator) Next() (*storage.HistoryHotRegion, error) {
+	if len(*it) == 0 {
+		return nil, nil
+	}
+	region := (*it)[0]
+	*it = (*it)[1:]
+	return region, nil
+}
+
+func (*mockHistoryHotRegionIterator) Release() {}
+
+func TestHotLoadForecastObserveHistory(t *testing.T) {
+	re := require.New(t)
+	cfg := statistics.LoadForecastConfig{Period: 24 * time.Hour, Slot: statistics.DefaultForecastSlot, Seasons: 7}
+	f := newHotLoadForecast(statistics.PeriodicForecastModel, cfg, 0)
+	now := time.Now().Truncate(cfg.Slot)
+	yesterday := now.AddDate(0, 0, -1)
+	newRegion := func(ts time.Time, regionID, storeID uint64, isLeader bool, bytes float64) *storage.HistoryHotRegion {
+		return &storage.HistoryHotRegion{
+			UpdateTime: ts.UnixMilli(),
+			RegionID:   regionID,
+			StoreID:    storeID,
+			IsLeader:   isLeader,
+			FlowBytes:  bytes,
+			KeyRate:    bytes / 10,
+		}
+	}
+	iter := mockHistoryHotRegionIterator{
+		newRegion(yesterday, 1, 1, true, 100),
+		newRegion(yesterday, 2, 1, false, 200),
+		newRegion(yesterday, 2, 2, true, 200),
+		// The latest load of a hot peer in a slot is used.
+		newRegion(yesterday.Add(time.Second), 1, 1, true, 300),
+	}
+	// The history hot regions are read up to the limit.
+	limited := iter
+	samples, records, err := f.collectHistory(&limited, utils.Write, 2)
+	re.NoError(err)
+	re.Equal(2, records)
+	re.Len(samples, 2)
+	samples, records, err = f.collectHistory(&iter, utils.Write, maxForecastSeedRecords)
+	re.NoError(err)
+	re.Equal(4, records)
+	// The samples are observed after they are loaded.
+	f.seedCh = make(chan []forecastSample, 1)
+	f.observeSeed()
+	re.NotNil(f.seedCh)
+	f.seedCh <- samples
+	f.observeSeed()
+	re.Nil(f.seedCh)
+
+	loads, ok := f.forecaster.Forecast(1, utils.Write, constant.RegionKind, now)
+	re.True(ok)
+	re.Equal(statistics.Loads{500, 50}, loads)
+	loads, ok = f.forecaster.Forecast(1, utils.Write, constant.LeaderKind, now)
+	re.True(ok)
+	re.Equal(statistics.Loads{300, 30}, loads)
+	loads, ok = f.forecaster.Forecast(2, utils.Write, constant.LeaderKind, now)
+	re.True(ok)
+	re.Equal(statistics.Loads{200, 20}, loads)
+	_, ok = f.forecaster.Forecast(1, utils.Read, constant.RegionKind, now)
+	re.False(ok)
+
+	// The forecast is not seeded if the cluster doesn't persist the history hot regions.
+	cancel, _, tc, _ := prepareSchedulersTest()
+	defer cancel()
+	f = newHotLoadForecast(statistics.PeriodicForecastModel, cfg, 0)
+	f.seed(tc, now)
+	re.True(f.seeded)
+	re.Nil(f.seedCh)
+}
+
 func TestHotReadRegionScheduleWithKeyRate(t *testing.T) {
 	re := require.New(t)
 
@@ -2385,6 +2760,19 @@ func TestConfigValidation(t *testing.T) {
 	err = hc.validateLocked()
 	re.Error(err)
 
+	// cpu is only allowed to be set in priorities for read-priorities
+	hc = initHotRegionScheduleConfig()
+	hc.ReadPriorities = []string{"cpu", "byte"}
+	err = hc.validateLocked()
+	re.NoError(err)
+	hc.WriteLeaderPriorities = []string{"cpu", "byte"}
+	err = hc.validateLocked()
+	re.Error(err)
+	hc = initHotRegionScheduleConfig()
+	hc.WritePeerPriorities = []string{"byte", "cpu"}
+	err = hc.validateLocked()
+	re.Error(err)
+
 	// query is not allowed to be set in priorities for write-peer-priorities
 	hc = initHotRegionScheduleConfig()
 	hc.WritePeerPriorities = []string{"query", "byte"}
@@ -2447,6 +2835,28 @@ func TestConfigValidation(t *testing.T) {
 	hc.SplitThresholds = 1.1
 	err = hc.validateLocked()
 	re.Error(err)
+
+	// forecast
+	hc = initHotRegionScheduleConfig()
+	re.Equal(noneForecastModel, hc.getForecastModel())
+	hc.ForecastModel = statistics.PeriodicForecastModel
+	err = hc.validateLocked()
+	re.NoError(err)
+	hc.ForecastModel = "unknown"
+	err = hc.validateLocked()
+	re.Error(err)
+	hc = initHotRegionScheduleConfig()
+	hc.ForecastPeriod = typeutil.NewDuration(time.Minute)
+	err = hc.validateLocked()
+	re.Error(err)
+	hc = initHotRegionScheduleConfig()
+	hc.ForecastSeasons = 0
+	err = hc.validateLocked()
+	re.Error(err)
+	hc = initHotRegionScheduleConfig()
+	hc.ForecastHorizon = typeutil.NewDuration(25 * time.Hour)
+	err = hc.validateLocked()
+	re.Error(err)
 }
 
 // ref https://github.com/tikv/pd/issues/5701
@@ -147,6 +147,23 @@ var (
 			Help:      "Pending influence sum of store in hot region scheduler.",
 		}, []string{"store", "rw", "dim"})
 
+	hotForecastStoreLoadGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "pd",
+			Subsystem: "scheduler",
+			Name:      "hot_forecast_store_load",
+			Help:      "The predicted and observed store load of the hot region scheduler forecast.",
+		}, []string{"store", "type", "dim", "source"})
+
+	hotForecastErrorRatioHist = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pd",
+			Subsystem: "scheduler",
+			Name:      "hot_forecast_error_ratio",
+			Help:      "Bucketed histogram of the relative error between the predicted and observed store load.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.2, 0.3, 0.5, 1, 2, 5},
+		}, []string{"type", "dim"})
+
 	ruleStatusGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "pd",
@@ -188,6 +205,8 @@ func init() {
 	prometheus.MustRegister(storeSlowTrendActionStatusGauge)
 	prometheus.MustRegister(storeSlowTrendMiscGauge)
 	prometheus.MustRegister(HotPendingSum)
+	prometheus.MustRegister(hotForecastStoreLoadGauge)
+	prometheus.MustRegister(hotForecastErrorRatioHist)
 	prometheus.MustRegister(balanceRangeGauge)
 	prometheus.MustRegister(balanceRangeJobGauge)
 }
@@ -248,6 +267,10 @@ func balanceRangeCounterWithEvent(event string) prometheus.Counter {
 	return schedulerCounter.WithLabelValues(types.BalanceRangeScheduler.String(), event)
 }
 
+func balanceCostCounterWithEvent(event string) prometheus.Counter {
+	return schedulerCounter.WithLabelValues(types.BalanceCostScheduler.String(), event)
+}
+
 // WithLabelValues is a heavy operation, define variable to avoid call it every time.
 var (
 	balanceLeaderScheduleCounter         = balanceLeaderCounterWithEvent("schedule")
@@ -267,6 +290,7 @@ var (
 	balanceRegionSkipCounter          = balanceRegionCounterWithEvent("skip")
 	balanceRegionCreateOpFailCounter  = balanceRegionCounterWithEvent("create-operator-fail")
 	balanceRegionNoReplacementCounter = balanceRegionCounterWithEvent("no-replacement")
+	balanceRegionTiFlashLimitCounter  = balanceRegionCounterWithEvent("tiflash-exceed-limit")
 
 	evictLeaderCounter              = evictLeaderCounterWithEvent("schedule")
 	evictLeaderNoLeaderCounter      = evictLeaderCounterWithEvent("no-leader")
@@ -291,6 +315,7 @@ var (
 	hotSchedulerNoRegionCounter             = hotRegionCounterWithEvent("no_region")
 	hotSchedulerUnhealthyReplicaCounter     = hotRegionCounterWithEvent("unhealthy_replica")
 	hotSchedulerAbnormalReplicaCounter      = hotRegionCounterWithEvent("abnormal_replica")
+	hotSchedulerLabelDeniedCounter          = hotRegionCounterWithEvent("label_denied")
 	hotSchedulerCreateOperatorFailedCounter = hotRegionCounterWithEvent("create_operator_failed")
 	hotSchedulerNewOperatorCounter          = hotRegionCounterWithEvent("new_operator")
 	hotSchedulerSnapshotSenderLimitCounter  = hotRegionCounterWithEvent("snapshot_sender_limit")
@@ -303,6 +328,7 @@ var (
 	hotSchedulerSplitSuccessCounter               = hotRegionCounterWithEvent("split_success")
 	hotSchedulerNeedSplitBeforeScheduleCounter    = hotRegionCounterWithEvent("need_split_before_move_peer")
 	hotSchedulerRegionTooHotNeedSplitCounter      = hotRegionCounterWithEvent("region_is_too_hot_need_split")
+	hotSchedulerSplitSiblingAvoidedCounter        = hotRegionCounterWithEvent("split_sibling_avoided")
 	// hot region counter related with the move peer
 	hotSchedulerMoveLeaderCounter     = hotRegionCounterWithEvent(moveLeader.String())
 	hotSchedulerMovePeerCounter       = hotRegionCounterWithEvent(movePeer.String())
@@ -316,6 +342,7 @@ var (
 	writeSkipKeyDimUniformStoreCounter   = hotRegionCounterWithEvent("write-skip-key-uniform-store")
 	readSkipQueryDimUniformStoreCounter  = hotRegionCounterWithEvent("read-skip-query-uniform-store")
 	writeSkipQueryDimUniformStoreCounter = hotRegionCounterWithEvent("write-skip-query-uniform-store")
+	readSkipCPUDimUniformStoreCounter    = hotRegionCounterWithEvent("read-skip-cpu-uniform-store")
 	pendingOpFailsStoreCounter           = hotRegionCounterWithEvent("pending-op-fails")
 
 	labelCounter            = labelCounterWithEvent("schedule")
@@ -369,4 +396,13 @@ var (
 	balanceRangeNoJobCounter         = balanceRangeCounterWithEvent("no-job")
 	balanceRangeBalancedCounter      = balanceRangeCounterWithEvent("balanced")
 	balancePersistFailedCounter      = balanceRangeCounterWithEvent("persist-failed")
+
+	balanceCostScheduleCounter      = balanceCostCounterWithEvent("schedule")
+	balanceCostNewOpCounter         = balanceCostCounterWithEvent("new-operator")
+	balanceCostNoRegionCounter      = balanceCostCounterWithEvent("no-region")
+	balanceCostHotCounter           = balanceCostCounterWithEvent("region-hot")
+	balanceCostNoLeaderCounter      = balanceCostCounterWithEvent("no-leader")
+	balanceCostSkipCounter          = balanceCostCounterWithEvent("skip")
+	balanceCostCreateOpFailCounter  = balanceCostCounterWithEvent("create-operator-fail")
+	balanceCostNoReplacementCounter = balanceCostCounterWithEvent("no-replacement")
 )
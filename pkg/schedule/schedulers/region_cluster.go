@@ -0,0 +1,65 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"github.com/tikv/pd/pkg/core"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/utils/keyutil"
+)
+
+// regionCluster isolates the cluster by region. The schedulers can only pick the
+// given region, while the stores are the same as the whole cluster, so it is used
+// to explain what the schedulers would do with the region.
+type regionCluster struct {
+	*cacheCluster
+	subCluster *core.BasicCluster // Only contains the given region.
+}
+
+// newRegionCluster creates a cluster in which only the given region can be picked.
+func newRegionCluster(cluster sche.SchedulerCluster, region *core.RegionInfo) *regionCluster {
+	subCluster := core.NewBasicCluster()
+	origin, overlaps, rangeChanged := subCluster.SetRegion(region)
+	subCluster.UpdateSubTree(region, origin, overlaps, rangeChanged)
+	return &regionCluster{
+		cacheCluster: newCacheCluster(cluster),
+		subCluster:   subCluster,
+	}
+}
+
+// RandFollowerRegions returns the region if it has a follower on the store.
+func (r *regionCluster) RandFollowerRegions(storeID uint64, ranges []keyutil.KeyRange) []*core.RegionInfo {
+	return r.subCluster.RandFollowerRegions(storeID, ranges)
+}
+
+// RandLeaderRegions returns the region if it has the leader on the store.
+func (r *regionCluster) RandLeaderRegions(storeID uint64, ranges []keyutil.KeyRange) []*core.RegionInfo {
+	return r.subCluster.RandLeaderRegions(storeID, ranges)
+}
+
+// RandLearnerRegions returns the region if it has a learner on the store.
+func (r *regionCluster) RandLearnerRegions(storeID uint64, ranges []keyutil.KeyRange) []*core.RegionInfo {
+	return r.subCluster.RandLearnerRegions(storeID, ranges)
+}
+
+// RandWitnessRegions returns the region if it has a witness on the store.
+func (r *regionCluster) RandWitnessRegions(storeID uint64, ranges []keyutil.KeyRange) []*core.RegionInfo {
+	return r.subCluster.RandWitnessRegions(storeID, ranges)
+}
+
+// RandPendingRegions returns the region if it has a pending peer on the store.
+func (r *regionCluster) RandPendingRegions(storeID uint64, ranges []keyutil.KeyRange) []*core.RegionInfo {
+	return r.subCluster.RandPendingRegions(storeID, ranges)
+}
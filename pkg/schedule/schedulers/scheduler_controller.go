@@ -16,8 +16,10 @@ package schedulers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,11 +30,13 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	sche "github.com/tikv/pd/pkg/schedule/core"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/plan"
 	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
 	"github.com/tikv/pd/pkg/storage/endpoint"
 	"github.com/tikv/pd/pkg/utils/logutil"
 	"github.com/tikv/pd/pkg/utils/syncutil"
@@ -58,10 +62,14 @@ type Controller struct {
 	// which will only be initialized and used in the microservice env now.
 	schedulerHandlers map[string]http.Handler
 	opController      *operator.Controller
+	timeWindows       *sc.TimeWindows
+	// shadows are the names of the schedulers running in shadow mode, which are
+	// persisted so that the mode survives the restart and the leader change.
+	shadows map[string]struct{}
 }
 
 // NewController creates a scheduler controller.
-func NewController(ctx context.Context, cluster sche.SchedulerCluster, storage endpoint.ConfigStorage, opController *operator.Controller) *Controller {
+func NewController(ctx context.Context, cluster sche.SchedulerCluster, storage endpoint.ConfigStorage, opController *operator.Controller, timeWindows *sc.TimeWindows) *Controller {
 	return &Controller{
 		ctx:               ctx,
 		cluster:           cluster,
@@ -69,6 +77,8 @@ func NewController(ctx context.Context, cluster sche.SchedulerCluster, storage e
 		schedulers:        make(map[string]*ScheduleController),
 		schedulerHandlers: make(map[string]http.Handler),
 		opController:      opController,
+		timeWindows:       timeWindows,
+		shadows:           make(map[string]struct{}),
 	}
 }
 
@@ -195,7 +205,7 @@ func (c *Controller) RemoveSchedulerHandler(name string) error {
 
 	s.(Scheduler).CleanConfig(c.cluster)
 	delete(c.schedulerHandlers, name)
-	return nil
+	return c.removeShadowLocked(name)
 }
 
 // AddScheduler adds a scheduler.
@@ -209,6 +219,9 @@ func (c *Controller) AddScheduler(scheduler Scheduler, args ...string) error {
 	}
 
 	s := NewScheduleController(c.ctx, c.cluster, c.opController, scheduler)
+	s.timeWindows = c.timeWindows
+	_, shadow := c.shadows[name]
+	s.SetShadow(shadow)
 	if err := s.PrepareConfig(c.cluster); err != nil {
 		return err
 	}
@@ -261,7 +274,7 @@ func (c *Controller) RemoveScheduler(name string) error {
 	s.Stop()
 	schedulerStatusGauge.DeleteLabelValues(name, "allow")
 	delete(c.schedulers, name)
-	return nil
+	return c.removeShadowLocked(name)
 }
 
 // PauseOrResumeScheduler pauses or resumes a scheduler by name.
@@ -295,6 +308,121 @@ func (c *Controller) PauseOrResumeScheduler(name string, t int64) error {
 	return err
 }
 
+// SetSchedulerShadow enables or disables the shadow mode of a scheduler by name.
+// The mode is persisted, and the scheduling service follows it by watching.
+func (c *Controller) SetSchedulerShadow(name string, shadow bool) error {
+	c.Lock()
+	defer c.Unlock()
+	if c.cluster == nil {
+		return errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	s, ok := c.schedulers[name]
+	if _, exist := c.schedulerHandlers[name]; !ok && !exist {
+		return errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	_, old := c.shadows[name]
+	if shadow {
+		c.shadows[name] = struct{}{}
+	} else {
+		delete(c.shadows, name)
+	}
+	if err := c.persistShadowsLocked(); err != nil {
+		if old {
+			c.shadows[name] = struct{}{}
+		} else {
+			delete(c.shadows, name)
+		}
+		return err
+	}
+	if ok {
+		s.SetShadow(shadow)
+	}
+	return nil
+}
+
+// IsSchedulerShadow returns whether a scheduler is running in shadow mode.
+func (c *Controller) IsSchedulerShadow(name string) (bool, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return false, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	_, ok := c.schedulers[name]
+	if _, exist := c.schedulerHandlers[name]; !ok && !exist {
+		return false, errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	_, shadow := c.shadows[name]
+	return shadow, nil
+}
+
+// ReloadShadowSchedulers reloads the schedulers running in shadow mode from the storage.
+func (c *Controller) ReloadShadowSchedulers() error {
+	data, err := c.storage.LoadShadowSchedulers()
+	if err != nil {
+		return err
+	}
+	var names []string
+	if len(data) > 0 {
+		if err := json.Unmarshal([]byte(data), &names); err != nil {
+			return errs.ErrJSONUnmarshal.Wrap(err).GenWithStackByCause()
+		}
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.shadows = make(map[string]struct{}, len(names))
+	for _, name := range names {
+		c.shadows[name] = struct{}{}
+	}
+	for name, s := range c.schedulers {
+		_, shadow := c.shadows[name]
+		if shadow != s.IsShadow() {
+			s.SetShadow(shadow)
+		}
+	}
+	return nil
+}
+
+// removeShadowLocked drops the shadow mode of a removed scheduler, so that it
+// is not in shadow mode once it is added again.
+func (c *Controller) removeShadowLocked(name string) error {
+	if _, ok := c.shadows[name]; !ok {
+		return nil
+	}
+	delete(c.shadows, name)
+	if err := c.persistShadowsLocked(); err != nil {
+		log.Error("can not persist the shadow schedulers", zap.String("scheduler-name", name), errs.ZapError(err))
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) persistShadowsLocked() error {
+	names := make([]string, 0, len(c.shadows))
+	for name := range c.shadows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	data, err := json.Marshal(names)
+	if err != nil {
+		return errs.ErrJSONMarshal.Wrap(err).GenWithStackByCause()
+	}
+	return c.storage.SaveShadowSchedulers(data)
+}
+
+// GetSchedulerShadowResults returns the shadow results of a scheduler by name.
+func (c *Controller) GetSchedulerShadowResults(name string) ([]*ShadowResult, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return nil, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	s, ok := c.schedulers[name]
+	if !ok {
+		return nil, errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	return s.GetShadowRecorder().GetResults(), nil
+}
+
 // ReloadSchedulerConfig reloads a scheduler's config if it exists.
 func (c *Controller) ReloadSchedulerConfig(name string) error {
 	if exist, _ := c.IsSchedulerExisted(name); !exist {
@@ -317,6 +445,25 @@ func (c *Controller) IsSchedulerAllowed(name string) (bool, error) {
 	return s.AllowSchedule(false), nil
 }
 
+// GetTimeWindows returns the scheduling time windows of the schedulers and checkers.
+func (c *Controller) GetTimeWindows() *sc.TimeWindows {
+	return c.timeWindows
+}
+
+// IsSchedulerInTimeWindow returns whether a scheduler is in its scheduling time windows.
+func (c *Controller) IsSchedulerInTimeWindow(name string) (bool, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if c.cluster == nil {
+		return false, errs.ErrNotBootstrapped.FastGenByArgs()
+	}
+	s, ok := c.schedulers[name]
+	if !ok {
+		return false, errs.ErrSchedulerNotFound.FastGenByArgs()
+	}
+	return s.IsInTimeWindow(), nil
+}
+
 // IsSchedulerPaused returns whether a scheduler is paused.
 func (c *Controller) IsSchedulerPaused(name string) (bool, error) {
 	c.RLock()
@@ -375,8 +522,20 @@ func (c *Controller) runScheduler(s *ScheduleController) {
 				continue
 			}
 			if op := s.Schedule(diagnosable); len(op) > 0 {
-				added := c.opController.AddWaitingOperator(op...)
-				log.Debug("add operator", zap.Int("added", added), zap.Int("total", len(op)), zap.String("scheduler", s.GetName()))
+				if s.IsShadow() {
+					// In shadow mode, the operators are only recorded for evaluation. They are
+					// canceled then, so that the pending influence of them is dropped by the
+					// schedulers tracking it, like the hot region scheduler.
+					s.shadowRecorder.Record(s.cluster, op)
+					for _, o := range op {
+						_ = o.Cancel(operator.Shadowed)
+					}
+					schedulerCounter.WithLabelValues(s.GetName(), "shadow-operator").Add(float64(len(op)))
+					log.Debug("record shadow operator", zap.Int("total", len(op)), zap.String("scheduler", s.GetName()))
+				} else {
+					added := c.opController.AddWaitingOperator(op...)
+					log.Debug("add operator", zap.Int("added", added), zap.Int("total", len(op)), zap.String("scheduler", s.GetName()))
+				}
 			}
 			// Note: we reset the ticker here to support updating configuration dynamically.
 			ticker.Reset(s.GetInterval())
@@ -449,6 +608,15 @@ type ScheduleController struct {
 	delayAt            int64
 	delayUntil         int64
 	diagnosticRecorder *DiagnosticRecorder
+	// shadow means the operators created by the scheduler are only recorded
+	// by the shadowRecorder rather than being added to the operator controller.
+	// The scheduler still runs as usual, so the state kept by it across rounds,
+	// like whether the hot region scheduler searches the revert regions, and the
+	// scheduler metrics are updated by the shadow operators as well.
+	shadow         atomic.Bool
+	shadowRecorder *ShadowRecorder
+	// timeWindows restricts the scheduler to run only in its scheduling time windows.
+	timeWindows *sc.TimeWindows
 }
 
 // NewScheduleController creates a new ScheduleController.
@@ -462,6 +630,7 @@ func NewScheduleController(ctx context.Context, cluster sche.SchedulerCluster, o
 		ctx:                ctx,
 		cancel:             cancel,
 		diagnosticRecorder: NewDiagnosticRecorder(s.GetType(), cluster.GetSchedulerConfig()),
+		shadowRecorder:     NewShadowRecorder(),
 	}
 }
 
@@ -520,6 +689,9 @@ retry:
 		if len(ops) == 0 {
 			continue
 		}
+		for _, op := range ops {
+			op.SetSource(s.GetName())
+		}
 		return ops
 	}
 	s.nextInterval = s.GetNextInterval(s.nextInterval)
@@ -532,6 +704,30 @@ func (s *ScheduleController) DiagnoseDryRun() ([]*operator.Operator, []plan.Plan
 	return s.Scheduler.Schedule(cacheCluster, true)
 }
 
+// ExplainRegion returns the operators and plans of the scheduler for the region.
+// A copy of the scheduler created from its config is run in dry run against the
+// cluster in which only the region can be picked, so the scheduler is not changed.
+func (s *ScheduleController) ExplainRegion(region *core.RegionInfo) ([]*operator.Operator, []plan.Plan, error) {
+	data, err := s.EncodeConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	scheduler, err := CreateScheduler(s.GetType(), s.opController, storage.NewStorageWithMemoryBackend(), ConfigJSONDecoder(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	ops, plans := scheduler.Schedule(newRegionCluster(s.cluster, region), true)
+	// The schedulers which do not pick the regions randomly, such as the hot
+	// region scheduler, may create operators for the other regions.
+	regionOps := ops[:0]
+	for _, op := range ops {
+		if op.RegionID() == region.GetID() {
+			regionOps = append(regionOps, op)
+		}
+	}
+	return regionOps, plans, nil
+}
+
 // GetInterval returns the interval of scheduling for a scheduler.
 func (s *ScheduleController) GetInterval() time.Duration {
 	return s.nextInterval
@@ -562,9 +758,23 @@ func (s *ScheduleController) AllowSchedule(diagnosable bool) bool {
 		}
 		return false
 	}
+	if !s.IsInTimeWindow() {
+		if diagnosable {
+			s.diagnosticRecorder.SetResultFromStatus(OutOfWindow)
+		}
+		return false
+	}
 	return true
 }
 
+// IsInTimeWindow returns if a scheduler is in its scheduling time windows.
+func (s *ScheduleController) IsInTimeWindow() bool {
+	if s.timeWindows == nil {
+		return true
+	}
+	return s.timeWindows.IsAllowed(s.GetName(), time.Now())
+}
+
 // IsPaused returns if a scheduler is paused.
 func (s *ScheduleController) IsPaused() bool {
 	delayUntil := atomic.LoadInt64(&s.delayUntil)
@@ -593,6 +803,25 @@ func (s *ScheduleController) SetDelay(delayAt, delayUntil int64) {
 	atomic.StoreInt64(&s.delayUntil, delayUntil)
 }
 
+// IsShadow returns if a scheduler is running in shadow mode.
+func (s *ScheduleController) IsShadow() bool {
+	return s.shadow.Load()
+}
+
+// SetShadow enables or disables the shadow mode of a scheduler. The recorded
+// shadow results are cleaned when the shadow mode is disabled.
+func (s *ScheduleController) SetShadow(shadow bool) {
+	s.shadow.Store(shadow)
+	if !shadow {
+		s.shadowRecorder.Clean()
+	}
+}
+
+// GetShadowRecorder returns the shadow recorder of a scheduler.
+func (s *ScheduleController) GetShadowRecorder() *ShadowRecorder {
+	return s.shadowRecorder
+}
+
 // GetDiagnosticRecorder returns the diagnostic recorder of a scheduler.
 func (s *ScheduleController) GetDiagnosticRecorder() *DiagnosticRecorder {
 	return s.diagnosticRecorder
@@ -18,6 +18,7 @@ import (
 	"context"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
@@ -559,3 +560,72 @@ func TestDisabled(t *testing.T) {
 		re.True(scheduling.IsDisable())
 	}
 }
+
+func TestSchedulerTimeWindow(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	store := storage.NewStorageWithMemoryBackend()
+	timeWindows := config.NewTimeWindows(store)
+	sc := NewController(context.Background(), tc, store, oc, timeWindows)
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, store, ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	re.NoError(sc.AddScheduler(sb))
+	defer func() {
+		re.NoError(sc.RemoveScheduler(sb.GetName()))
+	}()
+	s := sc.GetScheduler(sb.GetName())
+	re.True(s.AllowSchedule(false))
+
+	// Set a time window which does not contain the current time.
+	now := time.Now().UTC()
+	window := &config.TimeWindow{
+		Start: now.Add(2 * time.Hour).Format("15:04"),
+		End:   now.Add(3 * time.Hour).Format("15:04"),
+	}
+	re.NoError(timeWindows.Set(sb.GetName(), []*config.TimeWindow{window}))
+	re.False(s.AllowSchedule(true))
+	re.Equal(OutOfWindow, s.GetDiagnosticRecorder().GetLastResult().Status)
+	inWindow, err := sc.IsSchedulerInTimeWindow(sb.GetName())
+	re.NoError(err)
+	re.False(inWindow)
+
+	// The time windows are reloaded from the storage.
+	re.NoError(config.NewTimeWindows(store).Set(sb.GetName(), []*config.TimeWindow{{Start: "00:00", End: "00:00"}}))
+	re.NoError(sc.GetTimeWindows().Reload())
+	re.True(s.AllowSchedule(false))
+}
+
+func TestExplainRegion(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetEnablePlacementRules(false)
+	tc.SetMaxReplicasWithLabel(false, 1)
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	tc.AddRegionStore(1, 6)
+	tc.AddRegionStore(2, 8)
+	tc.AddRegionStore(3, 8)
+	tc.AddRegionStore(4, 16)
+	tc.AddLeaderRegion(1, 4)
+	tc.AddLeaderRegion(2, 1)
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	s := NewScheduleController(ctx, tc, oc, sb)
+
+	// Only the explained region is picked.
+	for range 10 {
+		ops, _, err := s.ExplainRegion(tc.GetRegion(1))
+		re.NoError(err)
+		re.Len(ops, 1)
+		re.Equal(uint64(1), ops[0].RegionID())
+	}
+	// The region on the store with the fewest regions is not moved.
+	ops, plans, err := s.ExplainRegion(tc.GetRegion(2))
+	re.NoError(err)
+	re.Empty(ops)
+	re.NotEmpty(plans)
+	re.Nil(oc.GetOperator(1))
+}
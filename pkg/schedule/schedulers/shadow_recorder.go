@@ -0,0 +1,131 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/tikv/pd/pkg/cache"
+	"github.com/tikv/pd/pkg/core/constant"
+	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/operator"
+)
+
+const maxShadowResultNum = 64
+
+// ShadowStoreScore is the score of a store before and after applying the
+// influence of the shadow operators.
+type ShadowStoreScore struct {
+	LeaderScore          float64 `json:"leader-score"`
+	RegionScore          float64 `json:"region-score"`
+	ProjectedLeaderScore float64 `json:"projected-leader-score"`
+	ProjectedRegionScore float64 `json:"projected-region-score"`
+}
+
+// ShadowStoreInfluence is the estimated influence on a store.
+type ShadowStoreInfluence struct {
+	RegionSize  int64 `json:"region-size"`
+	RegionCount int64 `json:"region-count"`
+	LeaderSize  int64 `json:"leader-size"`
+	LeaderCount int64 `json:"leader-count"`
+}
+
+// ShadowResult is the result of one round of scheduling in shadow mode.
+type ShadowResult struct {
+	Timestamp   uint64                           `json:"timestamp"`
+	Operators   []*operator.OpObject             `json:"operators"`
+	Influence   map[uint64]*ShadowStoreInfluence `json:"influence"`
+	StoreScores map[uint64]*ShadowStoreScore     `json:"store-scores"`
+}
+
+// ShadowRecorder records the operators generated by a scheduler in shadow mode.
+// The operators are never added to the operator controller.
+type ShadowRecorder struct {
+	results *cache.FIFO
+	// seq is the key of the results in the FIFO, since several rounds may be
+	// recorded in the same second.
+	seq atomic.Uint64
+}
+
+// NewShadowRecorder creates a new ShadowRecorder.
+func NewShadowRecorder() *ShadowRecorder {
+	return &ShadowRecorder{
+		results: cache.NewFIFO(maxShadowResultNum),
+	}
+}
+
+// Record records the operators and their estimated influence on the cluster.
+func (r *ShadowRecorder) Record(cluster sche.SchedulerCluster, ops []*operator.Operator) *ShadowResult {
+	result := r.analyze(cluster, ops, uint64(time.Now().Unix()))
+	r.results.Put(r.seq.Add(1), result)
+	return result
+}
+
+// GetResults returns the recorded results, from the oldest to the newest.
+func (r *ShadowRecorder) GetResults() []*ShadowResult {
+	items := r.results.Elems()
+	results := make([]*ShadowResult, 0, len(items))
+	for _, item := range items {
+		results = append(results, item.Value.(*ShadowResult))
+	}
+	return results
+}
+
+// Clean removes all the recorded results.
+func (r *ShadowRecorder) Clean() {
+	for r.results.Len() > 0 {
+		r.results.Remove()
+	}
+}
+
+func (*ShadowRecorder) analyze(cluster sche.SchedulerCluster, ops []*operator.Operator, ts uint64) *ShadowResult {
+	result := &ShadowResult{
+		Timestamp:   ts,
+		Operators:   make([]*operator.OpObject, 0, len(ops)),
+		Influence:   make(map[uint64]*ShadowStoreInfluence),
+		StoreScores: make(map[uint64]*ShadowStoreScore),
+	}
+	influence := operator.NewOpInfluence()
+	for _, op := range ops {
+		result.Operators = append(result.Operators, op.ToJSONObject())
+		op.TotalInfluence(*influence, cluster.GetRegion(op.RegionID()))
+	}
+
+	conf := cluster.GetSchedulerConfig()
+	policy := conf.GetLeaderSchedulePolicy()
+	leaderKind := constant.NewScheduleKind(constant.LeaderKind, policy)
+	regionKind := constant.NewScheduleKind(constant.RegionKind, constant.BySize)
+	for storeID, inf := range influence.StoresInfluence {
+		result.Influence[storeID] = &ShadowStoreInfluence{
+			RegionSize:  inf.RegionSize,
+			RegionCount: inf.RegionCount,
+			LeaderSize:  inf.LeaderSize,
+			LeaderCount: inf.LeaderCount,
+		}
+		store := cluster.GetStore(storeID)
+		if store == nil {
+			continue
+		}
+		version, high, low := conf.GetRegionScoreFormulaVersion(), conf.GetHighSpaceRatio(), conf.GetLowSpaceRatio()
+		result.StoreScores[storeID] = &ShadowStoreScore{
+			LeaderScore:          store.LeaderScore(policy, 0),
+			RegionScore:          store.RegionScore(version, high, low, 0),
+			ProjectedLeaderScore: store.LeaderScore(policy, inf.ResourceProperty(leaderKind)),
+			ProjectedRegionScore: store.RegionScore(version, high, low, inf.ResourceProperty(regionKind)),
+		}
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/schedule/config"
+	"github.com/tikv/pd/pkg/schedule/types"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/versioninfo"
+)
+
+func TestShadowRecorder(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	tc.SetClusterVersion(versioninfo.MinSupportedVersion(versioninfo.Version4_0))
+	tc.SetEnablePlacementRules(false)
+	tc.SetMaxReplicasWithLabel(false, 1)
+	sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, storage.NewStorageWithMemoryBackend(), ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+	re.NoError(err)
+	tc.AddRegionStore(1, 6)
+	tc.AddRegionStore(2, 8)
+	tc.AddRegionStore(3, 8)
+	tc.AddRegionStore(4, 16)
+	tc.AddLeaderRegion(1, 4)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	s := NewScheduleController(ctx, tc, oc, sb)
+	re.False(s.IsShadow())
+	s.SetShadow(true)
+	re.True(s.IsShadow())
+
+	ops := s.Schedule(false)
+	re.Len(ops, 1)
+	result := s.GetShadowRecorder().Record(tc, ops)
+	re.Len(result.Operators, 1)
+	re.Equal(uint64(1), result.Operators[0].RegionID)
+	// The region moves from store 4 to store 1.
+	re.Positive(result.Influence[1].RegionSize)
+	re.Negative(result.Influence[4].RegionSize)
+	re.Greater(result.StoreScores[1].ProjectedRegionScore, result.StoreScores[1].RegionScore)
+	re.Less(result.StoreScores[4].ProjectedRegionScore, result.StoreScores[4].RegionScore)
+	// The shadow operators should never be added to the operator controller.
+	re.Nil(oc.GetOperator(1))
+	re.Len(s.GetShadowRecorder().GetResults(), 1)
+
+	s.SetShadow(false)
+	re.False(s.IsShadow())
+	re.Empty(s.GetShadowRecorder().GetResults())
+}
+
+func TestShadowSchedulerPersisted(t *testing.T) {
+	re := require.New(t)
+	cancel, _, tc, oc := prepareSchedulersTest()
+	defer cancel()
+	store := storage.NewStorageWithMemoryBackend()
+	newScheduler := func() Scheduler {
+		sb, err := CreateScheduler(types.BalanceRegionScheduler, oc, store, ConfigSliceDecoder(types.BalanceRegionScheduler, []string{"", ""}))
+		re.NoError(err)
+		return sb
+	}
+	sc := NewController(context.Background(), tc, store, oc, config.NewTimeWindows(store))
+	sb := newScheduler()
+	re.NoError(sc.AddScheduler(sb))
+	re.NoError(sc.SetSchedulerShadow(sb.GetName(), true))
+	re.True(sc.GetScheduler(sb.GetName()).IsShadow())
+	re.Error(sc.SetSchedulerShadow("unknown", true))
+
+	// The shadow mode is restored after the restart.
+	restarted := NewController(context.Background(), tc, store, oc, config.NewTimeWindows(store))
+	re.NoError(restarted.ReloadShadowSchedulers())
+	re.NoError(restarted.AddScheduler(newScheduler()))
+	shadow, err := restarted.IsSchedulerShadow(sb.GetName())
+	re.NoError(err)
+	re.True(shadow)
+	re.True(restarted.GetScheduler(sb.GetName()).IsShadow())
+
+	// The shadow mode is dropped with the removed scheduler.
+	re.NoError(restarted.RemoveScheduler(sb.GetName()))
+	re.NoError(sc.ReloadShadowSchedulers())
+	re.False(sc.GetScheduler(sb.GetName()).IsShadow())
+	re.NoError(sc.RemoveScheduler(sb.GetName()))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/tikv/pd/pkg/core/constant"
 	"github.com/tikv/pd/pkg/errs"
 	sche "github.com/tikv/pd/pkg/schedule/core"
+	"github.com/tikv/pd/pkg/schedule/filter"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/schedule/plan"
@@ -184,6 +185,23 @@ func (p *solver) getTolerantResource() int64 {
 	return p.tolerantSource
 }
 
+// newLeaderZoneFilter returns the filter which keeps the leader of the region in
+// the zone required by the `leader_zone` label policy, it returns nil if the region
+// has no such policy.
+func newLeaderZoneFilter(scope string, cluster sche.SchedulerCluster, region *core.RegionInfo) filter.Filter {
+	l := cluster.GetRegionLabeler()
+	if l == nil {
+		return nil
+	}
+	zone := l.GetSchedulingPolicy(region).LeaderZone
+	if zone == "" {
+		return nil
+	}
+	return filter.NewLabelConstraintFilter(scope, []placement.LabelConstraint{
+		{Key: cluster.GetSchedulerConfig().GetLeaderZoneLabel(), Op: placement.In, Values: []string{zone}},
+	})
+}
+
 func adjustTolerantRatio(cluster sche.SchedulerCluster, kind constant.ScheduleKind) float64 {
 	var tolerantSizeRatio float64
 	switch c := cluster.(type) {
@@ -37,6 +37,8 @@ const (
 	RuleChecker CheckerSchedulerType = "rule-checker"
 	// SplitChecker is the name for split checker.
 	SplitChecker CheckerSchedulerType = "split-checker"
+	// BucketSplitChecker is the name for bucket split checker.
+	BucketSplitChecker CheckerSchedulerType = "bucket-split-checker"
 
 	// BalanceLeaderScheduler is balance leader scheduler name.
 	BalanceLeaderScheduler CheckerSchedulerType = "balance-leader-scheduler"
@@ -72,6 +74,8 @@ const (
 	LabelScheduler CheckerSchedulerType = "label-scheduler"
 	// BalanceRangeScheduler is balance key range scheduler name.
 	BalanceRangeScheduler CheckerSchedulerType = "balance-range-scheduler"
+	// BalanceCostScheduler is balance cost scheduler name.
+	BalanceCostScheduler CheckerSchedulerType = "balance-cost-scheduler"
 )
 
 // TODO: SchedulerTypeCompatibleMap and ConvertOldStrToType should be removed after
@@ -99,6 +103,7 @@ var (
 		TransferWitnessLeaderScheduler: "transfer-witness-leader",
 		LabelScheduler:                 "label",
 		BalanceRangeScheduler:          "balance-range",
+		BalanceCostScheduler:           "balance-cost",
 	}
 
 	// ConvertOldStrToType exists for compatibility.
@@ -122,6 +127,7 @@ var (
 		"transfer-witness-leader": TransferWitnessLeaderScheduler,
 		"label":                   LabelScheduler,
 		"balance-range":           BalanceRangeScheduler,
+		"balance-cost":            BalanceCostScheduler,
 	}
 
 	// StringToSchedulerType is a map to convert the scheduler string to the CheckerSchedulerType.
@@ -145,6 +151,7 @@ var (
 		"transfer-witness-leader-scheduler": TransferWitnessLeaderScheduler,
 		"label-scheduler":                   LabelScheduler,
 		"balance-range-scheduler":           BalanceRangeScheduler,
+		"balance-cost-scheduler":            BalanceCostScheduler,
 	}
 
 	// DefaultSchedulers is the default scheduler types.
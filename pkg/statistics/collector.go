@@ -59,6 +59,8 @@ func (tikvCollector) getLoads(storeLoads StoreKindLoads, peerLoadSum Loads, rwTy
 		loads[utils.ByteDim] = storeLoads[utils.StoreReadBytes]
 		loads[utils.KeyDim] = storeLoads[utils.StoreReadKeys]
 		loads[utils.QueryDim] = storeLoads[utils.StoreReadQuery]
+		// The store heartbeat doesn't report the read CPU usage, so use the sum of hot peers instead.
+		loads[utils.CPUDim] = peerLoadSum[utils.CPUDim]
 	case utils.Write:
 		switch kind {
 		case constant.LeaderKind:
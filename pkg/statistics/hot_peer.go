@@ -162,10 +162,17 @@ func (stat *HotPeerStat) GetActionType() utils.ActionType {
 }
 
 // GetLoad returns denoising load if possible.
+// The write peers don't have the CPU dim, so 0 is returned for the missing dims.
 func (stat *HotPeerStat) GetLoad(dim int) float64 {
 	if stat.rollingLoads != nil {
+		if dim >= len(stat.rollingLoads) {
+			return 0
+		}
 		return math.Round(stat.rollingLoads[dim].get())
 	}
+	if dim >= len(stat.Loads) {
+		return 0
+	}
 	return math.Round(stat.Loads[dim])
 }
 
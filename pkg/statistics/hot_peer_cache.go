@@ -66,7 +66,7 @@ type thresholds struct {
 	updatedTime time.Time
 	rates       []float64
 	topNLen     int
-	metrics     [utils.DimLen + 1]prometheus.Gauge // 0 is for byte, 1 is for key, 2 is for query, 3 is for total length.
+	metrics     [utils.DimLen + 1]prometheus.Gauge // 0 is for byte, 1 is for key, 2 is for query, 3 is for cpu, 4 is for total length.
 }
 
 // HotPeerCache saves the hot peer's statistics.
@@ -171,6 +171,10 @@ func (f *HotPeerCache) CheckPeerFlow(region *core.RegionInfo, peers []*metapb.Pe
 		return nil
 	}
 
+	// The kinds which are missing in the loads are treated as 0.
+	if len(deltaLoads) < int(utils.RegionStatCount) {
+		deltaLoads = append(deltaLoads[:len(deltaLoads):len(deltaLoads)], make([]float64, int(utils.RegionStatCount)-len(deltaLoads))...)
+	}
 	regionID := region.GetID()
 
 	regionPeers := region.GetPeers()
@@ -257,8 +261,8 @@ func (f *HotPeerCache) CheckColdPeer(storeID uint64, reportRegions map[uint64]*c
 			deltaLoads := make([]float64, utils.RegionStatCount)
 			thresholds := f.calcHotThresholds(storeID)
 			source := utils.Direct
-			for i, loads := range thresholds {
-				deltaLoads[i] = loads * float64(interval)
+			for dim, kind := range f.kind.RegionStats() {
+				deltaLoads[kind] = thresholds[dim] * float64(interval)
 			}
 			stat := f.updateHotPeerStat(region, newItem, oldItem, deltaLoads, time.Duration(interval)*time.Second, source)
 			if stat != nil {
@@ -274,6 +278,7 @@ func (f *HotPeerCache) collectMetrics() {
 		thresholds.metrics[utils.ByteDim].Set(thresholds.rates[utils.ByteDim])
 		thresholds.metrics[utils.KeyDim].Set(thresholds.rates[utils.KeyDim])
 		thresholds.metrics[utils.QueryDim].Set(thresholds.rates[utils.QueryDim])
+		thresholds.metrics[utils.CPUDim].Set(thresholds.rates[utils.CPUDim])
 		thresholds.metrics[utils.DimLen].Set(float64(thresholds.topNLen))
 	}
 }
@@ -303,6 +308,7 @@ func (f *HotPeerCache) calcHotThresholds(storeID uint64) []float64 {
 				utils.ByteDim:  hotCacheStatusGauge.WithLabelValues("byte-rate-threshold", store, kind),
 				utils.KeyDim:   hotCacheStatusGauge.WithLabelValues("key-rate-threshold", store, kind),
 				utils.QueryDim: hotCacheStatusGauge.WithLabelValues("query-rate-threshold", store, kind),
+				utils.CPUDim:   hotCacheStatusGauge.WithLabelValues("cpu-rate-threshold", store, kind),
 				utils.DimLen:   hotCacheStatusGauge.WithLabelValues("total_length", store, kind),
 			},
 		}
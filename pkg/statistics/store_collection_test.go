@@ -134,7 +134,7 @@ func TestSummaryStoreInfos(t *testing.T) {
 	re.Len(details, 2)
 	re.Empty(details[0].LoadPred.Current.HistoryLoads)
 	re.Empty(details[1].LoadPred.Current.HistoryLoads)
-	expectHistoryLoads := []float64{1, 2, 5}
+	expectHistoryLoads := []float64{1, 2, 5, 0}
 	for _, storeID := range []uint64{1, 3} {
 		loads := storeHistoryLoad.Get(storeID, rw, kind)
 		for i := range loads {
@@ -150,7 +150,7 @@ func TestSummaryStoreInfos(t *testing.T) {
 	storeHistoryLoad.sampleDuration = 0
 	for i := 1; i < 10; i++ {
 		details = summaryStoresLoadByEngine(storeInfos, storeLoads, storeHistoryLoad, nil, rw, kind, collector)
-		expect := []float64{2, 4, 10}
+		expect := []float64{2, 4, 10, 0}
 		for _, detail := range details {
 			loads := detail.LoadPred.Current.HistoryLoads
 			re.Len(loads, len(expectHistoryLoads))
@@ -57,6 +57,7 @@ func CollectHotPeerInfos(stores []*core.StoreInfo, regionStats map[uint64][]*Hot
 				TotalBytesRate: peerLoadSum[utils.ByteDim],
 				TotalKeysRate:  peerLoadSum[utils.KeyDim],
 				TotalQueryRate: peerLoadSum[utils.QueryDim],
+				TotalCPURate:   peerLoadSum[utils.CPUDim],
 				Count:          len(peers),
 			}
 		}
@@ -156,7 +157,6 @@ func summaryStoresLoadByEngine(
 ) []*StoreLoadDetail {
 	var (
 		loadDetail             = make([]*StoreLoadDetail, 0, len(storeInfos))
-		allStoreLoadSum        Loads
 		allStoreHistoryLoadSum HistoryLoads
 		allStoreCount          = 0
 		allHotPeersCount       = 0
@@ -197,9 +197,6 @@ func summaryStoresLoadByEngine(
 			storesHistoryLoads.Add(id, rwTy, kind, currentLoads)
 		}
 
-		for i := range allStoreLoadSum {
-			allStoreLoadSum[i] += currentLoads[i]
-		}
 		allStoreCount += 1
 		allHotPeersCount += len(hotPeers)
 
@@ -223,15 +220,11 @@ func summaryStoresLoadByEngine(
 	}
 
 	var (
-		expectCount = float64(allHotPeersCount) / float64(allStoreCount)
-		expectLoads Loads
+		expectCount              = float64(allHotPeersCount) / float64(allStoreCount)
+		expectLoads, stddevLoads = summaryExpectLoads(loadDetail, allHotPeersCount)
 		// TODO: remove some the max value or min value to avoid the effect of extreme value.
 		expectHistoryLoads HistoryLoads
-		stddevLoads        Loads
 	)
-	for i := range utils.DimLen {
-		expectLoads[i] = allStoreLoadSum[i] / float64(allStoreCount)
-	}
 
 	for dim := range allStoreHistoryLoadSum {
 		expectHistoryLoads[dim] = make([]float64, len(allStoreHistoryLoadSum[dim]))
@@ -239,16 +232,6 @@ func summaryStoresLoadByEngine(
 			expectHistoryLoads[dim][j] = allStoreHistoryLoadSum[dim][j] / float64(allStoreCount)
 		}
 	}
-	if allHotPeersCount != 0 {
-		for _, detail := range loadDetail {
-			for i := range expectLoads {
-				stddevLoads[i] += math.Pow(detail.LoadPred.Current.Loads[i]-expectLoads[i], 2) //nolint:staticcheck
-			}
-		}
-		for i := range stddevLoads {
-			stddevLoads[i] = math.Sqrt(stddevLoads[i]/float64(allStoreCount)) / expectLoads[i]
-		}
-	}
 
 	{
 		// Metric for debug.
@@ -259,6 +242,8 @@ func summaryStoresLoadByEngine(
 		hotPeerSummary.WithLabelValues(ty, engine).Set(expectLoads[utils.KeyDim])
 		ty = "exp-query-rate-" + rwTy.String() + "-" + kind.String()
 		hotPeerSummary.WithLabelValues(ty, engine).Set(expectLoads[utils.QueryDim])
+		ty = "exp-cpu-rate-" + rwTy.String() + "-" + kind.String()
+		hotPeerSummary.WithLabelValues(ty, engine).Set(expectLoads[utils.CPUDim])
 		ty = "exp-count-rate-" + rwTy.String() + "-" + kind.String()
 		hotPeerSummary.WithLabelValues(ty, engine).Set(expectCount)
 		ty = "stddev-byte-rate-" + rwTy.String() + "-" + kind.String()
@@ -267,6 +252,8 @@ func summaryStoresLoadByEngine(
 		hotPeerSummary.WithLabelValues(ty, engine).Set(stddevLoads[utils.KeyDim])
 		ty = "stddev-query-rate-" + rwTy.String() + "-" + kind.String()
 		hotPeerSummary.WithLabelValues(ty, engine).Set(stddevLoads[utils.QueryDim])
+		ty = "stddev-cpu-rate-" + rwTy.String() + "-" + kind.String()
+		hotPeerSummary.WithLabelValues(ty, engine).Set(stddevLoads[utils.CPUDim])
 	}
 	expect := StoreLoad{
 		Loads:        expectLoads,
@@ -284,6 +271,30 @@ func summaryStoresLoadByEngine(
 	return loadDetail
 }
 
+// summaryExpectLoads returns the expectation and the standard deviation of the current
+// loads of the stores. The standard deviation is zero if there is no hot peer.
+func summaryExpectLoads(loadDetail []*StoreLoadDetail, hotPeersCount int) (expectLoads, stddevLoads Loads) {
+	for _, detail := range loadDetail {
+		for i := range expectLoads {
+			expectLoads[i] += detail.LoadPred.Current.Loads[i]
+		}
+	}
+	for i := range expectLoads {
+		expectLoads[i] /= float64(len(loadDetail))
+	}
+	if hotPeersCount != 0 {
+		for _, detail := range loadDetail {
+			for i := range expectLoads {
+				stddevLoads[i] += math.Pow(detail.LoadPred.Current.Loads[i]-expectLoads[i], 2) //nolint:staticcheck
+			}
+		}
+		for i := range stddevLoads {
+			stddevLoads[i] = math.Sqrt(stddevLoads[i]/float64(len(loadDetail))) / expectLoads[i]
+		}
+	}
+	return expectLoads, stddevLoads
+}
+
 // filterHotPeers filters hot peers according to kind.
 // If kind is RegionKind, all hot peers will be returned.
 // If kind is LeaderKind, only leader hot peers will be returned.
@@ -0,0 +1,229 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"time"
+
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+const (
+	// PeriodicForecastModel predicts the load of a store by the average of the samples
+	// at the same phase of the previous periods, e.g. the same time of the previous days.
+	PeriodicForecastModel = "periodic"
+
+	// DefaultForecastSlot is the width of the time slot that the forecast samples are bucketed by.
+	DefaultForecastSlot = 5 * time.Minute
+	// DefaultForecastPeriod is the period of the load, 24h for daily periodicity and 168h for weekly.
+	DefaultForecastPeriod = 24 * time.Hour
+	// DefaultForecastSeasons is the number of the previous periods used to forecast.
+	DefaultForecastSeasons = 7
+)
+
+// LoadForecaster predicts the future load of the stores from their history samples.
+// It is not thread-safe.
+type LoadForecaster interface {
+	// Observe records the load of a store sampled at the given time. The samples may be observed
+	// out of time order, e.g. the history samples are observed after the current ones.
+	Observe(storeID uint64, rwTy utils.RWType, kind constant.ResourceKind, ts time.Time, loads Loads)
+	// Forecast predicts the load of a store at the given time.
+	// It returns false if there are not enough samples to forecast.
+	Forecast(storeID uint64, rwTy utils.RWType, kind constant.ResourceKind, at time.Time) (Loads, bool)
+}
+
+// LoadForecastConfig is the config to create a LoadForecaster.
+type LoadForecastConfig struct {
+	Period  time.Duration
+	Slot    time.Duration
+	Seasons int
+}
+
+// LoadForecasterCreator creates a LoadForecaster with the config.
+type LoadForecasterCreator func(cfg LoadForecastConfig) LoadForecaster
+
+var loadForecasters = map[string]LoadForecasterCreator{
+	PeriodicForecastModel: newPeriodicForecaster,
+}
+
+// RegisterLoadForecaster registers a forecast model. It should be called in init.
+func RegisterLoadForecaster(model string, creator LoadForecasterCreator) {
+	loadForecasters[model] = creator
+}
+
+// IsLoadForecastModelRegistered returns whether the forecast model is registered.
+func IsLoadForecastModelRegistered(model string) bool {
+	_, ok := loadForecasters[model]
+	return ok
+}
+
+// NewLoadForecaster creates a LoadForecaster by the model name.
+// It returns nil if the model is not registered.
+func NewLoadForecaster(model string, cfg LoadForecastConfig) LoadForecaster {
+	creator, ok := loadForecasters[model]
+	if !ok {
+		return nil
+	}
+	return creator(cfg)
+}
+
+// periodicForecaster buckets the samples by the slot of the period, and forecasts the load of a slot
+// by averaging the samples of the same slot in the previous `seasons` periods.
+type periodicForecaster struct {
+	period  int64
+	slot    int64
+	seasons int
+	// samples[read/write][leader/follower]-->[store id]-->[season][slot]
+	samples [utils.RWTypeLen][constant.ResourceKindLen]map[uint64][][]periodicSample
+}
+
+type periodicSample struct {
+	// season is the index of the period since the unix epoch.
+	season int64
+	count  int
+	sum    Loads
+}
+
+func newPeriodicForecaster(cfg LoadForecastConfig) LoadForecaster {
+	if cfg.Slot <= 0 {
+		cfg.Slot = DefaultForecastSlot
+	}
+	if cfg.Period < cfg.Slot {
+		cfg.Period = DefaultForecastPeriod
+	}
+	if cfg.Seasons <= 0 {
+		cfg.Seasons = DefaultForecastSeasons
+	}
+	f := &periodicForecaster{
+		period:  int64(cfg.Period),
+		slot:    int64(cfg.Slot),
+		seasons: cfg.Seasons,
+	}
+	for i := utils.RWType(0); i < utils.RWTypeLen; i++ {
+		for j := constant.ResourceKind(0); j < constant.ResourceKindLen; j++ {
+			f.samples[i][j] = make(map[uint64][][]periodicSample)
+		}
+	}
+	return f
+}
+
+func (f *periodicForecaster) locate(ts time.Time) (season int64, slot int) {
+	nanos := ts.UnixNano()
+	return nanos / f.period, int(nanos % f.period / f.slot)
+}
+
+// Observe implements the LoadForecaster interface.
+func (f *periodicForecaster) Observe(storeID uint64, rwTy utils.RWType, kind constant.ResourceKind, ts time.Time, loads Loads) {
+	samples, ok := f.samples[rwTy][kind][storeID]
+	if !ok {
+		slots := int((f.period + f.slot - 1) / f.slot)
+		samples = make([][]periodicSample, f.seasons)
+		for i := range samples {
+			samples[i] = make([]periodicSample, slots)
+		}
+		f.samples[rwTy][kind][storeID] = samples
+	}
+	season, slot := f.locate(ts)
+	sample := &samples[season%int64(f.seasons)][slot]
+	if sample.season > season {
+		// The slot is already used by a later period.
+		return
+	}
+	if sample.season != season {
+		*sample = periodicSample{season: season}
+	}
+	for i := range loads {
+		sample.sum[i] += loads[i]
+	}
+	sample.count++
+}
+
+// Forecast implements the LoadForecaster interface.
+func (f *periodicForecaster) Forecast(storeID uint64, rwTy utils.RWType, kind constant.ResourceKind, at time.Time) (Loads, bool) {
+	var ret Loads
+	samples, ok := f.samples[rwTy][kind][storeID]
+	if !ok {
+		return ret, false
+	}
+	season, slot := f.locate(at)
+	count := 0
+	for i := range samples {
+		sample := samples[i][slot]
+		// Only the samples in the previous periods are used.
+		if sample.count == 0 || sample.season >= season || sample.season < season-int64(f.seasons) {
+			continue
+		}
+		for dim := range ret {
+			ret[dim] += sample.sum[dim] / float64(sample.count)
+		}
+		count++
+	}
+	if count == 0 {
+		return ret, false
+	}
+	for dim := range ret {
+		ret[dim] /= float64(count)
+	}
+	return ret, true
+}
+
+// ForecastStoresLoad returns a copy of the store load details whose loads are raised to the forecast
+// loads, so that the stores expected to be hot are balanced before the peak. The loads are never lowered
+// by the forecast, otherwise the current hotspots would be ignored. The expectation and the standard
+// deviation of each engine are calculated from the raised loads. The given details are not modified.
+func ForecastStoresLoad(details map[uint64]*StoreLoadDetail, forecasts map[uint64]Loads) map[uint64]*StoreLoadDetail {
+	ret := make(map[uint64]*StoreLoadDetail, len(details))
+	var tikvDetails, tiflashDetails []*StoreLoadDetail
+	var tikvHotPeers, tiflashHotPeers int
+	for id, detail := range details {
+		pred := *detail.LoadPred
+		if forecast, ok := forecasts[id]; ok {
+			for i := range forecast {
+				if delta := forecast[i] - pred.Current.Loads[i]; delta > 0 {
+					pred.Current.Loads[i] += delta
+					pred.Future.Loads[i] += delta
+				}
+			}
+		}
+		forecastDetail := &StoreLoadDetail{
+			StoreSummaryInfo: detail.StoreSummaryInfo,
+			LoadPred:         &pred,
+			HotPeers:         detail.HotPeers,
+		}
+		ret[id] = forecastDetail
+		if detail.IsTiFlash() {
+			tiflashDetails = append(tiflashDetails, forecastDetail)
+			tiflashHotPeers += len(detail.HotPeers)
+		} else {
+			tikvDetails = append(tikvDetails, forecastDetail)
+			tikvHotPeers += len(detail.HotPeers)
+		}
+	}
+	updateExpectLoads(tikvDetails, tikvHotPeers)
+	updateExpectLoads(tiflashDetails, tiflashHotPeers)
+	return ret
+}
+
+func updateExpectLoads(details []*StoreLoadDetail, hotPeersCount int) {
+	if len(details) == 0 {
+		return
+	}
+	expectLoads, stddevLoads := summaryExpectLoads(details, hotPeersCount)
+	for _, detail := range details {
+		detail.LoadPred.Expect.Loads = expectLoads
+		detail.LoadPred.Stddev.Loads = stddevLoads
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/core/constant"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+func TestPeriodicForecaster(t *testing.T) {
+	re := require.New(t)
+	re.True(IsLoadForecastModelRegistered(PeriodicForecastModel))
+	re.False(IsLoadForecastModelRegistered("unknown"))
+	re.Nil(NewLoadForecaster("unknown", LoadForecastConfig{}))
+
+	forecaster := NewLoadForecaster(PeriodicForecastModel, LoadForecastConfig{
+		Period:  24 * time.Hour,
+		Slot:    5 * time.Minute,
+		Seasons: 2,
+	})
+	rwTp, kind := utils.Read, constant.LeaderKind
+	day := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	_, ok := forecaster.Forecast(1, rwTp, kind, day)
+	re.False(ok)
+
+	// The samples in the same slot are averaged.
+	forecaster.Observe(1, rwTp, kind, day, Loads{100, 10})
+	forecaster.Observe(1, rwTp, kind, day.Add(time.Minute), Loads{300, 30})
+	// The samples in the current period are not used.
+	_, ok = forecaster.Forecast(1, rwTp, kind, day.Add(2*time.Minute))
+	re.False(ok)
+	loads, ok := forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 1))
+	re.True(ok)
+	re.Equal(Loads{200, 20}, loads)
+	// The other slots, stores and types have no samples.
+	_, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 1).Add(5*time.Minute))
+	re.False(ok)
+	_, ok = forecaster.Forecast(2, rwTp, kind, day.AddDate(0, 0, 1))
+	re.False(ok)
+	_, ok = forecaster.Forecast(1, utils.Write, kind, day.AddDate(0, 0, 1))
+	re.False(ok)
+
+	// The samples of the previous seasons are averaged.
+	forecaster.Observe(1, rwTp, kind, day.AddDate(0, 0, 1), Loads{400, 40})
+	loads, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 2))
+	re.True(ok)
+	re.Equal(Loads{300, 30}, loads)
+	// Only the latest `seasons` periods are used.
+	forecaster.Observe(1, rwTp, kind, day.AddDate(0, 0, 2), Loads{600, 60})
+	loads, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 3))
+	re.True(ok)
+	re.Equal(Loads{500, 50}, loads)
+	loads, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 4))
+	re.True(ok)
+	re.Equal(Loads{600, 60}, loads)
+	_, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 5))
+	re.False(ok)
+
+	// The sample observed out of time order doesn't override the later one.
+	forecaster.Observe(1, rwTp, kind, day, Loads{1000, 100})
+	loads, ok = forecaster.Forecast(1, rwTp, kind, day.AddDate(0, 0, 3))
+	re.True(ok)
+	re.Equal(Loads{500, 50}, loads)
+}
+
+func TestForecastStoresLoad(t *testing.T) {
+	re := require.New(t)
+	newDetail := func(load float64, isTiFlash bool) *StoreLoadDetail {
+		info := &StoreSummaryInfo{isTiFlash: isTiFlash}
+		current := StoreLoad{Loads: Loads{load}, HotPeerCount: 1}
+		detail := &StoreLoadDetail{
+			StoreSummaryInfo: info,
+			LoadPred:         current.ToLoadPred(utils.Read, nil),
+			HotPeers:         []*HotPeerStat{{}},
+		}
+		detail.LoadPred.Expect.Loads = Loads{load}
+		return detail
+	}
+	details := map[uint64]*StoreLoadDetail{
+		1: newDetail(100, false),
+		2: newDetail(100, false),
+		3: newDetail(400, false),
+		4: newDetail(50, true),
+	}
+	forecastDetails := ForecastStoresLoad(details, map[uint64]Loads{
+		// The load is raised to the forecast.
+		1: {400},
+		// The load is not lowered by the forecast.
+		3: {100},
+	})
+	re.Equal(400.0, forecastDetails[1].LoadPred.Current.Loads[0])
+	re.Equal(400.0, forecastDetails[1].LoadPred.Future.Loads[0])
+	re.Equal(100.0, forecastDetails[2].LoadPred.Current.Loads[0])
+	re.Equal(400.0, forecastDetails[3].LoadPred.Current.Loads[0])
+	for _, id := range []uint64{1, 2, 3} {
+		re.Equal(300.0, forecastDetails[id].LoadPred.Expect.Loads[0])
+		re.InDelta(0.4714, forecastDetails[id].LoadPred.Stddev.Loads[0], 1e-4)
+	}
+	// The expectation of TiFlash is calculated separately.
+	re.Equal(50.0, forecastDetails[4].LoadPred.Expect.Loads[0])
+	re.Zero(forecastDetails[4].LoadPred.Stddev.Loads[0])
+
+	// The observed loads are not modified.
+	re.Equal(100.0, details[1].LoadPred.Current.Loads[0])
+	re.Equal(100.0, details[1].LoadPred.Future.Loads[0])
+	re.Equal(100.0, details[1].LoadPred.Expect.Loads[0])
+}
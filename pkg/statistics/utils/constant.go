@@ -43,4 +43,6 @@ var MinHotThresholds = [RegionStatCount]float64{
 	RegionWriteBytes:    1 * units.KiB,
 	RegionWriteKeys:     32,
 	RegionWriteQueryNum: 32,
+	// RegionReadCPU is the CPU usage percentage of the leader, 1 means 1% of a CPU core.
+	RegionReadCPU: 1,
 }
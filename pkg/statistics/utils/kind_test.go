@@ -50,7 +50,9 @@ func TestGetLoads(t *testing.T) {
 		core.SetReadKeys(2),
 		core.SetWrittenBytes(3),
 		core.SetWrittenKeys(4),
-		core.SetQueryStats(queryStats))
+		core.SetQueryStats(queryStats),
+		core.SetCPUUsage(15),
+		core.SetReportInterval(0, 10))
 	loads := regionA.GetLoads()
 	re.Len(loads, int(RegionStatCount))
 	re.Equal(float64(regionA.GetBytesRead()), loads[RegionReadBytes])
@@ -63,10 +65,12 @@ func TestGetLoads(t *testing.T) {
 	re.Equal(float64(regionA.GetWriteQueryNum()), loads[RegionWriteQueryNum])
 	writeQuery := float64(queryStats.Put + queryStats.Delete + queryStats.DeleteRange + queryStats.AcquirePessimisticLock + queryStats.Rollback + queryStats.Prewrite + queryStats.Commit)
 	re.Equal(float64(regionA.GetWriteQueryNum()), writeQuery)
+	re.Equal(float64(regionA.GetCPUUsage()*10), loads[RegionReadCPU])
 
 	loads = regionA.GetWriteLoads()
 	re.Len(loads, int(RegionStatCount))
 	re.Equal(0.0, loads[RegionReadBytes])
+	re.Equal(0.0, loads[RegionReadCPU])
 	re.Equal(0.0, loads[RegionReadKeys])
 	re.Equal(0.0, loads[RegionReadQueryNum])
 	re.Equal(float64(regionA.GetBytesWritten()), loads[RegionWriteBytes])
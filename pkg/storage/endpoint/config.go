@@ -34,6 +34,12 @@ type ConfigStorage interface {
 	LoadSchedulerConfig(schedulerName string) (string, error)
 	SaveSchedulerConfig(schedulerName string, data []byte) error
 	RemoveSchedulerConfig(schedulerName string) error
+	// The scheduling time windows of all the schedulers and checkers are stored together.
+	LoadScheduleTimeWindows() (string, error)
+	SaveScheduleTimeWindows(data []byte) error
+	// The names of the schedulers running in shadow mode are stored together.
+	LoadShadowSchedulers() (string, error)
+	SaveShadowSchedulers(data []byte) error
 }
 
 var _ ConfigStorage = (*StorageEndpoint)(nil)
@@ -80,3 +86,23 @@ func (se *StorageEndpoint) SaveSchedulerConfig(schedulerName string, data []byte
 func (se *StorageEndpoint) RemoveSchedulerConfig(schedulerName string) error {
 	return se.Remove(keypath.SchedulerConfigPath(schedulerName))
 }
+
+// LoadScheduleTimeWindows loads the scheduling time windows.
+func (se *StorageEndpoint) LoadScheduleTimeWindows() (string, error) {
+	return se.Load(keypath.ScheduleTimeWindowsPath())
+}
+
+// SaveScheduleTimeWindows saves the scheduling time windows.
+func (se *StorageEndpoint) SaveScheduleTimeWindows(data []byte) error {
+	return se.Save(keypath.ScheduleTimeWindowsPath(), string(data))
+}
+
+// LoadShadowSchedulers loads the names of the schedulers running in shadow mode.
+func (se *StorageEndpoint) LoadShadowSchedulers() (string, error) {
+	return se.Load(keypath.ShadowSchedulersPath())
+}
+
+// SaveShadowSchedulers saves the names of the schedulers running in shadow mode.
+func (se *StorageEndpoint) SaveShadowSchedulers(data []byte) error {
+	return se.Save(keypath.ShadowSchedulersPath(), string(data))
+}
@@ -29,6 +29,7 @@ type RuleStorage interface {
 	LoadRules(f func(k, v string)) error
 	LoadRuleGroups(f func(k, v string)) error
 	LoadRegionRules(f func(k, v string)) error
+	LoadRuleHistories(f func(k, v string)) error
 
 	// We need to use txn to avoid concurrent modification.
 	// And it is helpful for the scheduling server to watch the rule.
@@ -38,6 +39,8 @@ type RuleStorage interface {
 	DeleteRuleGroup(txn kv.Txn, groupID string) error
 	SaveRegionRule(txn kv.Txn, ruleKey string, rule any) error
 	DeleteRegionRule(txn kv.Txn, ruleKey string) error
+	SaveRuleHistory(txn kv.Txn, version uint64, history any) error
+	DeleteRuleHistory(txn kv.Txn, version uint64) error
 
 	RunInTxn(ctx context.Context, f func(txn kv.Txn) error) error
 }
@@ -93,3 +96,18 @@ func (se *StorageEndpoint) LoadRule(ruleKey string) (string, error) {
 func (se *StorageEndpoint) LoadRules(f func(k, v string)) error {
 	return se.loadRangeByPrefix(keypath.RulesPathPrefix(), f)
 }
+
+// LoadRuleHistories loads placement rule histories from storage.
+func (se *StorageEndpoint) LoadRuleHistories(f func(k, v string)) error {
+	return se.loadRangeByPrefix(keypath.RuleHistoryPathPrefix(), f)
+}
+
+// SaveRuleHistory stores a placement rule history to storage.
+func (*StorageEndpoint) SaveRuleHistory(txn kv.Txn, version uint64, history any) error {
+	return saveJSONInTxn(txn, keypath.RuleHistoryPath(version), history)
+}
+
+// DeleteRuleHistory removes a placement rule history from storage.
+func (*StorageEndpoint) DeleteRuleHistory(txn kv.Txn, version uint64) error {
+	return txn.Remove(keypath.RuleHistoryPath(version))
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+// The dimensions which the history hot regions can be grouped by.
+const (
+	// HotRegionsGroupByRegion groups the history hot regions by region ID.
+	HotRegionsGroupByRegion = "region"
+	// HotRegionsGroupByTable groups the history hot regions by the table of their start keys.
+	HotRegionsGroupByTable = "table"
+	// HotRegionsGroupByKeyspace groups the history hot regions by the keyspace of their start keys.
+	HotRegionsGroupByKeyspace = "keyspace"
+	// HotRegionsGroupByStore groups the history hot regions by store ID, with the hot load timeline of the stores.
+	HotRegionsGroupByStore = "store"
+)
+
+// The loads which the aggregated groups can be sorted by.
+const (
+	HotRegionsSortByByte  = "byte"
+	HotRegionsSortByKey   = "key"
+	HotRegionsSortByQuery = "query"
+)
+
+// HistoryHotRegionsAggregation is the aggregation result of the history hot regions.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type HistoryHotRegionsAggregation struct {
+	GroupBy string                    `json:"group_by"`
+	Groups  []*HistoryHotRegionsGroup `json:"groups"`
+}
+
+// HistoryHotRegionsGroup is the cumulative load of a group of the history hot regions.
+// The loads are the sums of the rates of all the records in the group, so the group
+// which keeps hot for a longer time has higher loads.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type HistoryHotRegionsGroup struct {
+	// Key is the region ID, the store ID, the keyspace ID, or the table ID of the group.
+	// The table ID is prefixed with the keyspace ID as `<keyspace_id>/<table_id>`
+	// if the keys belong to a keyspace.
+	Key           string  `json:"key"`
+	HotRegionType string  `json:"hot_region_type"`
+	Count         int     `json:"count"`
+	FlowBytes     float64 `json:"flow_bytes"`
+	KeyRate       float64 `json:"key_rate"`
+	QueryRate     float64 `json:"query_rate"`
+	// StartKey and EndKey are the key range covered by the records in hex format.
+	// They are empty if the group is a store.
+	StartKey string `json:"start_key,omitempty"`
+	EndKey   string `json:"end_key,omitempty"`
+	// Timeline is the hot load of the store in each step, only for the store group.
+	Timeline []*HistoryHotLoadPoint `json:"timeline,omitempty"`
+}
+
+// HistoryHotLoadPoint is the hot load of a store in a step of the timeline.
+// The load is the sum of the latest loads of the hot peers on the store in the step.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type HistoryHotLoadPoint struct {
+	// UpdateTime is the start time of the step in milliseconds.
+	UpdateTime int64   `json:"update_time"`
+	FlowBytes  float64 `json:"flow_bytes"`
+	KeyRate    float64 `json:"key_rate"`
+	QueryRate  float64 `json:"query_rate"`
+}
+
+// HistoryHotRegionsAggregator aggregates the history hot regions by the given dimension.
+type HistoryHotRegionsAggregator struct {
+	groupBy string
+	// step is the width of a timeline step in milliseconds.
+	step   int64
+	groups map[string]*HistoryHotRegionsGroup
+	// latest records group -> step -> regionID -> the latest record of the region in the step.
+	latest map[*HistoryHotRegionsGroup]map[int64]map[uint64]*HistoryHotRegion
+}
+
+// NewHistoryHotRegionsAggregator creates a HistoryHotRegionsAggregator.
+// The step is only used by the store group to build the timeline.
+func NewHistoryHotRegionsAggregator(groupBy string, step time.Duration) (*HistoryHotRegionsAggregator, error) {
+	switch groupBy {
+	case HotRegionsGroupByRegion, HotRegionsGroupByTable, HotRegionsGroupByKeyspace:
+	case HotRegionsGroupByStore:
+		if step < time.Millisecond {
+			return nil, errs.ErrInvalidArgument.FastGenByArgs("step", fmt.Sprintf("%s, should be at least 1ms", step))
+		}
+	default:
+		return nil, errs.ErrInvalidArgument.FastGenByArgs("group_by", fmt.Sprintf("%q, should be one of %s, %s, %s, %s", groupBy,
+			HotRegionsGroupByRegion, HotRegionsGroupByTable, HotRegionsGroupByKeyspace, HotRegionsGroupByStore))
+	}
+	return &HistoryHotRegionsAggregator{
+		groupBy: groupBy,
+		step:    step.Milliseconds(),
+		groups:  make(map[string]*HistoryHotRegionsGroup),
+		latest:  make(map[*HistoryHotRegionsGroup]map[int64]map[uint64]*HistoryHotRegion),
+	}, nil
+}
+
+// Add aggregates a history hot region into its group.
+// The write flow of a region is reported by all its peers, so only the leader is counted
+// unless grouping by store. The record is ignored if it does not belong to any group.
+func (a *HistoryHotRegionsAggregator) Add(region *HistoryHotRegion) {
+	if a.groupBy != HotRegionsGroupByStore && region.HotRegionType == utils.Write.String() && !region.IsLeader {
+		return
+	}
+	key, ok := a.groupKey(region)
+	if !ok {
+		return
+	}
+	id := region.HotRegionType + "/" + key
+	group, ok := a.groups[id]
+	if !ok {
+		group = &HistoryHotRegionsGroup{
+			Key:           key,
+			HotRegionType: region.HotRegionType,
+		}
+		if a.groupBy != HotRegionsGroupByStore {
+			group.StartKey, group.EndKey = region.StartKey, region.EndKey
+		}
+		a.groups[id] = group
+	}
+	group.Count++
+	group.FlowBytes += region.FlowBytes
+	group.KeyRate += region.KeyRate
+	group.QueryRate += region.QueryRate
+
+	if a.groupBy == HotRegionsGroupByStore {
+		a.addToTimeline(group, region)
+		return
+	}
+	if region.StartKey < group.StartKey {
+		group.StartKey = region.StartKey
+	}
+	if group.EndKey != "" && (region.EndKey == "" || region.EndKey > group.EndKey) {
+		group.EndKey = region.EndKey
+	}
+}
+
+func (a *HistoryHotRegionsAggregator) addToTimeline(group *HistoryHotRegionsGroup, region *HistoryHotRegion) {
+	steps, ok := a.latest[group]
+	if !ok {
+		steps = make(map[int64]map[uint64]*HistoryHotRegion)
+		a.latest[group] = steps
+	}
+	step := region.UpdateTime - region.UpdateTime%a.step
+	regions, ok := steps[step]
+	if !ok {
+		regions = make(map[uint64]*HistoryHotRegion)
+		steps[step] = regions
+	}
+	if old, ok := regions[region.RegionID]; !ok || old.UpdateTime <= region.UpdateTime {
+		regions[region.RegionID] = region
+	}
+}
+
+func (a *HistoryHotRegionsAggregator) groupKey(region *HistoryHotRegion) (string, bool) {
+	switch a.groupBy {
+	case HotRegionsGroupByRegion:
+		return strconv.FormatUint(region.RegionID, 10), true
+	case HotRegionsGroupByStore:
+		return strconv.FormatUint(region.StoreID, 10), true
+	}
+	keyspaceID, hasKeyspace, tableID, hasTable := decodeHotRegionKey(region.StartKey)
+	switch a.groupBy {
+	case HotRegionsGroupByKeyspace:
+		return strconv.FormatUint(uint64(keyspaceID), 10), hasKeyspace
+	case HotRegionsGroupByTable:
+		if !hasTable {
+			return "", false
+		}
+		if hasKeyspace {
+			return fmt.Sprintf("%d/%d", keyspaceID, tableID), true
+		}
+		return strconv.FormatInt(tableID, 10), true
+	}
+	return "", false
+}
+
+var (
+	tableKeyPrefix        = []byte{'t'}
+	keyspaceTxnModePrefix = byte('x')
+	keyspaceRawModePrefix = byte('r')
+)
+
+const keyspaceIDLen = 3
+
+// decodeHotRegionKey decodes the keyspace ID and the table ID from the hex format region key.
+func decodeHotRegionKey(hexKey string) (keyspaceID uint32, hasKeyspace bool, tableID int64, hasTable bool) {
+	raw, err := hex.DecodeString(hexKey)
+	if err != nil || len(raw) == 0 {
+		return
+	}
+	_, key, err := codec.DecodeBytes(raw)
+	if err != nil {
+		return
+	}
+	if len(key) > keyspaceIDLen && (key[0] == keyspaceTxnModePrefix || key[0] == keyspaceRawModePrefix) {
+		keyspaceID = uint32(key[1])<<16 | uint32(key[2])<<8 | uint32(key[3])
+		hasKeyspace = true
+		key = key[1+keyspaceIDLen:]
+	}
+	if !bytes.HasPrefix(key, tableKeyPrefix) {
+		return
+	}
+	if _, id, err := codec.DecodeInt(key[len(tableKeyPrefix):]); err == nil {
+		tableID, hasTable = id, true
+	}
+	return
+}
+
+// Result returns the aggregated groups sorted by the given load in descending order.
+// It returns the top `limit` groups, limit <= 0 means no limit.
+func (a *HistoryHotRegionsAggregator) Result(sortBy string, limit int) (*HistoryHotRegionsAggregation, error) {
+	ret := &HistoryHotRegionsAggregation{
+		GroupBy: a.groupBy,
+		Groups:  make([]*HistoryHotRegionsGroup, 0, len(a.groups)),
+	}
+	for _, group := range a.groups {
+		for step, regions := range a.latest[group] {
+			point := &HistoryHotLoadPoint{UpdateTime: step}
+			for _, region := range regions {
+				point.FlowBytes += region.FlowBytes
+				point.KeyRate += region.KeyRate
+				point.QueryRate += region.QueryRate
+			}
+			group.Timeline = append(group.Timeline, point)
+		}
+		sort.Slice(group.Timeline, func(i, j int) bool {
+			return group.Timeline[i].UpdateTime < group.Timeline[j].UpdateTime
+		})
+		ret.Groups = append(ret.Groups, group)
+	}
+	if err := ret.Sort(sortBy, limit); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Merge merges the groups of another aggregation with the same group by into it.
+// It is used to merge the aggregations from different PD servers.
+func (agg *HistoryHotRegionsAggregation) Merge(other *HistoryHotRegionsAggregation) {
+	groups := make(map[string]*HistoryHotRegionsGroup, len(agg.Groups))
+	for _, group := range agg.Groups {
+		groups[group.HotRegionType+"/"+group.Key] = group
+	}
+	for _, group := range other.Groups {
+		old, ok := groups[group.HotRegionType+"/"+group.Key]
+		if !ok {
+			agg.Groups = append(agg.Groups, group)
+			groups[group.HotRegionType+"/"+group.Key] = group
+			continue
+		}
+		old.Count += group.Count
+		old.FlowBytes += group.FlowBytes
+		old.KeyRate += group.KeyRate
+		old.QueryRate += group.QueryRate
+		if group.StartKey < old.StartKey {
+			old.StartKey = group.StartKey
+		}
+		if old.EndKey != "" && (group.EndKey == "" || group.EndKey > old.EndKey) {
+			old.EndKey = group.EndKey
+		}
+		old.Timeline = mergeTimeline(old.Timeline, group.Timeline)
+	}
+}
+
+func mergeTimeline(a, b []*HistoryHotLoadPoint) []*HistoryHotLoadPoint {
+	if len(b) == 0 {
+		return a
+	}
+	points := make(map[int64]*HistoryHotLoadPoint, len(a))
+	for _, point := range a {
+		points[point.UpdateTime] = point
+	}
+	for _, point := range b {
+		old, ok := points[point.UpdateTime]
+		if !ok {
+			a = append(a, point)
+			continue
+		}
+		old.FlowBytes += point.FlowBytes
+		old.KeyRate += point.KeyRate
+		old.QueryRate += point.QueryRate
+	}
+	sort.Slice(a, func(i, j int) bool {
+		return a[i].UpdateTime < a[j].UpdateTime
+	})
+	return a
+}
+
+// Sort sorts the groups by the given load in descending order and keeps the top `limit` groups.
+// limit <= 0 means no limit. The empty sortBy means sorting by byte.
+func (agg *HistoryHotRegionsAggregation) Sort(sortBy string, limit int) error {
+	var load func(*HistoryHotRegionsGroup) float64
+	switch sortBy {
+	case HotRegionsSortByByte, "":
+		load = func(g *HistoryHotRegionsGroup) float64 { return g.FlowBytes }
+	case HotRegionsSortByKey:
+		load = func(g *HistoryHotRegionsGroup) float64 { return g.KeyRate }
+	case HotRegionsSortByQuery:
+		load = func(g *HistoryHotRegionsGroup) float64 { return g.QueryRate }
+	default:
+		return errs.ErrInvalidArgument.FastGenByArgs("sort_by", fmt.Sprintf("%q, should be one of %s, %s, %s", sortBy,
+			HotRegionsSortByByte, HotRegionsSortByKey, HotRegionsSortByQuery))
+	}
+	sort.Slice(agg.Groups, func(i, j int) bool {
+		li, lj := load(agg.Groups[i]), load(agg.Groups[j])
+		if li != lj {
+			return li > lj
+		}
+		if agg.Groups[i].HotRegionType != agg.Groups[j].HotRegionType {
+			return agg.Groups[i].HotRegionType < agg.Groups[j].HotRegionType
+		}
+		return agg.Groups[i].Key < agg.Groups[j].Key
+	})
+	if limit > 0 && len(agg.Groups) > limit {
+		agg.Groups = agg.Groups[:limit]
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tikv/pd/pkg/codec"
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/statistics/utils"
+)
+
+func tableKey(keyspacePrefix []byte, tableID, rowID int64) string {
+	key := append(keyspacePrefix, codec.GenerateRowKey(tableID, rowID)...)
+	return core.HexRegionKeyStr(codec.EncodeBytes(key))
+}
+
+func TestAggregateHistoryHotRegions(t *testing.T) {
+	re := require.New(t)
+	read, write := utils.Read.String(), utils.Write.String()
+	keyspace := []byte{'x', 0, 0, 1}
+	records := []*HistoryHotRegion{
+		{UpdateTime: 1000, RegionID: 1, StoreID: 1, IsLeader: true, HotRegionType: read, FlowBytes: 100, KeyRate: 1, QueryRate: 10,
+			StartKey: tableKey(nil, 10, 0), EndKey: tableKey(nil, 10, 100)},
+		{UpdateTime: 2000, RegionID: 1, StoreID: 1, IsLeader: true, HotRegionType: read, FlowBytes: 100, KeyRate: 1, QueryRate: 10,
+			StartKey: tableKey(nil, 10, 0), EndKey: tableKey(nil, 10, 100)},
+		{UpdateTime: 1000, RegionID: 2, StoreID: 1, IsLeader: true, HotRegionType: read, FlowBytes: 150, KeyRate: 5, QueryRate: 5,
+			StartKey: tableKey(nil, 10, 100), EndKey: tableKey(nil, 11, 0)},
+		{UpdateTime: 1500, RegionID: 3, StoreID: 2, IsLeader: true, HotRegionType: read, FlowBytes: 50, KeyRate: 50, QueryRate: 1,
+			StartKey: tableKey(keyspace, 20, 0), EndKey: ""},
+		// The write flow of a region is reported by all its peers.
+		{UpdateTime: 1000, RegionID: 4, StoreID: 2, IsLeader: true, HotRegionType: write, FlowBytes: 300,
+			StartKey: tableKey(nil, 30, 0), EndKey: tableKey(nil, 31, 0)},
+		{UpdateTime: 1000, RegionID: 4, StoreID: 3, IsLeader: false, HotRegionType: write, FlowBytes: 300,
+			StartKey: tableKey(nil, 30, 0), EndKey: tableKey(nil, 31, 0)},
+		// The key is not a table key.
+		{UpdateTime: 1000, RegionID: 5, StoreID: 3, IsLeader: true, HotRegionType: read, FlowBytes: 10,
+			StartKey: core.HexRegionKeyStr([]byte("a")), EndKey: core.HexRegionKeyStr([]byte("b"))},
+	}
+	aggregate := func(groupBy, sortBy string, limit int) *HistoryHotRegionsAggregation {
+		aggregator, err := NewHistoryHotRegionsAggregator(groupBy, time.Second)
+		re.NoError(err)
+		for _, record := range records {
+			aggregator.Add(record)
+		}
+		ret, err := aggregator.Result(sortBy, limit)
+		re.NoError(err)
+		re.Equal(groupBy, ret.GroupBy)
+		return ret
+	}
+	keys := func(agg *HistoryHotRegionsAggregation) []string {
+		ret := make([]string, 0, len(agg.Groups))
+		for _, group := range agg.Groups {
+			ret = append(ret, group.HotRegionType+"/"+group.Key)
+		}
+		return ret
+	}
+
+	// top-N regions by cumulative load.
+	agg := aggregate(HotRegionsGroupByRegion, "", 3)
+	re.Equal([]string{"write/4", "read/1", "read/2"}, keys(agg))
+	re.Equal(1, agg.Groups[0].Count)
+	re.Equal(2, agg.Groups[1].Count)
+	re.Equal(200.0, agg.Groups[1].FlowBytes)
+	agg = aggregate(HotRegionsGroupByRegion, HotRegionsSortByKey, 1)
+	re.Equal([]string{"read/3"}, keys(agg))
+
+	// hot key ranges grouped by table.
+	agg = aggregate(HotRegionsGroupByTable, HotRegionsSortByQuery, 0)
+	re.Equal([]string{"read/10", "read/1/20", "write/30"}, keys(agg))
+	re.Equal(3, agg.Groups[0].Count)
+	re.Equal(25.0, agg.Groups[0].QueryRate)
+	re.Equal(tableKey(nil, 10, 0), agg.Groups[0].StartKey)
+	re.Equal(tableKey(nil, 11, 0), agg.Groups[0].EndKey)
+	re.Empty(agg.Groups[1].EndKey)
+
+	// grouped by keyspace.
+	agg = aggregate(HotRegionsGroupByKeyspace, "", 0)
+	re.Equal([]string{"read/1"}, keys(agg))
+
+	// hot load timeline of the stores.
+	agg = aggregate(HotRegionsGroupByStore, "", 0)
+	re.Equal([]string{"read/1", "write/2", "write/3", "read/2", "read/3"}, keys(agg))
+	timeline := agg.Groups[0].Timeline
+	re.Len(timeline, 2)
+	re.Equal(&HistoryHotLoadPoint{UpdateTime: 1000, FlowBytes: 250, KeyRate: 6, QueryRate: 15}, timeline[0])
+	re.Equal(&HistoryHotLoadPoint{UpdateTime: 2000, FlowBytes: 100, KeyRate: 1, QueryRate: 10}, timeline[1])
+
+	// Only the latest load of a region in a step is counted.
+	aggregator, err := NewHistoryHotRegionsAggregator(HotRegionsGroupByStore, 10*time.Second)
+	re.NoError(err)
+	for _, record := range records {
+		aggregator.Add(record)
+	}
+	agg, err = aggregator.Result("", 0)
+	re.NoError(err)
+	re.Equal([]*HistoryHotLoadPoint{{UpdateTime: 0, FlowBytes: 250, KeyRate: 6, QueryRate: 15}}, agg.Groups[0].Timeline)
+
+	// merge the aggregations from different servers.
+	agg = aggregate(HotRegionsGroupByStore, "", 0)
+	agg.Merge(aggregate(HotRegionsGroupByStore, "", 0))
+	re.NoError(agg.Sort(HotRegionsSortByByte, 2))
+	re.Equal([]string{"read/1", "write/2"}, keys(agg))
+	re.Equal(700.0, agg.Groups[0].FlowBytes)
+	re.Equal(&HistoryHotLoadPoint{UpdateTime: 1000, FlowBytes: 500, KeyRate: 12, QueryRate: 30}, agg.Groups[0].Timeline[0])
+
+	// invalid arguments.
+	_, err = NewHistoryHotRegionsAggregator("zone", time.Second)
+	re.ErrorIs(err, errs.ErrInvalidArgument)
+	_, err = NewHistoryHotRegionsAggregator(HotRegionsGroupByStore, 0)
+	re.ErrorIs(err, errs.ErrInvalidArgument)
+	re.ErrorIs(agg.Sort("cpu", 0), errs.ErrInvalidArgument)
+}
+
+func TestDecodeHotRegionKey(t *testing.T) {
+	re := require.New(t)
+	_, hasKeyspace, tableID, hasTable := decodeHotRegionKey(tableKey(nil, 42, 1))
+	re.False(hasKeyspace)
+	re.True(hasTable)
+	re.Equal(int64(42), tableID)
+
+	keyspaceID, hasKeyspace, tableID, hasTable := decodeHotRegionKey(tableKey([]byte{'x', 0, 1, 2}, 7, 1))
+	re.True(hasKeyspace)
+	re.Equal(uint32(258), keyspaceID)
+	re.True(hasTable)
+	re.Equal(int64(7), tableID)
+
+	keyspaceID, hasKeyspace, _, hasTable = decodeHotRegionKey(core.HexRegionKeyStr(codec.EncodeBytes([]byte{'r', 0, 0, 3, 'k'})))
+	re.True(hasKeyspace)
+	re.Equal(uint32(3), keyspaceID)
+	re.False(hasTable)
+
+	for _, key := range []string{"", "zz", core.HexRegionKeyStr([]byte("abc"))} {
+		_, hasKeyspace, _, hasTable = decodeHotRegionKey(key)
+		re.False(hasKeyspace)
+		re.False(hasTable)
+	}
+}
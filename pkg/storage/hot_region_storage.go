@@ -393,6 +393,14 @@ func (it *HotRegionStorageIterator) Next() (*HistoryHotRegion, error) {
 	return &message, nil
 }
 
+// Release releases the underlying iterators. It should be called if the iteration
+// is stopped before Next returns (nil, nil).
+func (it *HotRegionStorageIterator) Release() {
+	for _, iter := range it.iters {
+		iter.Release()
+	}
+}
+
 // HotRegionStorePath generate hot region store key for HotRegionStorage.
 func HotRegionStorePath(hotRegionType string, updateTime int64, regionID uint64) string {
 	return path.Join(
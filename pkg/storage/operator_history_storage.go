@@ -0,0 +1,313 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/log"
+
+	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/storage/kv"
+	"github.com/tikv/pd/pkg/utils/logutil"
+	"github.com/tikv/pd/pkg/utils/syncutil"
+)
+
+// OperatorHistoryStorage is used to store the finished operators.
+// It will pull the finished operators according to the `pullInterval`,
+// and delete data beyond the `remainingDays`.
+// Close() must be called after the use.
+type OperatorHistoryStorage struct {
+	*kv.LevelDBKV
+	loopWg sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+	helper OperatorHistoryStorageHelper
+
+	lastPullTime    time.Time
+	curReservedDays uint64
+	curInterval     time.Duration
+	mu              syncutil.RWMutex
+}
+
+// HistoryOperators wraps HistoryOperator.
+//
+// NOTE: This type is exported by HTTP API. Please pay more attention when modifying it.
+type HistoryOperators struct {
+	HistoryOperator []*HistoryOperator `json:"history_operator"`
+}
+
+// HistoryOperator wraps the finished operator info,
+// it is the storage format of OperatorHistoryStorage.
+type HistoryOperator struct {
+	// FinishTime is the time when the operator finished, in ms.
+	FinishTime int64  `json:"finish_time"`
+	RegionID   uint64 `json:"region_id"`
+	// Seq is the sequence number of the operator in the operator controller,
+	// which tells apart the operators of a region finished in the same ms.
+	Seq uint64 `json:"seq"`
+	// Scheduler is the desc of the operator, which is the name of the
+	// scheduler or checker creating it.
+	Scheduler    string   `json:"scheduler"`
+	Brief        string   `json:"brief"`
+	Kind         string   `json:"kind"`
+	Steps        []string `json:"steps"`
+	StoreIDs     []uint64 `json:"store_ids"`
+	Status       string   `json:"status"`
+	CancelReason string   `json:"cancel_reason,omitempty"`
+	// Duration is the time the operator takes, in ms.
+	Duration int64 `json:"duration"`
+}
+
+// OperatorHistoryStorageHelper help operator history storage get the finished operators.
+type OperatorHistoryStorageHelper interface {
+	// GetHistoryOperators gets the operators finished since the given time.
+	GetHistoryOperators(from time.Time) ([]HistoryOperator, error)
+	// IsLeader return true means this server is leader.
+	IsLeader() bool
+	// GetOperatorHistoryWriteInterval gets interval for PD to store the finished operators.
+	GetOperatorHistoryWriteInterval() time.Duration
+	// GetOperatorHistoryReservedDays gets days the finished operators are kept.
+	GetOperatorHistoryReservedDays() uint64
+}
+
+// NewOperatorHistoryStorage creates storage to store the finished operators.
+func NewOperatorHistoryStorage(
+	ctx context.Context,
+	filePath string,
+	helper OperatorHistoryStorageHelper,
+) (*OperatorHistoryStorage, error) {
+	levelDB, err := kv.NewLevelDBKV(filePath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	h := OperatorHistoryStorage{
+		LevelDBKV:       levelDB,
+		ctx:             ctx,
+		cancel:          cancel,
+		helper:          helper,
+		curReservedDays: helper.GetOperatorHistoryReservedDays(),
+		curInterval:     helper.GetOperatorHistoryWriteInterval(),
+	}
+	h.loopWg.Add(2)
+	go h.backgroundFlush()
+	go h.backgroundDelete()
+	return &h, nil
+}
+
+// Delete the operators whose finish_time is smaller than time.Now() minus remain day in the background.
+func (h *OperatorHistoryStorage) backgroundDelete() {
+	defer logutil.LogPanic()
+
+	// make delete happened in defaultDeleteTime clock.
+	now := time.Now()
+	next := time.Date(now.Year(), now.Month(), now.Day(), defaultDeleteTime, 0, 0, 0, now.Location())
+	d := next.Sub(now)
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	isFirst := true
+	ticker := time.NewTicker(d)
+	defer func() {
+		ticker.Stop()
+		h.loopWg.Done()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			h.updateConfig()
+			if isFirst {
+				ticker.Reset(24 * time.Hour)
+				isFirst = false
+			}
+			curReservedDays := h.getCurReservedDays()
+			if curReservedDays == 0 {
+				continue
+			}
+			if err := h.delete(int(curReservedDays)); err != nil {
+				log.Error("delete operator history meet error", errs.ZapError(err))
+			}
+		case <-h.ctx.Done():
+			return
+		}
+	}
+}
+
+// Write the finished operators into db in the background.
+func (h *OperatorHistoryStorage) backgroundFlush() {
+	defer logutil.LogPanic()
+
+	ticker := time.NewTicker(h.getCurInterval())
+	defer func() {
+		ticker.Stop()
+		h.loopWg.Done()
+	}()
+	for {
+		select {
+		case <-ticker.C:
+			h.updateConfig()
+			ticker.Reset(h.getCurInterval())
+			if h.getCurReservedDays() == 0 || !h.helper.IsLeader() {
+				continue
+			}
+			if err := h.pullAndFlush(); err != nil {
+				log.Error("flush operator history meet error", errs.ZapError(err))
+			}
+		case <-h.ctx.Done():
+			return
+		}
+	}
+}
+
+// pullAndFlush pulls the operators finished since the last pull and writes
+// them into db. An operator may be pulled more than once, which is fine since
+// it is always stored with the same key of its finish time, region and sequence.
+func (h *OperatorHistoryStorage) pullAndFlush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	ops, err := h.helper.GetHistoryOperators(h.lastPullTime)
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	for i := range ops {
+		value, err := json.Marshal(&ops[i])
+		if err != nil {
+			return errs.ErrProtoMarshal.Wrap(err).GenWithStackByCause()
+		}
+		batch.Put([]byte(OperatorHistoryStorePath(ops[i].FinishTime, ops[i].RegionID, ops[i].Seq)), value)
+	}
+	if err := h.Write(batch, nil); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	h.lastPullTime = now
+	return nil
+}
+
+func (h *OperatorHistoryStorage) updateConfig() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	interval := h.helper.GetOperatorHistoryWriteInterval()
+	if interval != h.curInterval {
+		log.Info("operator history write interval changed",
+			zap.Duration("previous-interval", h.curInterval),
+			zap.Duration("new-interval", interval))
+		h.curInterval = interval
+	}
+	reservedDays := h.helper.GetOperatorHistoryReservedDays()
+	if reservedDays != h.curReservedDays {
+		log.Info("operator history reserved days changed",
+			zap.Uint64("previous-reserved-days", h.curReservedDays),
+			zap.Uint64("new-reserved-days", reservedDays))
+		h.curReservedDays = reservedDays
+	}
+}
+
+func (h *OperatorHistoryStorage) getCurInterval() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.curInterval
+}
+
+func (h *OperatorHistoryStorage) getCurReservedDays() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.curReservedDays
+}
+
+func (h *OperatorHistoryStorage) delete(reservedDays int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	batch := new(leveldb.Batch)
+	endTime := time.Now().AddDate(0, 0, 0-reservedDays).UnixNano() / int64(time.Millisecond)
+	iter := h.LevelDBKV.NewIterator(&util.Range{
+		Start: []byte(OperatorHistoryStorePath(0, 0, 0)),
+		Limit: []byte(OperatorHistoryStorePath(endTime, math.MaxUint64, math.MaxUint64)),
+	}, nil)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	iter.Release()
+	if err := h.Write(batch, nil); err != nil {
+		return errs.ErrLevelDBWrite.Wrap(err).GenWithStackByCause()
+	}
+	return nil
+}
+
+// NewIterator returns an iterator which traverses the operators finished
+// in [startTime, endTime], both are in ms.
+func (h *OperatorHistoryStorage) NewIterator(startTime, endTime int64) OperatorHistoryStorageIterator {
+	return OperatorHistoryStorageIterator{
+		iter: h.LevelDBKV.NewIterator(&util.Range{
+			Start: []byte(OperatorHistoryStorePath(startTime, 0, 0)),
+			Limit: []byte(OperatorHistoryStorePath(endTime, math.MaxUint64, math.MaxUint64)),
+		}, nil),
+	}
+}
+
+// Close closes the kv.
+func (h *OperatorHistoryStorage) Close() error {
+	h.cancel()
+	h.loopWg.Wait()
+	if err := h.LevelDBKV.Close(); err != nil {
+		return errs.ErrLevelDBClose.Wrap(err).GenWithStackByArgs()
+	}
+	return nil
+}
+
+// OperatorHistoryStorageIterator iterates over the HistoryOperator.
+type OperatorHistoryStorageIterator struct {
+	iter iterator.Iterator
+}
+
+// Next moves the iterator to the next key/value pair.
+// And return HistoryOperator which it is now pointing to.
+// It will return (nil, nil), if there is no more HistoryOperator.
+func (it *OperatorHistoryStorageIterator) Next() (*HistoryOperator, error) {
+	if !it.iter.Next() {
+		it.iter.Release()
+		return nil, nil
+	}
+	var op HistoryOperator
+	if err := json.Unmarshal(it.iter.Value(), &op); err != nil {
+		it.iter.Release()
+		return nil, err
+	}
+	return &op, nil
+}
+
+// OperatorHistoryStorePath generates the key of a finished operator for OperatorHistoryStorage.
+func OperatorHistoryStorePath(finishTime int64, regionID, seq uint64) string {
+	return path.Join(
+		"schedule",
+		"operator_history",
+		fmt.Sprintf("%020d", finishTime),
+		fmt.Sprintf("%020d", regionID),
+		fmt.Sprintf("%020d", seq),
+	)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockOperatorHistoryHelper struct {
+	ops          []HistoryOperator
+	lastFrom     time.Time
+	reservedDays uint64
+	pullInterval time.Duration
+}
+
+func (m *mockOperatorHistoryHelper) GetHistoryOperators(from time.Time) ([]HistoryOperator, error) {
+	m.lastFrom = from
+	result := make([]HistoryOperator, len(m.ops))
+	copy(result, m.ops)
+	return result, nil
+}
+
+func (*mockOperatorHistoryHelper) IsLeader() bool {
+	return true
+}
+
+func (m *mockOperatorHistoryHelper) GetOperatorHistoryWriteInterval() time.Duration {
+	return m.pullInterval
+}
+
+func (m *mockOperatorHistoryHelper) GetOperatorHistoryReservedDays() uint64 {
+	return m.reservedDays
+}
+
+func TestOperatorHistoryStorage(t *testing.T) {
+	re := require.New(t)
+	helper := &mockOperatorHistoryHelper{reservedDays: 1, pullInterval: 10 * time.Minute}
+	store, err := NewOperatorHistoryStorage(context.Background(), t.TempDir(), helper)
+	re.NoError(err)
+	defer store.Close()
+
+	now := time.Now()
+	ts := func(d time.Duration) int64 {
+		return now.Add(d).UnixNano() / int64(time.Millisecond)
+	}
+	helper.ops = []HistoryOperator{
+		{FinishTime: ts(-2 * 24 * time.Hour), RegionID: 1, Scheduler: "balance-leader-scheduler", StoreIDs: []uint64{1, 2}},
+		{FinishTime: ts(0), RegionID: 2, Scheduler: "balance-region-scheduler", StoreIDs: []uint64{2, 3}, Steps: []string{"add peer on store 3", "remove peer on store 2"}},
+		{FinishTime: ts(time.Second), RegionID: 3, Seq: 3, Scheduler: "replica-checker", Status: "CANCEL", CancelReason: "timeout"},
+		// The operators of a region finished in the same ms are told apart by the sequence.
+		{FinishTime: ts(time.Second), RegionID: 3, Seq: 4, Scheduler: "rule-checker"},
+	}
+	re.NoError(store.pullAndFlush())
+	re.True(helper.lastFrom.IsZero())
+	// Pulling the same operators again does not duplicate them.
+	re.NoError(store.pullAndFlush())
+	re.False(helper.lastFrom.IsZero())
+
+	iter := store.NewIterator(0, ts(time.Hour))
+	var ops []*HistoryOperator
+	for next, err := iter.Next(); next != nil && err == nil; next, err = iter.Next() {
+		ops = append(ops, next)
+	}
+	re.Len(ops, 4)
+	for i, op := range ops {
+		re.Equal(helper.ops[i], *op)
+	}
+
+	iter = store.NewIterator(ts(-time.Hour), ts(0))
+	next, err := iter.Next()
+	re.NoError(err)
+	re.Equal(uint64(2), next.RegionID)
+	next, err = iter.Next()
+	re.NoError(err)
+	re.Nil(next)
+
+	// The operators finished before the reserved days are deleted.
+	re.NoError(store.delete(1))
+	iter = store.NewIterator(0, ts(time.Hour))
+	next, err = iter.Next()
+	re.NoError(err)
+	re.Equal(uint64(2), next.RegionID)
+}
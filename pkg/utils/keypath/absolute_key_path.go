@@ -59,6 +59,13 @@ const (
 	ruleGroupPathFormat     = "/pd/%d/rule_group/%s"   // "/pd/{cluster_id}/rule_group/{group_id}"
 	regionLablePathFormat   = "/pd/%d/region_label/%s" // "/pd/{cluster_id}/region_label/{label_id}"
 	regionLabelPrefixFormat = "/pd/%d/region_label/"   // "/pd/{cluster_id}/region_label/"
+	// ruleHistoryPathFormat should not be under the ruleCommonPrefixFormat, which is watched by the scheduling server.
+	ruleHistoryPathFormat = "/pd/%d/placement_history/%s" // "/pd/{cluster_id}/placement_history/{version}"
+
+	// scheduleTimeWindowsPathFormat should not be under the scheduler config prefix, which is loaded as the scheduler configs.
+	scheduleTimeWindowsPathFormat = "/pd/%d/schedule_time_windows" // "/pd/{cluster_id}/schedule_time_windows"
+	// shadowSchedulersPathFormat should not be under the scheduler config prefix either.
+	shadowSchedulersPathFormat = "/pd/%d/shadow_schedulers" // "/pd/{cluster_id}/shadow_schedulers"
 
 	// Maintenance task path format
 	maintenanceTaskPathFormat = "/pd/%d/maintenance/%s" // "/pd/{cluster_id}/maintenance/{task_type}"
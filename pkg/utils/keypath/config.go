@@ -30,3 +30,13 @@ func SchedulerConfigPathPrefix() string {
 func SchedulerConfigPath(schedulerName string) string {
 	return fmt.Sprintf(schedulerConfigPathFormat, ClusterID(), schedulerName)
 }
+
+// ScheduleTimeWindowsPath returns the path to save the scheduling time windows of the schedulers and checkers.
+func ScheduleTimeWindowsPath() string {
+	return fmt.Sprintf(scheduleTimeWindowsPathFormat, ClusterID())
+}
+
+// ShadowSchedulersPath returns the path to save the names of the schedulers running in shadow mode.
+func ShadowSchedulersPath() string {
+	return fmt.Sprintf(shadowSchedulersPathFormat, ClusterID())
+}
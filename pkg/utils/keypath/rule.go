@@ -52,3 +52,13 @@ func RuleGroupPathPrefix() string {
 func RegionLabelPathPrefix() string {
 	return RegionLabelKeyPath("")
 }
+
+// RuleHistoryPath returns the path to save the placement rule history with the given version.
+func RuleHistoryPath(version uint64) string {
+	return fmt.Sprintf(ruleHistoryPathFormat, ClusterID(), fmt.Sprintf("%020d", version))
+}
+
+// RuleHistoryPathPrefix returns the path prefix to save the placement rule histories.
+func RuleHistoryPathPrefix() string {
+	return fmt.Sprintf(ruleHistoryPathFormat, ClusterID(), "")
+}
@@ -46,6 +46,10 @@ import (
 // the swagger could generate the right definitions for the config structs.
 var _ *sc.ScheduleConfig = nil
 
+// replicationConfigHistorySource is the source of the placement rule histories recorded by
+// the updates of the replication config.
+const replicationConfigHistorySource = "replication-config"
+
 type confHandler struct {
 	svr *server.Server
 	rd  *render.Render
@@ -256,7 +260,7 @@ func (h *confHandler) updateReplication(config *config.Config, key string, value
 	}
 
 	if updated {
-		err = h.svr.SetReplicationConfig(config.Replication)
+		err = h.setReplicationConfig(config.Replication)
 	}
 	return err
 }
@@ -452,13 +456,27 @@ func (h *confHandler) SetReplicationConfig(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.svr.SetReplicationConfig(*config); err != nil {
+	if err := h.setReplicationConfig(*config); err != nil {
 		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	h.rd.JSON(w, http.StatusOK, "The config is updated.")
 }
 
+// setReplicationConfig updates the replication config, and attributes the rule histories
+// recorded by the update of the default rule to the replication config.
+func (h *confHandler) setReplicationConfig(cfg sc.ReplicationConfig) error {
+	rc := h.svr.GetRaftCluster()
+	if rc == nil {
+		return h.svr.SetReplicationConfig(cfg)
+	}
+	var err error
+	rc.GetRuleManager().WithHistorySource(replicationConfigHistorySource, func() {
+		err = h.svr.SetReplicationConfig(cfg)
+	})
+	return err
+}
+
 // GetLabelPropertyConfig gets the label property config.
 // @Tags     config
 // @Summary  Get label property config.
@@ -23,6 +23,9 @@ import (
 
 	"github.com/unrolled/render"
 
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/statistics/utils"
 	"github.com/tikv/pd/server"
 )
@@ -166,3 +169,37 @@ func (h *hotStatusHandler) GetHistoryHotRegions(w http.ResponseWriter, r *http.R
 	}
 	h.rd.JSON(w, http.StatusOK, results)
 }
+
+// GetHistoryHotRegionsAggregation aggregates the history hot regions.
+// @Tags     hotspot
+// @Summary  Aggregate the history hot regions by region, table, keyspace or store.
+// @Accept   json
+// @Produce  json
+// @Success  200  {object}  storage.HistoryHotRegionsAggregation
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /hotspot/regions/history/aggregate [get]
+func (h *hotStatusHandler) GetHistoryHotRegionsAggregation(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	request := &server.HistoryHotRegionsAggregateRequest{}
+	err = json.Unmarshal(data, request)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results, err := h.AggregateHistoryHotRegions(request)
+	if err != nil {
+		if errors.ErrorEqual(err, errs.ErrInvalidArgument) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, results)
+}
@@ -22,6 +22,9 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
 
+	"github.com/pingcap/errors"
+
+	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/schedule/operator"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/server"
@@ -203,3 +206,159 @@ func (h *operatorHandler) GetOperatorRecords(w http.ResponseWriter, r *http.Requ
 	}
 	h.r.JSON(w, http.StatusOK, records)
 }
+
+// GetOperatorBatches lists the running and recently finished operator batches.
+// @Tags     operator
+// @Summary  List operator batches.
+// @Produce  json
+// @Success  200  {array}   operator.BatchObject
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches [get]
+func (h *operatorHandler) GetOperatorBatches(w http.ResponseWriter, _ *http.Request) {
+	batches, err := h.Handler.GetOperatorBatches()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, batches)
+}
+
+// CreateOperatorBatch creates a batch of operators with ordering dependencies.
+// @Tags     operator
+// @Summary  Create an operator batch.
+// @Accept   json
+// @Param    body  body  object  true  "json params"
+// @Produce  json
+// @Success  200  {integer}  int     "The id of the created batch."
+// @Failure  400  {string}   string  "The input is invalid."
+// @Router   /operators/batches [post]
+func (h *operatorHandler) CreateOperatorBatch(w http.ResponseWriter, r *http.Request) {
+	var input map[string]any
+	if err := apiutil.ReadJSONRespondError(h.r, w, r.Body, &input); err != nil {
+		return
+	}
+
+	id, err := h.AddOperatorBatch(input)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, id)
+}
+
+// GetOperatorBatch gets an operator batch by id.
+// @Tags     operator
+// @Summary  Get an operator batch.
+// @Param    batch_id  path  int  true  "The id of the batch"
+// @Produce  json
+// @Success  200  {object}  operator.BatchObject
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The batch does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches/{batch_id} [get]
+func (h *operatorHandler) GetOperatorBatch(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["batch_id"], 10, 64)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	batch, err := h.Handler.GetOperatorBatch(id)
+	if err != nil {
+		if errors.ErrorEqual(err, errs.ErrOperatorNotFound) {
+			h.r.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, batch)
+}
+
+// DeleteOperatorBatch cancels an operator batch and all its running operators.
+// @Tags     operator
+// @Summary  Cancel an operator batch.
+// @Param    batch_id  path  int  true  "The id of the batch"
+// @Produce  json
+// @Success  200  {string}  string  "The operator batch is canceled."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The batch does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/batches/{batch_id} [delete]
+func (h *operatorHandler) DeleteOperatorBatch(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(mux.Vars(r)["batch_id"], 10, 64)
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.CancelOperatorBatch(id); err != nil {
+		if errors.ErrorEqual(err, errs.ErrOperatorNotFound) {
+			h.r.JSON(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "The operator batch is canceled.")
+}
+
+// GetOperatorHistory lists the persisted finished operators.
+// @Tags     operator
+// @Summary  List the persisted finished operators.
+// @Param    start      query  integer  false  "From Unix timestamp"
+// @Param    end        query  integer  false  "To Unix timestamp, now by default"
+// @Param    region_id  query  integer  false  "Region id, can be specified multiple times"
+// @Param    store_id   query  integer  false  "Store id, can be specified multiple times"
+// @Param    scheduler  query  string   false  "The scheduler or checker creating the operator, can be specified multiple times"
+// @Produce  json
+// @Success  200  {object}  storage.HistoryOperators
+// @Failure  400  {string}  string  "The request is invalid."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /operators/history [get]
+func (h *operatorHandler) GetOperatorHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, err := apiutil.ParseTime(query.Get("start"))
+	if err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	end := time.Now()
+	if query.Has("end") {
+		if end, err = apiutil.ParseTime(query.Get("end")); err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	request := &server.HistoryOperatorsRequest{
+		StartTime:  start.UnixNano() / int64(time.Millisecond),
+		EndTime:    end.UnixNano() / int64(time.Millisecond),
+		Schedulers: query["scheduler"],
+	}
+	if request.RegionIDs, err = parseUint64Query(query["region_id"]); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if request.StoreIDs, err = parseUint64Query(query["store_id"]); err != nil {
+		h.r.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	results, err := h.GetAllRequestHistoryOperators(request)
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, results)
+}
+
+func parseUint64Query(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
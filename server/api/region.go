@@ -147,6 +147,78 @@ func (h *regionsHandler) CheckRegionsReplicated(w http.ResponseWriter, r *http.R
 	h.rd.JSON(w, http.StatusOK, state)
 }
 
+// ExplainRegion explains why the region is or is not scheduled.
+// @Tags     region
+// @Summary  Run all checkers and schedulers against the region, and list the filters, rules and limits which affect it.
+// @Param    id  path  integer  true  "Region Id"
+// @Produce  json
+// @Success  200  {object}  handler.RegionExplanation
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The region does not exist."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/{id}/explain [get]
+func (h *regionsHandler) ExplainRegion(w http.ResponseWriter, r *http.Request) {
+	region, code, err := h.PreCheckForRegion(mux.Vars(r)["id"])
+	if err != nil {
+		h.rd.JSON(w, code, err.Error())
+		return
+	}
+	explanation, err := h.Handler.ExplainRegion(region)
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, explanation)
+}
+
+// GetSplitAdvices lists the load-based split advices of the regions.
+// @Tags     region
+// @Summary  List the regions whose load is concentrated in a few buckets and the recommended split keys.
+// @Produce  json
+// @Success  200  {array}   checker.SplitAdvice
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/split-advices [get]
+func (h *regionsHandler) GetSplitAdvices(w http.ResponseWriter, _ *http.Request) {
+	advices, err := h.Handler.GetSplitAdvices()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, advices)
+}
+
+// GetRepairProgress gets the status of repairing the regions by the rule checker.
+// @Tags     region
+// @Summary  Get the cross location repair budget and the regions waiting for it, grouped by the healthy replica count.
+// @Produce  json
+// @Success  200  {object}  checker.RepairProgress
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/repair-progress [get]
+func (h *regionsHandler) GetRepairProgress(w http.ResponseWriter, _ *http.Request) {
+	progress, err := h.Handler.GetRepairProgress()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, progress)
+}
+
+// GetMergeBacklog gets the runs of the small regions found by the range merge planner.
+// @Tags     region
+// @Summary  Get the pending merges of the long runs of small regions.
+// @Produce  json
+// @Success  200  {object}  checker.MergeBacklog
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /regions/merge-backlog [get]
+func (h *regionsHandler) GetMergeBacklog(w http.ResponseWriter, _ *http.Request) {
+	backlog, err := h.Handler.GetMergeBacklog()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, backlog)
+}
+
 type regionsHandler struct {
 	*server.Handler
 	svr *server.Server
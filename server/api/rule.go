@@ -20,11 +20,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/unrolled/render"
 
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/server"
@@ -70,7 +72,15 @@ func (m ruleMiddleware) middleware(h http.Handler) http.Handler {
 			return
 		}
 		ctx := context.WithValue(r.Context(), ruleCtxKey{}, manager)
-		h.ServeHTTP(w, r.WithContext(ctx))
+		if r.Method == http.MethodGet {
+			h.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		// The rule histories recorded by the request are attributed to the caller.
+		ip, _ := apiutil.GetIPPortFromHTTPRequest(r)
+		manager.WithHistorySource(apiutil.GetCallerIDOnHTTP(r)+"@"+ip, func() {
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
 	})
 }
 
@@ -197,6 +207,27 @@ func (h *ruleHandler) CheckRegionPlacementRule(w http.ResponseWriter, r *http.Re
 	h.rd.JSON(w, http.StatusOK, regionFit)
 }
 
+// GetRuleComplianceReport returns the compliance of all rules.
+// @Tags     rule
+// @Summary  Get the number of the satisfied, under-replicated and orphan peer regions of each rule.
+// @Produce  json
+// @Success  200  {object}  checker.RuleComplianceReport
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/compliance [get]
+func (h *ruleHandler) GetRuleComplianceReport(w http.ResponseWriter, _ *http.Request) {
+	report, err := h.Handler.GetRuleComplianceReport()
+	if err == errs.ErrPlacementDisabled {
+		h.rd.JSON(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, report)
+}
+
 // GetRulesByKey returns all rules of the cluster by key.
 // @Tags     rule
 // @Summary  List all rules of cluster by key.
@@ -385,7 +416,11 @@ func (h *ruleHandler) SetGroupConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := manager.SetRuleGroup(&ruleGroup); err != nil {
-		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		if errs.ErrRuleContent.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 	cluster := getCluster(r)
@@ -476,6 +511,87 @@ func (h *ruleHandler) SetPlacementRules(w http.ResponseWriter, r *http.Request)
 	h.rd.JSON(w, http.StatusOK, "Update rules and groups successfully.")
 }
 
+// SimulatePlacementRules estimates the result of applying the rules and groups configuration.
+// @Tags     rule
+// @Summary  Simulate applying the rules and groups configuration against the current stores and regions.
+// @Param    partial  query  bool  false  "if partially update rules"  default(false)
+// @Produce  json
+// @Success  200  {object}  placement.SimulationResult
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/simulate [post]
+func (h *ruleHandler) SimulatePlacementRules(w http.ResponseWriter, r *http.Request) {
+	cluster := getCluster(r)
+	manager := getRuleManager(r)
+	var groups []placement.GroupBundle
+	if err := apiutil.ReadJSONRespondError(h.rd, w, r.Body, &groups); err != nil {
+		return
+	}
+	_, partial := r.URL.Query()["partial"]
+	res, err := manager.SetKeyType(h.svr.GetConfig().PDServerCfg.KeyType).
+		SimulateGroupBundles(cluster, cluster.GetRegions(), groups, !partial, checker.NewSimulationTargetSelector(cluster))
+	if err != nil {
+		if errs.ErrRuleContent.Equal(err) || errs.ErrHexDecodingString.Equal(err) {
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, res)
+}
+
+// GetRuleHistory returns the history of the rule changes.
+// @Tags     rule
+// @Summary  List the history of the rule changes, the latest one first.
+// @Produce  json
+// @Success  200  {array}   placement.RuleHistory
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/history [get]
+func (h *ruleHandler) GetRuleHistory(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	histories, err := manager.GetHistories()
+	if err != nil {
+		h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, histories)
+}
+
+// RollbackRules rolls back the rules to the given version of the history.
+// @Tags     rule
+// @Summary  Roll back the rules and groups to the state right after the given version.
+// @Param    version  path  integer  true  "The version of the history"
+// @Produce  json
+// @Success  200  {string}  string  "Rollback rules successfully."
+// @Failure  400  {string}  string  "The input is invalid."
+// @Failure  404  {string}  string  "The history of the version does not exist."
+// @Failure  412  {string}  string  "Placement rules feature is disabled."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /config/rules/rollback/{version} [post]
+func (h *ruleHandler) RollbackRules(w http.ResponseWriter, r *http.Request) {
+	manager := getRuleManager(r)
+	version, err := strconv.ParseUint(mux.Vars(r)["version"], 10, 64)
+	if err != nil {
+		h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := manager.RollbackToVersion(version); err != nil {
+		switch {
+		case errs.ErrRuleHistoryNotFound.Equal(err):
+			h.rd.JSON(w, http.StatusNotFound, err.Error())
+		case errs.ErrRuleContent.Equal(err), errs.ErrHexDecodingString.Equal(err):
+			h.rd.JSON(w, http.StatusBadRequest, err.Error())
+		default:
+			h.rd.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	h.rd.JSON(w, http.StatusOK, "Rollback rules successfully.")
+}
+
 // GetPlacementRuleByGroup returns group config and all rules belong to the group.
 // @Tags     rule
 // @Summary  Get group config and all rules belong to the group.
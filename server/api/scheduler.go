@@ -33,6 +33,7 @@ import (
 
 	"github.com/tikv/pd/pkg/errs"
 	"github.com/tikv/pd/pkg/mcs/utils/constant"
+	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/slice"
 	"github.com/tikv/pd/pkg/utils/apiutil"
@@ -57,6 +58,7 @@ func newSchedulerHandler(svr *server.Server, r *render.Render) *schedulerHandler
 // GetSchedulers lists all schedulers.
 // @Tags     scheduler
 // @Summary  List all created schedulers by status.
+// @Param    status  query  string  false  "The status of the schedulers, such as paused, disabled, shadow and out-of-window."
 // @Produce  json
 // @Success  200  {array}   string
 // @Failure  500  {string}  string  "PD server failed to proceed the request."
@@ -333,6 +335,119 @@ func (h *schedulerHandler) PauseOrResumeScheduler(w http.ResponseWriter, r *http
 	h.r.JSON(w, http.StatusOK, "Pause or resume the scheduler successfully.")
 }
 
+// SetSchedulerShadow enables or disables the shadow mode of a scheduler.
+// @Tags     scheduler
+// @Summary  Enable or disable the shadow mode of a scheduler.
+// @Accept   json
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Param    body  body  object  true  "json params"
+// @Produce  json
+// @Success  200  {string}  string  "Set the shadow mode of the scheduler successfully."
+// @Failure  400  {string}  string  "Bad format request."
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/shadow/{name} [post]
+func (h *schedulerHandler) SetSchedulerShadow(w http.ResponseWriter, r *http.Request) {
+	var input map[string]bool
+	if err := apiutil.ReadJSONRespondError(h.r, w, r.Body, &input); err != nil {
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	enable, ok := input["enable"]
+	if !ok {
+		h.r.JSON(w, http.StatusBadRequest, "missing enable")
+		return
+	}
+	if err := h.Handler.SetSchedulerShadow(name, enable); err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "Set the shadow mode of the scheduler successfully.")
+}
+
+// GetSchedulerShadowResults lists the shadow results of a scheduler.
+// @Tags     scheduler
+// @Summary  List the operators recorded by a scheduler in shadow mode.
+// @Param    name  path  string  true  "The name of the scheduler."
+// @Produce  json
+// @Success  200  {array}   schedulers.ShadowResult
+// @Failure  404  {string}  string  "The scheduler is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/shadow/{name} [get]
+func (h *schedulerHandler) GetSchedulerShadowResults(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	results, err := h.Handler.GetSchedulerShadowResults(name)
+	if err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, results)
+}
+
+// GetScheduleTimeWindows lists the scheduling time windows.
+// @Tags     scheduler
+// @Summary  List the scheduling time windows of all the schedulers and checkers.
+// @Produce  json
+// @Success  200  {object}  map[string][]sc.TimeWindow
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/time-windows [get]
+func (h *schedulerHandler) GetScheduleTimeWindows(w http.ResponseWriter, _ *http.Request) {
+	windows, err := h.Handler.GetScheduleTimeWindows()
+	if err != nil {
+		h.r.JSON(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.r.JSON(w, http.StatusOK, windows)
+}
+
+// SetScheduleTimeWindows sets the scheduling time windows of a scheduler or checker.
+// @Tags     scheduler
+// @Summary  Set the scheduling time windows of a scheduler or checker, it only runs in these windows.
+// @Accept   json
+// @Param    name  path  string               true  "The name of the scheduler or checker."
+// @Param    body  body  []sc.TimeWindow  true  "The scheduling time windows."
+// @Produce  json
+// @Success  200  {string}  string  "Set the scheduling time windows successfully."
+// @Failure  400  {string}  string  "Bad format request."
+// @Failure  404  {string}  string  "The scheduler or checker is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/time-windows/{name} [post]
+func (h *schedulerHandler) SetScheduleTimeWindows(w http.ResponseWriter, r *http.Request) {
+	var windows []*sc.TimeWindow
+	if err := apiutil.ReadJSONRespondError(h.r, w, r.Body, &windows); err != nil {
+		return
+	}
+	for _, window := range windows {
+		if err := window.Adjust(); err != nil {
+			h.r.JSON(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := h.Handler.SetScheduleTimeWindows(mux.Vars(r)["name"], windows); err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "Set the scheduling time windows successfully.")
+}
+
+// DeleteScheduleTimeWindows deletes the scheduling time windows of a scheduler or checker.
+// @Tags     scheduler
+// @Summary  Delete the scheduling time windows of a scheduler or checker, it is always allowed to run after that.
+// @Param    name  path  string  true  "The name of the scheduler or checker."
+// @Produce  json
+// @Success  200  {string}  string  "Delete the scheduling time windows successfully."
+// @Failure  404  {string}  string  "The scheduler or checker is not found."
+// @Failure  500  {string}  string  "PD server failed to proceed the request."
+// @Router   /schedulers/time-windows/{name} [delete]
+func (h *schedulerHandler) DeleteScheduleTimeWindows(w http.ResponseWriter, r *http.Request) {
+	if err := h.Handler.SetScheduleTimeWindows(mux.Vars(r)["name"], nil); err != nil {
+		h.handleErr(w, err)
+		return
+	}
+	h.r.JSON(w, http.StatusOK, "Delete the scheduling time windows successfully.")
+}
+
 func (h *schedulerHandler) isSchedulerExist(scheduler types.CheckerSchedulerType) (bool, error) {
 	rc, err := h.GetRaftCluster()
 	if err != nil {
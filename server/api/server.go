@@ -48,13 +48,23 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	//	"/operators", http.MethodGet
 	//	"/operators", http.MethodPost
 	//	"/operators/records",http.MethodGet
+	//	"/operators/batches", http.MethodGet
+	//	"/operators/batches", http.MethodPost
+	//	"/operators/batches/{batch_id}", http.MethodGet
+	//	"/operators/batches/{batch_id}", http.MethodDelete
 	//	"/operators/{region_id}", http.MethodGet
 	//	"/operators/{region_id}", http.MethodDelete
 	//	"/checker/{name}", http.MethodPost
 	//	"/checker/{name}", http.MethodGet
+	//	"/regions/{id}/explain", http.MethodGet
+	//	"/regions/split-advices", http.MethodGet
+	//	"/regions/repair-progress", http.MethodGet
+	//	"/regions/merge-backlog", http.MethodGet
 	//	"/schedulers", http.MethodGet
 	//	"/schedulers/{name}", http.MethodPost, which is to be used to pause or resume the scheduler rather than create a new scheduler
 	//	"/schedulers/diagnostic/{name}", http.MethodGet
+	//	"/schedulers/shadow/{name}", http.MethodGet
+	//	"/schedulers/time-windows", http.MethodGet
 	//	"/scheduler-config", http.MethodGet
 	//	"/hotspot/regions/read", http.MethodGet
 	//	"/hotspot/regions/write", http.MethodGet
@@ -62,8 +72,12 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 	//	"/hotspot/stores", http.MethodGet
 	//	"/hotspot/buckets", http.MethodGet
 	// Following requests are **not** redirected:
+	//	"/operators/history", http.MethodGet
 	//	"/schedulers", http.MethodPost
 	//	"/schedulers/{name}", http.MethodDelete
+	//	"/schedulers/time-windows/{name}", http.MethodPost
+	//	"/schedulers/time-windows/{name}", http.MethodDelete
+	//	"/schedulers/shadow/{name}", http.MethodPost
 	//  Because the writing of all the config of the scheduling service is in the PD,
 	// 	we should not post and delete the scheduler directly in the scheduling service.
 	router.PathPrefix(APIPrefix).Handler(negroni.New(
@@ -78,7 +92,12 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 				prefix+"/operators",
 				scheapi.APIPathPrefix+"/operators",
 				constant.SchedulingServiceName,
-				[]string{http.MethodPost, http.MethodGet, http.MethodDelete}),
+				[]string{http.MethodPost, http.MethodGet, http.MethodDelete},
+				func(r *http.Request) bool {
+					// The operator history is persisted by PD, which pulls the finished
+					// operators from the scheduling server.
+					return r.URL.Path != prefix+"/operators/history"
+				}),
 			serverapi.MicroserviceRedirectRule(
 				prefix+"/checker", // Note: this is a typo in the original code
 				scheapi.APIPathPrefix+"/checkers",
@@ -114,6 +133,31 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 				scheapi.APIPathPrefix+"/regions/replicated",
 				constant.SchedulingServiceName,
 				[]string{http.MethodGet}),
+			serverapi.MicroserviceRedirectRule(
+				prefix+"/regions/split-advices",
+				scheapi.APIPathPrefix+"/regions/split-advices",
+				constant.SchedulingServiceName,
+				[]string{http.MethodGet}),
+			serverapi.MicroserviceRedirectRule(
+				prefix+"/regions/repair-progress",
+				scheapi.APIPathPrefix+"/regions/repair-progress",
+				constant.SchedulingServiceName,
+				[]string{http.MethodGet}),
+			serverapi.MicroserviceRedirectRule(
+				prefix+"/regions/merge-backlog",
+				scheapi.APIPathPrefix+"/regions/merge-backlog",
+				constant.SchedulingServiceName,
+				[]string{http.MethodGet}),
+			serverapi.MicroserviceRedirectRule(
+				prefix+"/regions/",
+				scheapi.APIPathPrefix+"/regions",
+				constant.SchedulingServiceName,
+				[]string{http.MethodGet},
+				func(r *http.Request) bool {
+					// Only the explanation is served by the scheduling service, other
+					// region information is still served by PD.
+					return strings.HasSuffix(r.URL.Path, "/explain")
+				}),
 			serverapi.MicroserviceRedirectRule(
 				prefix+"/config/region-label/rules",
 				scheapi.APIPathPrefix+"/config/region-label/rules",
@@ -128,12 +172,20 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 				prefix+"/hotspot",
 				scheapi.APIPathPrefix+"/hotspot",
 				constant.SchedulingServiceName,
-				[]string{http.MethodGet}),
+				[]string{http.MethodGet},
+				func(r *http.Request) bool {
+					// The history hot regions are aggregated from the storage of PD.
+					return !strings.HasSuffix(r.URL.Path, "/history/aggregate")
+				}),
 			serverapi.MicroserviceRedirectRule(
 				prefix+"/config/rules",
 				scheapi.APIPathPrefix+"/config/rules",
 				constant.SchedulingServiceName,
-				[]string{http.MethodGet}),
+				[]string{http.MethodGet},
+				func(r *http.Request) bool {
+					// The rule history is persisted by PD only.
+					return !strings.HasSuffix(r.URL.Path, "/history")
+				}),
 			serverapi.MicroserviceRedirectRule(
 				prefix+"/config/rule/",
 				scheapi.APIPathPrefix+"/config/rule",
@@ -170,7 +222,12 @@ func NewHandler(_ context.Context, svr *server.Server) (http.Handler, apiutil.AP
 				prefix+"/schedulers/", // Note: this means "/schedulers/{name}", which is to be used to pause or resume the scheduler
 				scheapi.APIPathPrefix+"/schedulers",
 				constant.SchedulingServiceName,
-				[]string{http.MethodPost}),
+				[]string{http.MethodPost},
+				func(r *http.Request) bool {
+					// The scheduling time windows and the shadow mode are persisted by PD like the scheduler configs.
+					return !strings.HasPrefix(r.URL.Path, prefix+"/schedulers/time-windows") &&
+						!strings.HasPrefix(r.URL.Path, prefix+"/schedulers/shadow")
+				}),
 		),
 		negroni.Wrap(r)),
 	)
@@ -134,6 +134,7 @@ type Server interface {
 	ReplicateFileToMember(ctx context.Context, member *pdpb.Member, name string, data []byte) error
 	GetKeyspaceGroupManager() *keyspace.GroupManager
 	IsKeyspaceGroupEnabled() bool
+	GetHistoryHotRegionStorage() *storage.HotRegionStorage
 }
 
 // RaftCluster is used for cluster config management.
@@ -184,6 +185,7 @@ type RaftCluster struct {
 	independentServices      sync.Map
 	hbstreams                *hbstream.HeartbeatStreams
 	tsoAllocator             *tso.Allocator
+	hotRegionStorage         *storage.HotRegionStorage
 
 	// heartbeatRunner is used to process the subtree update task asynchronously.
 	heartbeatRunner ratelimit.Runner
@@ -237,6 +239,11 @@ func NewRaftCluster(
 	}
 }
 
+// GetHistoryHotRegionStorage returns the storage of the history hot regions.
+func (c *RaftCluster) GetHistoryHotRegionStorage() *storage.HotRegionStorage {
+	return c.hotRegionStorage
+}
+
 // GetStoreConfig returns the store config.
 func (c *RaftCluster) GetStoreConfig() sc.StoreConfigProvider {
 	return c.GetOpts()
@@ -334,6 +341,7 @@ func (c *RaftCluster) Start(s Server, bootstrap bool) (err error) {
 		return nil
 	}
 	c.isKeyspaceGroupEnabled = s.IsKeyspaceGroupEnabled()
+	c.hotRegionStorage = s.GetHistoryHotRegionStorage()
 	err = c.InitCluster(s.GetAllocator(), s.GetPersistOptions(), s.GetHBStreams(), s.GetKeyspaceGroupManager())
 	if err != nil {
 		return err
@@ -1113,6 +1121,7 @@ func (c *RaftCluster) HandleStoreHeartbeat(heartbeat *pdpb.StoreHeartbeatRequest
 				utils.RegionWriteBytes:    0,
 				utils.RegionWriteKeys:     0,
 				utils.RegionWriteQueryNum: 0,
+				utils.RegionReadCPU:       float64(region.GetStoreReadCPU(storeID, interval)),
 			}
 			checkReadPeerTask := func(cache *statistics.HotPeerCache) {
 				stats := cache.CheckPeerFlow(region, []*metapb.Peer{peer}, loads, interval)
@@ -1872,6 +1881,7 @@ func (c *RaftCluster) checkStore(store *core.StoreInfo, stores []*core.StoreInfo
 		}
 	}
 	c.progressManager.UpdateProgress(store, regionSize, threshold)
+	c.progressManager.UpdateRepairProgress(store, regionSize, c.opt.GetMaxStoreDownTime())
 	return
 }
 
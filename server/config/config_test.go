@@ -146,6 +146,18 @@ func TestValidation(t *testing.T) {
 	re.NoError(cfg.Schedule.Validate())
 	cfg.Schedule.TolerantSizeRatio = -0.6
 	re.Error(cfg.Schedule.Validate())
+	cfg.Schedule.TolerantSizeRatio = 0
+	// The preemption requires the v2 store limit.
+	cfg.Schedule.OperatorPreemptionPolicy = "replica"
+	re.Error(cfg.Schedule.Validate())
+	cfg.Schedule.StoreLimitVersion = "v2"
+	re.NoError(cfg.Schedule.Validate())
+	cfg.Schedule.OperatorPreemptionPolicy = "none"
+	cfg.Schedule.StoreLimitVersion = "v1"
+	cfg.Schedule.SchedulerStoreLimitQuota = map[string]float64{"balance-hot-region-scheduler": 0.3}
+	re.NoError(cfg.Schedule.Validate())
+	cfg.Schedule.SchedulerStoreLimitQuota["balance-hot-region-scheduler"] = 1.5
+	re.Error(cfg.Schedule.Validate())
 	// check quota
 	re.Equal(defaultQuotaBackendBytes, cfg.QuotaBackendBytes)
 	// check request bytes
@@ -203,6 +215,8 @@ leader-schedule-limit = 0
 	re.Equal(0, cfg.Log.File.MaxDays)
 	re.Equal(0, cfg.Log.File.MaxBackups)
 	re.Equal(uint64(0), cfg.Schedule.MaxMergeRegionKeys)
+	re.Equal("none", cfg.Schedule.OperatorRollbackPolicy)
+	re.Equal("none", cfg.Schedule.OperatorPreemptionPolicy)
 	re.Equal("http://127.0.0.1:9090", cfg.PDServerCfg.MetricStorage)
 
 	re.Equal(defaultTSOUpdatePhysicalInterval, cfg.TSOUpdatePhysicalInterval.Duration)
@@ -316,6 +316,77 @@ func (o *PersistOptions) SetEnableDiagnostic(enable bool) {
 	o.SetScheduleConfig(v)
 }
 
+// GetOperatorRollbackPolicy returns the policy of rolling back the partially applied operators.
+func (o *PersistOptions) GetOperatorRollbackPolicy() string {
+	return o.GetScheduleConfig().OperatorRollbackPolicy
+}
+
+// GetOperatorPreemptionPolicy returns the policy of preempting the lower priority operators.
+func (o *PersistOptions) GetOperatorPreemptionPolicy() string {
+	return o.GetScheduleConfig().OperatorPreemptionPolicy
+}
+
+// GetLeaderZoneLabel returns the store label key of the zone used by the `leader_zone` label policy.
+func (o *PersistOptions) GetLeaderZoneLabel() string {
+	return o.GetScheduleConfig().LeaderZoneLabel
+}
+
+// GetSchedulerStoreLimitQuota returns the max ratio of the store limit that the scheduler can use.
+// It returns 0 if the scheduler has no quota.
+func (o *PersistOptions) GetSchedulerStoreLimitQuota(name string) float64 {
+	return o.GetScheduleConfig().SchedulerStoreLimitQuota[name]
+}
+
+// IsBucketSplitEnabled returns if the regions can be split at the bucket boundaries by load.
+func (o *PersistOptions) IsBucketSplitEnabled() bool {
+	return o.GetScheduleConfig().EnableBucketSplit
+}
+
+// GetBucketSplitMinLoad returns the min byte rate of a region to be split by buckets.
+func (o *PersistOptions) GetBucketSplitMinLoad() float64 {
+	return o.GetScheduleConfig().BucketSplitMinLoad
+}
+
+// GetBucketSplitHotRatio returns the min ratio of the region load in the hottest buckets to split the region.
+func (o *PersistOptions) GetBucketSplitHotRatio() float64 {
+	return o.GetScheduleConfig().BucketSplitHotRatio
+}
+
+// GetBucketSplitMaxHotBuckets returns the max number of the hottest buckets which the region load is concentrated in.
+func (o *PersistOptions) GetBucketSplitMaxHotBuckets() int {
+	return o.GetScheduleConfig().BucketSplitMaxHotBuckets
+}
+
+// GetCrossLocationRepairSizePerMinute returns the max size of the regions which can be repaired across locations per minute.
+func (o *PersistOptions) GetCrossLocationRepairSizePerMinute() uint64 {
+	return o.GetScheduleConfig().CrossLocationRepairSizePerMinute
+}
+
+// IsRangeMergeEnabled returns if the long runs of small regions can be merged in a tree-shaped sequence.
+func (o *PersistOptions) IsRangeMergeEnabled() bool {
+	return o.GetScheduleConfig().EnableRangeMerge
+}
+
+// GetRangeMergeMinRegions returns the min number of the adjacent small regions to be merged as a run.
+func (o *PersistOptions) GetRangeMergeMinRegions() int {
+	return o.GetScheduleConfig().RangeMergeMinRegions
+}
+
+// GetRangeMergeScheduleLimit returns the max number of the region pairs merged by the range merge at the same time.
+func (o *PersistOptions) GetRangeMergeScheduleLimit() uint64 {
+	return o.GetScheduleConfig().RangeMergeScheduleLimit
+}
+
+// GetTiFlashReplicaSizeRatio returns the ratio of the size of a TiFlash columnar replica to the approximate region size.
+func (o *PersistOptions) GetTiFlashReplicaSizeRatio() float64 {
+	return o.GetScheduleConfig().TiFlashReplicaSizeRatio
+}
+
+// GetTiFlashRegionScheduleLimit returns the limit for the balance region operators on the TiFlash stores.
+func (o *PersistOptions) GetTiFlashRegionScheduleLimit() uint64 {
+	return o.GetScheduleConfig().TiFlashRegionScheduleLimit
+}
+
 // IsWitnessAllowed returns whether is enable to use witness.
 func (o *PersistOptions) IsWitnessAllowed() bool {
 	return o.GetScheduleConfig().EnableWitness
@@ -700,6 +771,16 @@ func (o *PersistOptions) GetHotRegionsReservedDays() uint64 {
 	return o.GetScheduleConfig().HotRegionsReservedDays
 }
 
+// GetOperatorHistoryWriteInterval gets interval for PD to store the finished operators.
+func (o *PersistOptions) GetOperatorHistoryWriteInterval() time.Duration {
+	return o.GetScheduleConfig().OperatorHistoryWriteInterval.Duration
+}
+
+// GetOperatorHistoryReservedDays gets days the finished operators are kept.
+func (o *PersistOptions) GetOperatorHistoryReservedDays() uint64 {
+	return o.GetScheduleConfig().OperatorHistoryReservedDays
+}
+
 // AddSchedulerCfg adds the scheduler configurations.
 func (o *PersistOptions) AddSchedulerCfg(tp types.CheckerSchedulerType, args []string) {
 	oldType := types.SchedulerTypeCompatibleMap[tp]
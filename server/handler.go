@@ -16,10 +16,12 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -124,6 +126,16 @@ func (h *Handler) GetHotRegionsReservedDays() uint64 {
 	return h.opt.GetHotRegionsReservedDays()
 }
 
+// GetOperatorHistoryWriteInterval gets interval for PD to store the finished operators.
+func (h *Handler) GetOperatorHistoryWriteInterval() time.Duration {
+	return h.opt.GetOperatorHistoryWriteInterval()
+}
+
+// GetOperatorHistoryReservedDays gets days the finished operators are kept.
+func (h *Handler) GetOperatorHistoryReservedDays() uint64 {
+	return h.opt.GetOperatorHistoryReservedDays()
+}
+
 // HistoryHotRegionsRequest wrap request condition from tidb.
 // it is request from tidb
 type HistoryHotRegionsRequest struct {
@@ -137,14 +149,57 @@ type HistoryHotRegionsRequest struct {
 	HotRegionTypes []string `json:"hot_region_type,omitempty"`
 }
 
+// HistoryHotRegionsAggregateRequest wraps the condition and the aggregation of
+// the history hot regions.
+type HistoryHotRegionsAggregateRequest struct {
+	HistoryHotRegionsRequest
+	GroupBy string `json:"group_by"`
+	SortBy  string `json:"sort_by,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+	// Step is the interval of the store timeline in milliseconds.
+	Step int64 `json:"step,omitempty"`
+}
+
 // GetAllRequestHistoryHotRegion gets all hot region info in HistoryHotRegion form.
 func (h *Handler) GetAllRequestHistoryHotRegion(request *HistoryHotRegionsRequest) (*storage.HistoryHotRegions, error) {
+	var results []*storage.HistoryHotRegion
+	err := h.scanRequestHistoryHotRegion(request, func(region *storage.HistoryHotRegion) {
+		results = append(results, region)
+	})
+	return &storage.HistoryHotRegions{
+		HistoryHotRegion: results,
+	}, err
+}
+
+// AggregateHistoryHotRegions aggregates the history hot regions matching the request.
+func (h *Handler) AggregateHistoryHotRegions(request *HistoryHotRegionsAggregateRequest) (*storage.HistoryHotRegionsAggregation, error) {
+	step := time.Duration(request.Step) * time.Millisecond
+	if request.Step == 0 {
+		step = h.opt.GetHotRegionsWriteInterval()
+	}
+	aggregator, err := storage.NewHistoryHotRegionsAggregator(request.GroupBy, step)
+	if err != nil {
+		return nil, err
+	}
+	condition := request.HistoryHotRegionsRequest
+	if len(condition.IsLearners) == 0 {
+		condition.IsLearners = []bool{true, false}
+	}
+	if len(condition.IsLeaders) == 0 {
+		condition.IsLeaders = []bool{true, false}
+	}
+	if err := h.scanRequestHistoryHotRegion(&condition, aggregator.Add); err != nil {
+		return nil, err
+	}
+	return aggregator.Result(request.SortBy, request.Limit)
+}
+
+func (h *Handler) scanRequestHistoryHotRegion(request *HistoryHotRegionsRequest, fn func(*storage.HistoryHotRegion)) error {
 	var hotRegionTypes = storage.HotRegionTypes
 	if len(request.HotRegionTypes) != 0 {
 		hotRegionTypes = request.HotRegionTypes
 	}
 	iter := h.GetHistoryHotRegionIter(hotRegionTypes, request.StartTime, request.EndTime)
-	var results []*storage.HistoryHotRegion
 	regionSet, storeSet, peerSet, learnerSet, leaderSet :=
 		make(map[uint64]bool), make(map[uint64]bool),
 		make(map[uint64]bool), make(map[bool]bool), make(map[bool]bool)
@@ -181,11 +236,9 @@ func (h *Handler) GetAllRequestHistoryHotRegion(request *HistoryHotRegionsReques
 		if !leaderSet[next.IsLeader] {
 			continue
 		}
-		results = append(results, next)
+		fn(next)
 	}
-	return &storage.HistoryHotRegions{
-		HistoryHotRegion: results,
-	}, err
+	return err
 }
 
 // AddScheduler adds a scheduler.
@@ -453,6 +506,86 @@ func (h *Handler) GetHistoryHotRegionIter(
 	return iter
 }
 
+// GetHistoryOperators gets the operators finished since the given time in HistoryOperator form.
+// The operators are pulled from the scheduling server if it is independent, since
+// they finish there.
+func (h *Handler) GetHistoryOperators(from time.Time) ([]storage.HistoryOperator, error) {
+	rc, err := h.GetRaftCluster()
+	if err != nil {
+		return nil, err
+	}
+	if !rc.IsServiceIndependent(constant.SchedulingServiceName) {
+		return h.Handler.GetHistoryOperators(from)
+	}
+	addr, ok := h.s.GetServicePrimaryAddr(h.s.Context(), constant.SchedulingServiceName)
+	if !ok {
+		return nil, errs.ErrNotFoundSchedulingPrimary.FastGenByArgs()
+	}
+	url := fmt.Sprintf("%s/scheduling/api/v1/operators/history", addr)
+	if !from.IsZero() {
+		url = fmt.Sprintf("%s?from=%d", url, from.Unix())
+	}
+	resp, err := apiutil.GetJSON(h.s.GetHTTPClient(), url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errs.ErrSchedulingServer.FastGenByArgs(resp.StatusCode)
+	}
+	var ops []storage.HistoryOperator
+	if err := apiutil.ReadJSON(resp.Body, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// HistoryOperatorsRequest wraps the request conditions of the finished operators.
+type HistoryOperatorsRequest struct {
+	// StartTime and EndTime are in ms.
+	StartTime  int64
+	EndTime    int64
+	RegionIDs  []uint64
+	StoreIDs   []uint64
+	Schedulers []string
+}
+
+// GetAllRequestHistoryOperators gets the persisted operators which match the request.
+func (h *Handler) GetAllRequestHistoryOperators(request *HistoryOperatorsRequest) (*storage.HistoryOperators, error) {
+	if h.s.operatorHistoryStorage == nil {
+		return nil, errs.ErrServerNotStarted.FastGenByArgs()
+	}
+	iter := h.s.operatorHistoryStorage.NewIterator(request.StartTime, request.EndTime)
+	regionSet, storeSet, schedulerSet := make(map[uint64]bool), make(map[uint64]bool), make(map[string]bool)
+	for _, id := range request.RegionIDs {
+		regionSet[id] = true
+	}
+	for _, id := range request.StoreIDs {
+		storeSet[id] = true
+	}
+	for _, name := range request.Schedulers {
+		schedulerSet[name] = true
+	}
+	results := make([]*storage.HistoryOperator, 0)
+	var next *storage.HistoryOperator
+	var err error
+	for next, err = iter.Next(); next != nil && err == nil; next, err = iter.Next() {
+		if len(regionSet) != 0 && !regionSet[next.RegionID] {
+			continue
+		}
+		if len(storeSet) != 0 && !slices.ContainsFunc(next.StoreIDs, func(id uint64) bool { return storeSet[id] }) {
+			continue
+		}
+		if len(schedulerSet) != 0 && !schedulerSet[next.Scheduler] {
+			continue
+		}
+		results = append(results, next)
+	}
+	return &storage.HistoryOperators{
+		HistoryOperator: results,
+	}, err
+}
+
 // RedirectSchedulerUpdate update scheduler config. Export this func to help handle damaged store.
 func (h *Handler) RedirectSchedulerUpdate(name string, storeID float64) error {
 	input := make(map[string]any)
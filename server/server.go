@@ -194,6 +194,8 @@ type Server struct {
 
 	// hot region history info storage
 	hotRegionStorage *storage.HotRegionStorage
+	// operatorHistoryStorage stores the finished operators.
+	operatorHistoryStorage *storage.OperatorHistoryStorage
 	// Store as map[string]*grpc.ClientConn
 	clientConns sync.Map
 
@@ -490,6 +492,11 @@ func (s *Server) startServer(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	s.operatorHistoryStorage, err = storage.NewOperatorHistoryStorage(
+		ctx, filepath.Join(s.cfg.DataDir, "operator-history"), s.handler)
+	if err != nil {
+		return err
+	}
 
 	// Run callbacks
 	log.Info("triggering the start callback functions")
@@ -568,6 +575,12 @@ func (s *Server) Close() {
 		}
 	}
 
+	if s.operatorHistoryStorage != nil {
+		if err := s.operatorHistoryStorage.Close(); err != nil {
+			log.Error("close operator history storage meet error", errs.ZapError(err))
+		}
+	}
+
 	s.grpcServiceRateLimiter.Close()
 	s.serviceRateLimiter.Close()
 	// Run callbacks
@@ -857,6 +870,11 @@ func (s *Server) GetHistoryHotRegionStorage() *storage.HotRegionStorage {
 	return s.hotRegionStorage
 }
 
+// GetOperatorHistoryStorage returns the backend storage of the finished operators.
+func (s *Server) GetOperatorHistoryStorage() *storage.OperatorHistoryStorage {
+	return s.operatorHistoryStorage
+}
+
 // SetStorage changes the storage only for test purpose.
 // When we use it, we should prevent calling GetStorage, otherwise, it may cause a data race problem.
 func (s *Server) SetStorage(storage storage.Storage) {
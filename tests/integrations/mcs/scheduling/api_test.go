@@ -151,6 +151,14 @@ func (suite *apiTestSuite) checkAPIForward(cluster *tests.TestCluster) {
 	err = testutil.CheckGetJSON(tests.TestDialClient, fmt.Sprintf("%s/%s", urlPrefix, "operators/records"), nil,
 		testutil.StatusNotOK(re), testutil.WithHeader(re, apiutil.XForwardedToMicroserviceHeader, "true"))
 	re.NoError(err)
+	// The operator history is persisted by PD, it should not be forwarded.
+	err = testutil.CheckGetJSON(tests.TestDialClient, fmt.Sprintf("%s/%s", urlPrefix, "operators/history"), nil,
+		testutil.StatusOK(re), testutil.WithoutHeader(re, apiutil.XForwardedToMicroserviceHeader))
+	re.NoError(err)
+	// PD pulls the finished operators from the scheduling server to persist them.
+	ops, err := leader.GetHandler().GetHistoryOperators(time.Time{})
+	re.NoError(err)
+	re.Empty(ops)
 
 	// Test checker
 	err = testutil.ReadGetJSON(re, tests.TestDialClient, fmt.Sprintf("%s/%s", urlPrefix, "checker/merge"), &resp,
@@ -257,6 +265,10 @@ func (suite *apiTestSuite) checkAPIForward(cluster *tests.TestCluster) {
 	err = testutil.ReadGetJSON(re, tests.TestDialClient, fmt.Sprintf("%s/%s", urlPrefix, "hotspot/regions/history"), &history,
 		testutil.WithHeader(re, apiutil.XForwardedToMicroserviceHeader, "true"))
 	re.NoError(err)
+	var aggregation storage.HistoryHotRegionsAggregation
+	err = testutil.ReadGetJSONWithBody(re, tests.TestDialClient, fmt.Sprintf("%s/%s", urlPrefix, "hotspot/regions/history/aggregate"),
+		[]byte(`{"group_by":"store"}`), &aggregation, testutil.WithoutHeader(re, apiutil.XForwardedToMicroserviceHeader))
+	re.NoError(err)
 
 	// Test region label
 	var labelRules []*labeler.LabelRule
@@ -206,6 +206,67 @@ func (suite *configTestSuite) TestSchedulerConfigWatch() {
 	watcher.Close()
 }
 
+func (suite *configTestSuite) TestTimeWindowsWatch() {
+	re := suite.Require()
+	pdTimeWindows := suite.pdLeaderServer.GetRaftCluster().GetCoordinator().GetSchedulersController().GetTimeWindows()
+	// Make sure the time windows are persisted before the watcher is created.
+	re.NoError(pdTimeWindows.Set(types.BalanceRegionScheduler.String(), []*sc.TimeWindow{{Start: "22:00", End: "06:00"}}))
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	watcher, err := config.NewWatcher(
+		suite.ctx,
+		suite.pdLeaderServer.GetEtcdClient(),
+		config.NewPersistConfig(config.NewConfig(), cache.NewStringTTL(suite.ctx, sc.DefaultGCInterval, sc.DefaultTTL)),
+		storage,
+	)
+	re.NoError(err)
+	// The time windows are loaded by the initial load of the watcher.
+	timeWindows := sc.NewTimeWindows(storage)
+	re.NoError(timeWindows.Reload())
+	re.Len(timeWindows.Get(types.BalanceRegionScheduler.String()), 1)
+	// The time windows are not treated as a scheduler config.
+	names, _, err := storage.LoadAllSchedulerConfigs()
+	re.NoError(err)
+	for _, name := range names {
+		re.NotEmpty(schedulers.FindSchedulerTypeByName(name))
+	}
+	// Remove the time windows.
+	re.NoError(pdTimeWindows.Set(types.BalanceRegionScheduler.String(), nil))
+	testutil.Eventually(re, func() bool {
+		re.NoError(timeWindows.Reload())
+		return len(timeWindows.GetAll()) == 0
+	})
+	watcher.Close()
+}
+
+func (suite *configTestSuite) TestShadowSchedulersWatch() {
+	re := suite.Require()
+	pdSchedulers := suite.pdLeaderServer.GetRaftCluster().GetCoordinator().GetSchedulersController()
+	name := types.BalanceRegionScheduler.String()
+	testutil.Eventually(re, func() bool {
+		return pdSchedulers.GetScheduler(name) != nil
+	})
+	re.NoError(pdSchedulers.SetSchedulerShadow(name, true))
+	storage := endpoint.NewStorageEndpoint(kv.NewMemoryKV(), nil)
+	watcher, err := config.NewWatcher(
+		suite.ctx,
+		suite.pdLeaderServer.GetEtcdClient(),
+		config.NewPersistConfig(config.NewConfig(), cache.NewStringTTL(suite.ctx, sc.DefaultGCInterval, sc.DefaultTTL)),
+		storage,
+	)
+	re.NoError(err)
+	// The shadow schedulers are loaded by the initial load of the watcher.
+	data, err := storage.LoadShadowSchedulers()
+	re.NoError(err)
+	re.JSONEq(`["`+name+`"]`, data)
+	re.NoError(pdSchedulers.SetSchedulerShadow(name, false))
+	testutil.Eventually(re, func() bool {
+		data, err = storage.LoadShadowSchedulers()
+		re.NoError(err)
+		return data == "[]"
+	})
+	watcher.Close()
+}
+
 func assertEvictLeaderStoreIDs(
 	re *require.Assertions, storage *endpoint.StorageEndpoint, storeIDs []uint64,
 ) {
@@ -53,6 +53,7 @@ func (suite *hotStatusTestSuite) TestHotStatus() {
 	suite.env.RunTestInNonMicroserviceEnv(suite.checkGetHistoryHotRegionsBasic)
 	suite.env.RunTestInNonMicroserviceEnv(suite.checkGetHistoryHotRegionsTimeRange)
 	suite.env.RunTestInNonMicroserviceEnv(suite.checkGetHistoryHotRegionsIDAndTypes)
+	suite.env.RunTestInNonMicroserviceEnv(suite.checkGetHistoryHotRegionsAggregation)
 }
 
 func (suite *hotStatusTestSuite) checkGetHotStore(cluster *tests.TestCluster) {
@@ -210,6 +211,70 @@ func (suite *hotStatusTestSuite) checkGetHistoryHotRegionsIDAndTypes(cluster *te
 	re.NoError(err)
 }
 
+func (suite *hotStatusTestSuite) checkGetHistoryHotRegionsAggregation(cluster *tests.TestCluster) {
+	re := suite.Require()
+
+	leader := cluster.GetLeaderServer()
+	urlPrefix := leader.GetAddr() + "/pd/api/v1"
+	hotRegionStorage := leader.GetServer().GetHistoryHotRegionStorage()
+	// Use a time range which is not used by other checks.
+	start := time.Now().AddDate(1, 0, 0)
+	hotRegions := []*storage.HistoryHotRegion{
+		{RegionID: 1, StoreID: 1, IsLeader: true, HotRegionType: "read", FlowBytes: 100,
+			UpdateTime: start.UnixNano() / int64(time.Millisecond)},
+		{RegionID: 1, StoreID: 1, IsLeader: true, HotRegionType: "read", FlowBytes: 100,
+			UpdateTime: start.Add(time.Minute).UnixNano() / int64(time.Millisecond)},
+		{RegionID: 2, StoreID: 2, IsLeader: true, HotRegionType: "read", FlowBytes: 150,
+			UpdateTime: start.UnixNano() / int64(time.Millisecond)},
+		{RegionID: 3, StoreID: 2, IsLeader: true, HotRegionType: "write", FlowBytes: 50,
+			UpdateTime: start.UnixNano() / int64(time.Millisecond)},
+	}
+	err := writeToDB(hotRegionStorage.LevelDBKV, hotRegions)
+	re.NoError(err)
+	request := server.HistoryHotRegionsAggregateRequest{
+		HistoryHotRegionsRequest: server.HistoryHotRegionsRequest{
+			StartTime: start.UnixNano() / int64(time.Millisecond),
+			EndTime:   start.Add(time.Hour).UnixNano() / int64(time.Millisecond),
+		},
+		GroupBy: storage.HotRegionsGroupByRegion,
+		Limit:   2,
+	}
+	check := func(expected ...string) func([]byte, int, http.Header) {
+		return func(res []byte, statusCode int, _ http.Header) {
+			re.Equal(http.StatusOK, statusCode)
+			agg := &storage.HistoryHotRegionsAggregation{}
+			re.NoError(json.Unmarshal(res, agg))
+			re.Equal(request.GroupBy, agg.GroupBy)
+			keys := make([]string, 0, len(agg.Groups))
+			for _, group := range agg.Groups {
+				keys = append(keys, group.HotRegionType+"/"+group.Key)
+			}
+			re.Equal(expected, keys)
+		}
+	}
+	data, err := json.Marshal(request)
+	re.NoError(err)
+	err = testutil.CheckGetJSON(tests.TestDialClient, urlPrefix+"/hotspot/regions/history/aggregate", data, check("read/1", "read/2"))
+	re.NoError(err)
+
+	request.GroupBy = storage.HotRegionsGroupByStore
+	request.Limit = 0
+	request.Step = time.Hour.Milliseconds()
+	data, err = json.Marshal(request)
+	re.NoError(err)
+	err = testutil.CheckGetJSON(tests.TestDialClient, urlPrefix+"/hotspot/regions/history/aggregate", data, check("read/1", "read/2", "write/2"))
+	re.NoError(err)
+
+	for _, errRequest := range []string{
+		`{"group_by":"zone"}`,
+		`{"group_by":"region","sort_by":"cpu"}`,
+		`{"group_by":"store","step":-1}`,
+	} {
+		err = testutil.CheckGetJSON(tests.TestDialClient, urlPrefix+"/hotspot/regions/history/aggregate", []byte(errRequest), testutil.Status(re, http.StatusBadRequest))
+		re.NoError(err)
+	}
+}
+
 func writeToDB(kv *kv.LevelDBKV, hotRegions []*storage.HistoryHotRegion) error {
 	batch := new(leveldb.Batch)
 	for _, region := range hotRegions {
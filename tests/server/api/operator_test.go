@@ -555,3 +555,48 @@ func (suite *operatorTestSuite) checkRemoveOperators(cluster *tests.TestCluster)
 	err = testutil.CheckGetJSON(tests.TestDialClient, url, nil, testutil.StatusOK(re), testutil.StringNotContain(re, "merge: region 10 to 20"), testutil.StringNotContain(re, "rm peer: store [3]"))
 	re.NoError(err)
 }
+
+func (suite *operatorTestSuite) TestOperatorBatch() {
+	suite.env.RunTest(suite.checkOperatorBatch)
+}
+
+func (suite *operatorTestSuite) checkOperatorBatch(cluster *tests.TestCluster) {
+	re := suite.Require()
+
+	r1 := core.NewTestRegionInfo(10, 1, []byte(""), []byte("b"),
+		core.SetPeers([]*metapb.Peer{{Id: 11, StoreId: 1}, {Id: 12, StoreId: 2}, {Id: 13, StoreId: 3}}),
+		core.SetRegionConfVer(1), core.SetRegionVersion(1))
+	tests.MustPutRegionInfo(re, cluster, r1)
+	r2 := core.NewTestRegionInfo(20, 1, []byte("b"), []byte(""),
+		core.SetPeers([]*metapb.Peer{{Id: 21, StoreId: 1}, {Id: 22, StoreId: 2}, {Id: 23, StoreId: 3}}),
+		core.SetRegionConfVer(1), core.SetRegionVersion(1))
+	tests.MustPutRegionInfo(re, cluster, r2)
+
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/operators/batches", cluster.GetLeaderServer().GetAddr())
+	err := testutil.CheckPostJSON(tests.TestDialClient, urlPrefix, []byte(`{"desc":"test", "operators":[{"name":"remove-peer", "region_id": 10, "store_id": 3, "depends": [1]}]}`), testutil.StatusNotOK(re))
+	re.NoError(err)
+	var id uint64
+	err = testutil.CheckPostJSON(tests.TestDialClient, urlPrefix, []byte(`{"desc":"test", "operators":[
+		{"name":"remove-peer", "region_id": 10, "store_id": 3},
+		{"name":"remove-peer", "region_id": 20, "store_id": 3, "depends": [0]}]}`), testutil.StatusOK(re), testutil.ExtractJSON(re, &id))
+	re.NoError(err)
+
+	// The second operator waits for the first one.
+	url := fmt.Sprintf("%s/%d", urlPrefix, id)
+	var batch operator.BatchObject
+	err = testutil.ReadGetJSON(re, tests.TestDialClient, url, &batch)
+	re.NoError(err)
+	re.Equal("Started", batch.Status)
+	re.Len(batch.Steps, 2)
+	re.Equal("Started", batch.Steps[0].Status)
+	re.Equal("Created", batch.Steps[1].Status)
+	err = testutil.CheckGetJSON(tests.TestDialClient, fmt.Sprintf("%s/pd/api/v1/operators/20", cluster.GetLeaderServer().GetAddr()), nil, testutil.StatusNotOK(re))
+	re.NoError(err)
+
+	err = testutil.CheckDelete(tests.TestDialClient, url, testutil.StatusOK(re))
+	re.NoError(err)
+	err = testutil.ReadGetJSON(re, tests.TestDialClient, url, &batch)
+	re.NoError(err)
+	re.Equal("Canceled", batch.Status)
+	re.Equal("Canceled", batch.Steps[1].Status)
+}
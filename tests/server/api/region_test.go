@@ -36,7 +36,10 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/response"
+	"github.com/tikv/pd/pkg/schedule/checker"
+	"github.com/tikv/pd/pkg/schedule/handler"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/schedule/types"
 	"github.com/tikv/pd/pkg/utils/apiutil"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/server/api"
@@ -360,6 +363,63 @@ func (suite *regionTestSuite) checkRegion(cluster *tests.TestCluster) {
 	re.Equal(response.NewAPIRegionInfo(r), r2)
 }
 
+func (suite *regionTestSuite) TestExplainRegion() {
+	suite.env.RunTest(suite.checkExplainRegion)
+}
+
+func (suite *regionTestSuite) checkExplainRegion(cluster *tests.TestCluster) {
+	re := suite.Require()
+	pauseAllCheckers(re, cluster)
+	leader := cluster.GetLeaderServer()
+	urlPrefix := leader.GetAddr() + "/pd/api/v1"
+	tests.MustPutStore(re, cluster, &metapb.Store{
+		Id:        1,
+		State:     metapb.StoreState_Up,
+		NodeState: metapb.NodeState_Serving,
+	})
+	r := core.NewTestRegionInfo(2, 1, []byte("a"), []byte("b"))
+	tests.MustPutRegionInfo(re, cluster, r)
+	checkRegionCount(re, cluster, 1)
+
+	url := fmt.Sprintf("%s/regions/%s/explain", urlPrefix, "x")
+	re.NoError(testutil.CheckGetJSON(tests.TestDialClient, url, nil, testutil.Status(re, http.StatusBadRequest)))
+	url = fmt.Sprintf("%s/regions/%d/explain", urlPrefix, 2333)
+	re.NoError(testutil.CheckGetJSON(tests.TestDialClient, url, nil, testutil.Status(re, http.StatusNotFound)))
+
+	url = fmt.Sprintf("%s/regions/%d/explain", urlPrefix, r.GetID())
+	explanation := &handler.RegionExplanation{}
+	re.NoError(testutil.ReadGetJSON(re, tests.TestDialClient, url, explanation))
+	re.Equal(r.GetID(), explanation.RegionID)
+	re.Len(explanation.PlacementRules, 1)
+	re.Equal(placement.DefaultGroupID, explanation.PlacementRules[0].GroupID)
+	checkers := make(map[string]string)
+	for _, e := range explanation.Checkers {
+		checkers[e.Name] = e.Status
+	}
+	re.Equal(checker.CheckPaused, checkers[types.RuleChecker.String()])
+	re.Equal(checker.CheckInactive, checkers[types.ReplicaChecker.String()])
+	re.Equal(checker.CheckPaused, checkers[types.SplitChecker.String()])
+	// The bucket split is disabled by default.
+	re.Equal(checker.CheckInactive, checkers[types.BucketSplitChecker.String()])
+
+	url = fmt.Sprintf("%s/regions/split-advices", urlPrefix)
+	var advices []*checker.SplitAdvice
+	re.NoError(testutil.ReadGetJSON(re, tests.TestDialClient, url, &advices))
+	re.Empty(advices)
+
+	url = fmt.Sprintf("%s/regions/repair-progress", urlPrefix)
+	progress := &checker.RepairProgress{}
+	re.NoError(testutil.ReadGetJSON(re, tests.TestDialClient, url, progress))
+	re.Zero(progress.CrossLocationSizeLimit)
+	re.Empty(progress.WaitingRegions)
+
+	url = fmt.Sprintf("%s/regions/merge-backlog", urlPrefix)
+	backlog := &checker.MergeBacklog{}
+	re.NoError(testutil.ReadGetJSON(re, tests.TestDialClient, url, backlog))
+	re.Zero(backlog.PendingMerges)
+	re.Empty(backlog.Runs)
+}
+
 func (suite *regionTestSuite) TestRegionCheck() {
 	suite.env.RunTest(suite.checkRegionCheck)
 }
@@ -32,6 +32,7 @@ import (
 
 	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/errs"
+	"github.com/tikv/pd/pkg/schedule/checker"
 	"github.com/tikv/pd/pkg/schedule/labeler"
 	"github.com/tikv/pd/pkg/schedule/placement"
 	"github.com/tikv/pd/pkg/utils/etcdutil"
@@ -553,6 +554,34 @@ func (suite *ruleTestSuite) checkGetAllByKey(cluster *tests.TestCluster) {
 	}
 }
 
+func (suite *ruleTestSuite) TestCompliance() {
+	suite.env.RunTest(suite.checkCompliance)
+}
+
+func (suite *ruleTestSuite) checkCompliance(cluster *tests.TestCluster) {
+	re := suite.Require()
+	leaderServer := cluster.GetLeaderServer()
+	pdAddr := leaderServer.GetAddr()
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/config", pdAddr)
+
+	rule := placement.Rule{GroupID: "h", ID: "50", StartKeyHex: "8888", EndKeyHex: "9111", Role: placement.Voter, Count: 1}
+	data, err := json.Marshal(rule)
+	re.NoError(err)
+	err = testutil.CheckPostJSON(tests.TestDialClient, urlPrefix+"/rule", data, testutil.StatusOK(re))
+	re.NoError(err)
+
+	report := &checker.RuleComplianceReport{}
+	testutil.Eventually(re, func() bool {
+		err = testutil.ReadGetJSON(re, tests.TestDialClient, urlPrefix+"/rules/compliance", report)
+		re.NoError(err)
+		return len(report.Rules) == 2
+	})
+	for _, r := range report.Rules {
+		re.Contains([]string{placement.DefaultRuleID, rule.ID}, r.ID)
+		re.Zero(r.Regions)
+	}
+}
+
 func (suite *ruleTestSuite) TestDelete() {
 	suite.env.RunTest(suite.checkDelete)
 }
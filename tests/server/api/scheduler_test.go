@@ -249,11 +249,13 @@ func (suite *scheduleTestSuite) checkAPI(cluster *tests.TestCluster) {
 					"min-hot-byte-rate":          100.0,
 					"min-hot-key-rate":           10.0,
 					"min-hot-query-rate":         10.0,
+					"min-hot-cpu-rate":           1.0,
 					"max-zombie-rounds":          3.0,
 					"max-peer-number":            1000.0,
 					"byte-rate-rank-step-ratio":  0.05,
 					"key-rate-rank-step-ratio":   0.05,
 					"query-rate-rank-step-ratio": 0.05,
+					"cpu-rate-rank-step-ratio":   0.05,
 					"count-rank-step-ratio":      0.01,
 					"great-dec-ratio":            0.95,
 					"minor-dec-ratio":            0.99,
@@ -268,6 +270,10 @@ func (suite *scheduleTestSuite) checkAPI(cluster *tests.TestCluster) {
 					"strict-picking-store":       "true",
 					"history-sample-duration":    "5m0s",
 					"history-sample-interval":    "30s",
+					"forecast-model":             "none",
+					"forecast-period":            "24h0m0s",
+					"forecast-seasons":           7.0,
+					"forecast-horizon":           "10m0s",
 				}
 				testutil.Eventually(re, func() bool {
 					re.NoError(testutil.ReadGetJSON(re, tests.TestDialClient, listURL, &resp))
@@ -662,6 +668,77 @@ func (suite *scheduleTestSuite) checkDisable(cluster *tests.TestCluster) {
 	assertNoScheduler(re, urlPrefix, name)
 }
 
+func (suite *scheduleTestSuite) TestTimeWindows() {
+	suite.te.RunTest(suite.checkTimeWindows)
+}
+
+func (suite *scheduleTestSuite) checkTimeWindows(cluster *tests.TestCluster) {
+	re := suite.Require()
+	leaderAddr := cluster.GetLeaderServer().GetAddr()
+	urlPrefix := fmt.Sprintf("%s/pd/api/v1/schedulers", leaderAddr)
+	for i := 1; i <= 4; i++ {
+		store := &metapb.Store{
+			Id:        uint64(i),
+			State:     metapb.StoreState_Up,
+			NodeState: metapb.NodeState_Serving,
+		}
+		tests.MustPutStore(re, cluster, store)
+	}
+
+	name := "shuffle-leader-scheduler"
+	body, err := json.Marshal(map[string]any{"name": name})
+	re.NoError(err)
+	addScheduler(re, urlPrefix, body)
+	suite.assertSchedulerExists(urlPrefix, name)
+
+	// Set a time window which does not contain the current time.
+	now := time.Now().UTC()
+	windows := []*sc.TimeWindow{{
+		Weekdays: "*",
+		Start:    now.Add(2 * time.Hour).Format("15:04"),
+		End:      now.Add(3 * time.Hour).Format("15:04"),
+	}}
+	body, err = json.Marshal(windows)
+	re.NoError(err)
+	windowURL := fmt.Sprintf("%s/time-windows", urlPrefix)
+	err = testutil.CheckPostJSON(tests.TestDialClient, windowURL+"/"+name, body, testutil.StatusOK(re))
+	re.NoError(err)
+	suite.assertSchedulerExists(fmt.Sprintf("%s?status=out-of-window", urlPrefix), name)
+	var allWindows map[string][]*sc.TimeWindow
+	err = testutil.ReadGetJSON(re, tests.TestDialClient, windowURL, &allWindows)
+	re.NoError(err)
+	re.Len(allWindows[name], 1)
+	re.Equal(windows[0].Start, allWindows[name][0].Start)
+
+	// The checkers could also have time windows.
+	body, err = json.Marshal([]*sc.TimeWindow{{Start: "22:00", End: "06:00", TimeZone: "Asia/Shanghai"}})
+	re.NoError(err)
+	err = testutil.CheckPostJSON(tests.TestDialClient, windowURL+"/merge-checker", body, testutil.StatusOK(re))
+	re.NoError(err)
+	// Invalid time windows and unknown names are rejected.
+	body, err = json.Marshal([]*sc.TimeWindow{{Start: "22:00", End: "30:00"}})
+	re.NoError(err)
+	err = testutil.CheckPostJSON(tests.TestDialClient, windowURL+"/merge-checker", body, testutil.StatusNotOK(re))
+	re.NoError(err)
+	err = testutil.CheckPostJSON(tests.TestDialClient, windowURL+"/unknown-checker", []byte("[]"), testutil.StatusNotOK(re))
+	re.NoError(err)
+
+	err = testutil.CheckDelete(tests.TestDialClient, windowURL+"/"+name, testutil.StatusOK(re))
+	re.NoError(err)
+	err = testutil.CheckDelete(tests.TestDialClient, windowURL+"/merge-checker", testutil.StatusOK(re))
+	re.NoError(err)
+	assertNoScheduler(re, fmt.Sprintf("%s?status=out-of-window", urlPrefix), name)
+	testutil.Eventually(re, func() bool {
+		allWindows = nil
+		err = testutil.ReadGetJSON(re, tests.TestDialClient, windowURL, &allWindows)
+		re.NoError(err)
+		return len(allWindows) == 0
+	})
+
+	deleteScheduler(re, urlPrefix, name)
+	assertNoScheduler(re, urlPrefix, name)
+}
+
 func addScheduler(re *require.Assertions, urlPrefix string, body []byte) {
 	err := testutil.CheckPostJSON(tests.TestDialClient, urlPrefix, body, testutil.StatusOK(re))
 	re.NoError(err)
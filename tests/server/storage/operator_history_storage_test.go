@@ -0,0 +1,114 @@
+// Copyright 2025 TiKV Project Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+
+	"github.com/tikv/pd/pkg/core"
+	"github.com/tikv/pd/pkg/storage"
+	"github.com/tikv/pd/pkg/utils/testutil"
+	"github.com/tikv/pd/server"
+	"github.com/tikv/pd/server/config"
+	"github.com/tikv/pd/tests"
+)
+
+type operatorHistoryStorageTestSuite struct {
+	suite.Suite
+	env *tests.SchedulingTestEnvironment
+}
+
+func TestOperatorHistoryStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(operatorHistoryStorageTestSuite))
+}
+
+func (s *operatorHistoryStorageTestSuite) SetupSuite() {
+	s.env = tests.NewSchedulingTestEnvironment(s.T(),
+		func(cfg *config.Config, _ string) {
+			cfg.Schedule.OperatorHistoryWriteInterval.Duration = 100 * time.Millisecond
+			cfg.Schedule.OperatorHistoryReservedDays = 1
+		},
+	)
+}
+
+func (s *operatorHistoryStorageTestSuite) TearDownSuite() {
+	s.env.Cleanup()
+}
+
+func (s *operatorHistoryStorageTestSuite) TestOperatorHistoryStorage() {
+	s.env.RunTestInNonMicroserviceEnv(s.checkOperatorHistoryStorage)
+}
+
+func (s *operatorHistoryStorageTestSuite) checkOperatorHistoryStorage(cluster *tests.TestCluster) {
+	re := s.Require()
+	for _, id := range []uint64{1, 2, 3} {
+		tests.MustPutStore(re, cluster, &metapb.Store{Id: id, State: metapb.StoreState_Up, NodeState: metapb.NodeState_Serving})
+	}
+	for _, id := range []uint64{10, 20} {
+		region := core.NewTestRegionInfo(id, 1, []byte(fmt.Sprintf("%d", id)), []byte(fmt.Sprintf("%d", id+1)),
+			core.SetPeers([]*metapb.Peer{{Id: id + 1, StoreId: 1}, {Id: id + 2, StoreId: 2}, {Id: id + 3, StoreId: 3}}),
+			core.SetRegionConfVer(1), core.SetRegionVersion(1))
+		tests.MustPutRegionInfo(re, cluster, region)
+	}
+	leaderServer := cluster.GetLeaderServer()
+	handler := leaderServer.GetServer().GetHandler()
+	start := time.Now().UnixMilli()
+	re.NoError(handler.AddRemovePeerOperator(10, 3))
+	re.NoError(handler.AddTransferLeaderOperator(20, 2))
+	re.NoError(handler.RemoveOperators())
+
+	var ops *storage.HistoryOperators
+	testutil.Eventually(re, func() bool { // wait for the finished operators to be written to the storage
+		var err error
+		ops, err = handler.GetAllRequestHistoryOperators(&server.HistoryOperatorsRequest{
+			StartTime: start,
+			EndTime:   time.Now().UnixMilli(),
+		})
+		return err == nil && len(ops.HistoryOperator) == 2
+	})
+	for _, op := range ops.HistoryOperator {
+		re.Equal("admin-"+map[uint64]string{10: "remove-peer", 20: "transfer-leader"}[op.RegionID], op.Scheduler)
+		re.Equal("Canceled", op.Status)
+		re.Equal("admin stop", op.CancelReason)
+		re.NotEmpty(op.Steps)
+	}
+
+	// Filter by the store.
+	ops, err := handler.GetAllRequestHistoryOperators(&server.HistoryOperatorsRequest{
+		StartTime: start,
+		EndTime:   time.Now().UnixMilli(),
+		StoreIDs:  []uint64{3},
+	})
+	re.NoError(err)
+	re.Len(ops.HistoryOperator, 1)
+	re.Equal(uint64(10), ops.HistoryOperator[0].RegionID)
+	re.Equal([]uint64{3}, ops.HistoryOperator[0].StoreIDs)
+	// Filter by the scheduler.
+	ops, err = handler.GetAllRequestHistoryOperators(&server.HistoryOperatorsRequest{
+		StartTime:  start,
+		EndTime:    time.Now().UnixMilli(),
+		Schedulers: []string{"admin-transfer-leader"},
+	})
+	re.NoError(err)
+	re.Len(ops.HistoryOperator, 1)
+	re.Equal(uint64(20), ops.HistoryOperator[0].RegionID)
+	re.Equal([]uint64{1, 2}, ops.HistoryOperator[0].StoreIDs)
+}
@@ -42,6 +42,10 @@ const (
 	clusterVersionPrefix          = "pd/api/v1/config/cluster-version"
 	rulesPrefix                   = "pd/api/v1/config/rules"
 	rulesBatchPrefix              = "pd/api/v1/config/rules/batch"
+	rulesSimulatePrefix           = "pd/api/v1/config/rules/simulate"
+	rulesHistoryPrefix            = "pd/api/v1/config/rules/history"
+	rulesRollbackPrefix           = "pd/api/v1/config/rules/rollback"
+	rulesCompliancePrefix         = "pd/api/v1/config/rules/compliance"
 	rulePrefix                    = "pd/api/v1/config/rule"
 	ruleGroupPrefix               = "pd/api/v1/config/rule_group"
 	ruleGroupsPrefix              = "pd/api/v1/config/rule_groups"
@@ -626,8 +630,30 @@ func NewPlacementRulesCommand() *cobra.Command {
 	}
 	ruleBundleSave.Flags().String("in", "rules.json", "the file contains all group configs and all rules")
 	ruleBundleSave.Flags().Bool("partial", false, "do not drop all old configurations, partial update")
-	ruleBundle.AddCommand(ruleBundleGet, ruleBundleSet, ruleBundleDelete, ruleBundleLoad, ruleBundleSave)
-	c.AddCommand(enable, disable, show, load, save, ruleGroup, ruleBundle)
+	ruleBundleSimulate := &cobra.Command{
+		Use:   "simulate",
+		Short: "simulate saving all group configs and rules from file against the current cluster",
+		Run:   simulateRuleBundle,
+	}
+	ruleBundleSimulate.Flags().String("in", "rules.json", "the file contains all group configs and all rules")
+	ruleBundleSimulate.Flags().Bool("partial", false, "do not drop all old configurations, partial update")
+	ruleBundle.AddCommand(ruleBundleGet, ruleBundleSet, ruleBundleDelete, ruleBundleLoad, ruleBundleSave, ruleBundleSimulate)
+	history := &cobra.Command{
+		Use:   "history",
+		Short: "show the history of the placement rule changes",
+		Run:   showRulesHistoryFunc,
+	}
+	rollback := &cobra.Command{
+		Use:   "rollback <version>",
+		Short: "roll back placement rules to the given version of the history",
+		Run:   rollbackRulesFunc,
+	}
+	compliance := &cobra.Command{
+		Use:   "compliance",
+		Short: "show the compliance of the regions of each placement rule",
+		Run:   showRulesComplianceFunc,
+	}
+	c.AddCommand(enable, disable, show, load, save, ruleGroup, ruleBundle, history, rollback, compliance)
 	return c
 }
 
@@ -915,6 +941,74 @@ func saveRuleBundle(cmd *cobra.Command, _ []string) {
 	cmd.Println(res)
 }
 
+func simulateRuleBundle(cmd *cobra.Command, _ []string) {
+	var file string
+	if f := cmd.Flag("in"); f != nil {
+		file = f.Value.String()
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+
+	path := rulesSimulatePrefix
+	if ok, _ := cmd.Flags().GetBool("partial"); ok {
+		path += "?partial=true"
+	}
+
+	res, err := doRequest(cmd, path, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewReader(content)))
+	if err != nil {
+		cmd.Printf("failed to simulate rule bundles %s: %s\n", content, err)
+		return
+	}
+
+	cmd.Println(res)
+}
+
+func showRulesHistoryFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, rulesHistoryPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func rollbackRulesFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	if _, err := strconv.ParseUint(args[0], 10, 64); err != nil {
+		cmd.Println("version should be a number")
+		return
+	}
+	res, err := doRequest(cmd, path.Join(rulesRollbackPrefix, args[0]), http.MethodPost, http.Header{})
+	if err != nil {
+		cmd.Printf("failed to rollback rules: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
+func showRulesComplianceFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	res, err := doRequest(cmd, rulesCompliancePrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Printf("Failed to get the compliance of placement rules: %s\n", err)
+		return
+	}
+	cmd.Println(res)
+}
+
 func buildHeader(cmd *cobra.Command) http.Header {
 	header := http.Header{}
 	forbiddenRedirectToMicroservice, err := cmd.Flags().GetBool(flagFromPD)
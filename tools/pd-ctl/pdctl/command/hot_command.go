@@ -35,6 +35,8 @@ const (
 	hotStoresPrefix         = "pd/api/v1/hotspot/stores"
 	hotRegionsHistoryPrefix = "pd/api/v1/hotspot/regions/history"
 	hotBucketsPrefix        = "pd/api/v1/hotspot/buckets"
+
+	hotRegionsHistoryAggregatePrefix = "pd/api/v1/hotspot/regions/history/aggregate"
 )
 
 // NewHotSpotCommand return a hot subcommand of rootCmd
@@ -127,6 +129,10 @@ func NewHotRegionsHistoryCommand() *cobra.Command {
 		Short: "show the hot history regions",
 		Run:   showHotRegionsHistoryCommandFunc,
 	}
+	cmd.Flags().String("group-by", "", "aggregate the hot history regions by region, table, keyspace or store")
+	cmd.Flags().String("sort-by", storage.HotRegionsSortByByte, "sort the aggregated groups by byte, key or query")
+	cmd.Flags().Int("limit", 0, "the number of the aggregated groups to show, 0 means no limit")
+	cmd.Flags().Duration("step", 0, "the step of the store timeline, default to the hot regions write interval")
 	return cmd
 }
 
@@ -165,6 +171,10 @@ func showHotRegionsHistoryCommandFunc(cmd *cobra.Command, args []string) {
 		cmd.Printf("Failed to get history hotspot: %s\n", err)
 		return
 	}
+	if groupBy, _ := cmd.Flags().GetString("group-by"); groupBy != "" {
+		showHotRegionsHistoryAggregationCommandFunc(cmd, groupBy, input)
+		return
+	}
 	data, _ := json.Marshal(input)
 	endpoints := getEndpoints(cmd)
 	hotRegions := &storage.HistoryHotRegions{}
@@ -200,6 +210,45 @@ func showHotRegionsHistoryCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(string(resp))
 }
 
+func showHotRegionsHistoryAggregationCommandFunc(cmd *cobra.Command, groupBy string, input map[string]any) {
+	sortBy, _ := cmd.Flags().GetString("sort-by")
+	limit, _ := cmd.Flags().GetInt("limit")
+	step, _ := cmd.Flags().GetDuration("step")
+	input["group_by"] = groupBy
+	input["sort_by"] = sortBy
+	input["step"] = step.Milliseconds()
+	data, _ := json.Marshal(input)
+	// Each PD server only keeps the history during it is the leader,
+	// so the groups are merged before applying the limit.
+	agg := &storage.HistoryHotRegionsAggregation{GroupBy: groupBy}
+	for _, endpoint := range getEndpoints(cmd) {
+		resp, err := doRequestSingleEndpoint(cmd, endpoint, hotRegionsHistoryAggregatePrefix,
+			http.MethodGet, http.Header{
+				"Content-Type": {"application/json"},
+			}, WithBody(bytes.NewBuffer(data)))
+		if err != nil {
+			cmd.Printf("Failed to get history hotspot: %s\n", err)
+			return
+		}
+		tempAgg := &storage.HistoryHotRegionsAggregation{}
+		if err = json.Unmarshal([]byte(resp), tempAgg); err != nil {
+			cmd.Printf("Failed to get history hotspot: %s\n", err)
+			return
+		}
+		agg.Merge(tempAgg)
+	}
+	if err := agg.Sort(sortBy, limit); err != nil {
+		cmd.Printf("Failed to get history hotspot: %s\n", err)
+		return
+	}
+	resp, err := json.Marshal(agg)
+	if err != nil {
+		cmd.Printf("Failed to get history hotspot: %s\n", err)
+		return
+	}
+	cmd.Println(string(resp))
+}
+
 func parseOptionalArgs(prefix string, param string, args []string) (string, error) {
 	argsLen := len(args)
 	if argsLen > 0 {
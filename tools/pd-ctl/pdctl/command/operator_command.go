@@ -17,6 +17,7 @@ package command
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -58,6 +59,7 @@ func NewOperatorCommand() *cobra.Command {
 	c.AddCommand(NewAddOperatorCommand())
 	c.AddCommand(NewRemoveOperatorCommand())
 	c.AddCommand(NewHistoryOperatorCommand())
+	c.AddCommand(NewBatchOperatorCommand())
 	return c
 }
 
@@ -454,10 +456,27 @@ func NewHistoryOperatorCommand() *cobra.Command {
 		Run:     historyOperatorCommandFunc,
 		Example: HistoryExample,
 	}
+	c.Flags().Bool("persisted", false, "list the persisted operators instead of the recent ones in memory, implied by the other flags")
+	c.Flags().String("end", "", "list the persisted operators finished before end, end is a timestamp")
+	c.Flags().StringSlice("region", nil, "list the persisted operators of the regions")
+	c.Flags().StringSlice("store", nil, "list the persisted operators involving the stores")
+	c.Flags().StringSlice("scheduler", nil, "list the persisted operators created by the schedulers or checkers")
 	return c
 }
 
 func historyOperatorCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	persisted, _ := cmd.Flags().GetBool("persisted")
+	for _, name := range []string{"end", "region", "store", "scheduler"} {
+		persisted = persisted || cmd.Flags().Changed(name)
+	}
+	if persisted {
+		showPersistedOperatorHistory(cmd, args)
+		return
+	}
 	path := operatorsPrefix + "/" + "records"
 	if len(args) == 1 {
 		path += "?from=" + args[0]
@@ -470,6 +489,87 @@ func historyOperatorCommandFunc(cmd *cobra.Command, args []string) {
 	cmd.Println(records)
 }
 
+func showPersistedOperatorHistory(cmd *cobra.Command, args []string) {
+	query := url.Values{}
+	if len(args) == 1 {
+		query.Set("start", args[0])
+	}
+	if end, _ := cmd.Flags().GetString("end"); end != "" {
+		query.Set("end", end)
+	}
+	regions, _ := cmd.Flags().GetStringSlice("region")
+	for _, id := range regions {
+		query.Add("region_id", id)
+	}
+	stores, _ := cmd.Flags().GetStringSlice("store")
+	for _, id := range stores {
+		query.Add("store_id", id)
+	}
+	schedulers, _ := cmd.Flags().GetStringSlice("scheduler")
+	for _, name := range schedulers {
+		query.Add("scheduler", name)
+	}
+	path := operatorsPrefix + "/history"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	r, err := doRequest(cmd, path, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+// NewBatchOperatorCommand returns commands about operator batches.
+func NewBatchOperatorCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "batch",
+		Short: "operator batch commands",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "show [batch_id]",
+		Short: "show the operator batches",
+		Run:   showOperatorBatchCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "cancel <batch_id>",
+		Short: "cancel the operator batch and all its running operators",
+		Run:   cancelOperatorBatchCommandFunc,
+	})
+	return c
+}
+
+func showOperatorBatchCommandFunc(cmd *cobra.Command, args []string) {
+	path := operatorsPrefix + "/batches"
+	if len(args) == 1 {
+		path += "/" + args[0]
+	} else if len(args) > 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, path, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func cancelOperatorBatchCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	path := operatorsPrefix + "/batches/" + args[0]
+	_, err := doRequest(cmd, path, http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
 func parseUint64s(args []string) ([]uint64, error) {
 	results := make([]uint64, 0, len(args))
 	for _, arg := range args {
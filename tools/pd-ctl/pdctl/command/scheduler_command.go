@@ -33,6 +33,8 @@ var (
 	schedulersPrefix          = "pd/api/v1/schedulers"
 	schedulerConfigPrefix     = "pd/api/v1/scheduler-config"
 	schedulerDiagnosticPrefix = "pd/api/v1/schedulers/diagnostic"
+	schedulerShadowPrefix     = "pd/api/v1/schedulers/shadow"
+	scheduleTimeWindowsPrefix = "pd/api/v1/schedulers/time-windows"
 	evictLeaderSchedulerName  = "evict-leader-scheduler"
 	grantLeaderSchedulerName  = "grant-leader-scheduler"
 )
@@ -50,6 +52,7 @@ func NewSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewResumeSchedulerCommand())
 	c.AddCommand(NewConfigSchedulerCommand())
 	c.AddCommand(NewDescribeSchedulerCommand())
+	c.AddCommand(NewShadowSchedulerCommand())
 	return c
 }
 
@@ -112,7 +115,7 @@ func NewShowSchedulerCommand() *cobra.Command {
 		Short: "show schedulers",
 		Run:   showSchedulerCommandFunc,
 	}
-	c.Flags().String("status", "", "the scheduler status value can be [paused | disabled]")
+	c.Flags().String("status", "", "the scheduler status value can be [paused | disabled | shadow | out-of-window]")
 	c.Flags().BoolP("timestamp", "t", false, "fetch the paused and resume timestamp for paused scheduler(s)")
 	return c
 }
@@ -153,6 +156,7 @@ func NewAddSchedulerCommand() *cobra.Command {
 	c.AddCommand(NewScatterRangeSchedulerCommandV2())
 	c.AddCommand(NewBalanceLeaderSchedulerCommand())
 	c.AddCommand(NewBalanceRegionSchedulerCommand())
+	c.AddCommand(NewBalanceCostSchedulerCommand())
 	c.AddCommand(NewBalanceHotRegionSchedulerCommand())
 	c.AddCommand(NewRandomMergeSchedulerCommand())
 	c.AddCommand(NewLabelSchedulerCommand())
@@ -313,6 +317,16 @@ func NewBalanceRegionSchedulerCommand() *cobra.Command {
 	return c
 }
 
+// NewBalanceCostSchedulerCommand returns a command to add a balance-cost-scheduler.
+func NewBalanceCostSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cost-scheduler",
+		Short: "add a scheduler to balance the cost of stores, which is weighted by region size, written bytes, CPU usage and IO pressure",
+		Run:   addSchedulerCommandFunc,
+	}
+	return c
+}
+
 // NewBalanceHotRegionSchedulerCommand returns a command to add a balance-hot-region-scheduler.
 func NewBalanceHotRegionSchedulerCommand() *cobra.Command {
 	c := &cobra.Command{
@@ -542,14 +556,105 @@ func NewConfigSchedulerCommand() *cobra.Command {
 		newConfigShuffleRegionCommand(),
 		newConfigGrantHotRegionCommand(),
 		newConfigBalanceLeaderCommand(),
+		newConfigBalanceCostCommand(),
 		newConfigEvictSlowStoreCommand(),
 		newConfigShuffleHotRegionSchedulerCommand(),
 		newConfigEvictSlowTrendCommand(),
 		newConfigBalanceRangeCommand(),
+		newConfigTimeWindowCommand(),
 	)
 	return c
 }
 
+func newConfigTimeWindowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "time-window",
+		Short: "time-window config, a scheduler or checker with time windows only runs in these windows",
+		Run:   showTimeWindowCommandFunc,
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the time windows of all the schedulers and checkers",
+		Run:   showTimeWindowCommandFunc,
+	})
+	setCommand := &cobra.Command{
+		Use:   "set <scheduler|checker> <start-end>...",
+		Short: "set the time windows of a scheduler or checker, e.g. `set balance-region-scheduler 22:00-06:00 --weekdays 1-5`",
+		Run:   setTimeWindowCommandFunc,
+	}
+	setCommand.Flags().String("weekdays", "*", "the cron-like day-of-week field of the time windows, e.g. 1-5 or 0,6")
+	setCommand.Flags().String("time-zone", "", "the IANA time zone of the time windows, UTC by default")
+	c.AddCommand(setCommand)
+	c.AddCommand(&cobra.Command{
+		Use:   "delete <scheduler|checker>",
+		Short: "delete the time windows of a scheduler or checker",
+		Run:   deleteTimeWindowCommandFunc,
+	})
+	return c
+}
+
+func showTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	r, err := doRequest(cmd, scheduleTimeWindowsPrefix, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
+
+func setTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	weekdays, _ := cmd.Flags().GetString("weekdays")
+	timeZone, _ := cmd.Flags().GetString("time-zone")
+	windows := make([]map[string]string, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		start, end, ok := strings.Cut(arg, "-")
+		if !ok {
+			cmd.Printf("Invalid time window %s, it should be like 22:00-06:00\n", arg)
+			return
+		}
+		windows = append(windows, map[string]string{
+			"weekdays":  weekdays,
+			"start":     start,
+			"end":       end,
+			"time-zone": timeZone,
+		})
+	}
+	data, err := json.Marshal(windows)
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	path := scheduleTimeWindowsPrefix + "/" + getEscapedSchedulerName(args[0])
+	_, err = doRequest(cmd, path, http.MethodPost, http.Header{"Content-Type": {"application/json"}}, WithBody(bytes.NewBuffer(data)))
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
+func deleteTimeWindowCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	path := scheduleTimeWindowsPrefix + "/" + getEscapedSchedulerName(args[0])
+	_, err := doRequest(cmd, path, http.MethodDelete, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println("Success!")
+}
+
 func newConfigBalanceLeaderCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "balance-leader-scheduler",
@@ -570,6 +675,26 @@ func newConfigBalanceLeaderCommand() *cobra.Command {
 	return c
 }
 
+func newConfigBalanceCostCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cost-scheduler",
+		Short: "balance-cost-scheduler config",
+		Run:   listSchedulerConfigCommandFunc,
+	}
+
+	c.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "show the config item",
+		Run:   listSchedulerConfigCommandFunc,
+	}, &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "set the config item, e.g. `set cpu-weight 2`",
+		Run:   func(cmd *cobra.Command, args []string) { postSchedulerConfigCommandFunc(cmd, c.Name(), args) },
+	})
+
+	return c
+}
+
 func newConfigBalanceRangeCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use:   "balance-range-scheduler",
@@ -706,6 +831,7 @@ var hiddenHotConfig = []string{
 	"byte-rate-rank-step-ratio",
 	"key-rate-rank-step-ratio",
 	"query-rate-rank-step-ratio",
+	"cpu-rate-rank-step-ratio",
 	"count-rank-step-ratio",
 	"great-dec-ratio",
 	"minor-dec-ratio",
@@ -950,6 +1076,7 @@ func NewDescribeSchedulerCommand() *cobra.Command {
 	c.AddCommand(
 		newDescribeBalanceRegionCommand(),
 		newDescribeBalanceLeaderCommand(),
+		newDescribeBalanceCostCommand(),
 	)
 	return c
 }
@@ -972,6 +1099,15 @@ func newDescribeBalanceLeaderCommand() *cobra.Command {
 	return c
 }
 
+func newDescribeBalanceCostCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "balance-cost-scheduler",
+		Short: "describe the balance-cost-scheduler",
+		Run:   describeSchedulerCommandFunc,
+	}
+	return c
+}
+
 func describeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
 		cmd.Println(cmd.UsageString())
@@ -987,3 +1123,51 @@ func describeSchedulerCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cmd.Println(r)
 }
+
+// NewShadowSchedulerCommand returns command to manage the shadow mode of the scheduler.
+func NewShadowSchedulerCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "shadow",
+		Short: "manage the shadow mode of a scheduler, the operators are only recorded in shadow mode",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "enable <scheduler>",
+		Short: "enable the shadow mode of a scheduler",
+		Run:   setShadowSchedulerCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "disable <scheduler>",
+		Short: "disable the shadow mode of a scheduler",
+		Run:   setShadowSchedulerCommandFunc,
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "show <scheduler>",
+		Short: "show the operators, influence and projected store scores recorded in shadow mode",
+		Run:   showShadowSchedulerCommandFunc,
+	})
+	return c
+}
+
+func setShadowSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	path := schedulerShadowPrefix + "/" + getEscapedSchedulerName(args[0])
+	input := map[string]any{"enable": cmd.Name() == "enable"}
+	postJSON(cmd, path, input)
+}
+
+func showShadowSchedulerCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Println(cmd.UsageString())
+		return
+	}
+	url := schedulerShadowPrefix + "/" + getEscapedSchedulerName(args[0])
+	r, err := doRequest(cmd, url, http.MethodGet, http.Header{})
+	if err != nil {
+		cmd.Println(err)
+		return
+	}
+	cmd.Println(r)
+}
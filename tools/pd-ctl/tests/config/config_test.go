@@ -24,15 +24,19 @@ import (
 	"time"
 
 	"github.com/coreos/go-semver/semver"
+	"github.com/docker/go-units"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/kvproto/pkg/pdpb"
 
+	"github.com/tikv/pd/pkg/core"
 	"github.com/tikv/pd/pkg/ratelimit"
 	sc "github.com/tikv/pd/pkg/schedule/config"
 	"github.com/tikv/pd/pkg/schedule/placement"
+	"github.com/tikv/pd/pkg/utils/keypath"
 	"github.com/tikv/pd/pkg/utils/testutil"
 	"github.com/tikv/pd/pkg/utils/typeutil"
 	"github.com/tikv/pd/server/config"
@@ -830,6 +834,166 @@ func (suite *configTestSuite) checkPlacementRuleBundle(cluster *pdTests.TestClus
 	})
 }
 
+func (suite *configTestSuite) TestPlacementRuleSimulate() {
+	suite.env.RunTest(suite.checkPlacementRuleSimulate)
+}
+
+func (suite *configTestSuite) checkPlacementRuleSimulate(cluster *pdTests.TestCluster) {
+	re := suite.Require()
+	leaderServer := cluster.GetLeaderServer()
+	pdAddr := leaderServer.GetAddr()
+	cmd := ctl.GetRootCmd()
+
+	// The stores are healthy so that they can be the targets.
+	for id := uint64(1); id <= 3; id++ {
+		pdTests.MustPutStore(re, cluster, &metapb.Store{
+			Id:            id,
+			State:         metapb.StoreState_Up,
+			LastHeartbeat: time.Now().UnixNano(),
+		})
+		pdTests.MustHandleStoreHeartbeat(re, cluster, &pdpb.StoreHeartbeatRequest{
+			Header: &pdpb.RequestHeader{ClusterId: keypath.ClusterID()},
+			Stats: &pdpb.StoreStats{
+				StoreId:   id,
+				Capacity:  100 * units.GiB,
+				Available: 100 * units.GiB,
+			},
+		})
+	}
+	pdTests.MustPutRegion(re, cluster, 1, 1, []byte("a"), []byte("b"), core.SetApproximateSize(10))
+	output, err := tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "enable")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+
+	f, err := os.CreateTemp("", "pd_tests")
+	re.NoError(err)
+	fname := f.Name()
+	f.Close()
+	defer os.RemoveAll(fname)
+
+	simulate := func(count int) *placement.SimulationResult {
+		bundles := []placement.GroupBundle{{ID: placement.DefaultGroupID, Rules: []*placement.Rule{
+			{GroupID: placement.DefaultGroupID, ID: placement.DefaultRuleID, Role: placement.Voter, Count: count},
+		}}}
+		b, err := json.Marshal(bundles)
+		re.NoError(err)
+		re.NoError(os.WriteFile(fname, b, 0600))
+		output, err := tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-bundle", "simulate", "--in="+fname)
+		re.NoError(err)
+		var res placement.SimulationResult
+		re.NoError(json.Unmarshal(output, &res), string(output))
+		return &res
+	}
+
+	res := simulate(1)
+	re.Equal(1, res.RegionCount)
+	re.Zero(res.ChangedCount)
+	res = simulate(3)
+	re.Equal(1, res.ChangedCount)
+	re.Equal(2, res.AddPeers)
+	re.Equal(int64(20*units.MiB), res.MoveBytes)
+	res = simulate(4)
+	re.Equal(1, res.UnsatisfiableCount)
+	re.Equal([]uint64{1}, res.UnsatisfiableRegions)
+
+	// The rules in use are not changed.
+	checkLoadRuleBundle(re, pdAddr, fname, []placement.GroupBundle{
+		{ID: placement.DefaultGroupID, Index: 0, Override: false, Rules: []*placement.Rule{{GroupID: placement.DefaultGroupID, ID: placement.DefaultRuleID, Role: placement.Voter, Count: 3}}},
+	})
+}
+
+func (suite *configTestSuite) TestPlacementRuleHistory() {
+	suite.env.RunTest(suite.checkPlacementRuleHistory)
+}
+
+func (suite *configTestSuite) checkPlacementRuleHistory(cluster *pdTests.TestCluster) {
+	re := suite.Require()
+	leaderServer := cluster.GetLeaderServer()
+	pdAddr := leaderServer.GetAddr()
+	cmd := ctl.GetRootCmd()
+
+	pdTests.MustPutStore(re, cluster, &metapb.Store{
+		Id:    1,
+		State: metapb.StoreState_Up,
+	})
+	output, err := tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "enable")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+
+	f, err := os.CreateTemp("", "pd_tests")
+	re.NoError(err)
+	fname := f.Name()
+	f.Close()
+	defer os.RemoveAll(fname)
+
+	setCount := func(count int) {
+		bundle := placement.GroupBundle{ID: placement.DefaultGroupID, Rules: []*placement.Rule{
+			{GroupID: placement.DefaultGroupID, ID: placement.DefaultRuleID, Role: placement.Voter, Count: count},
+		}}
+		b, err := json.Marshal(bundle)
+		re.NoError(err)
+		re.NoError(os.WriteFile(fname, b, 0600))
+		_, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rule-bundle", "set", "--in="+fname)
+		re.NoError(err)
+	}
+	getHistories := func() []*placement.RuleHistory {
+		output, err := tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "history")
+		re.NoError(err)
+		var histories []*placement.RuleHistory
+		re.NoError(json.Unmarshal(output, &histories), string(output))
+		return histories
+	}
+
+	// The histories may be recorded by the previous tests on the same cluster.
+	histories := getHistories()
+	base, version := len(histories), uint64(0)
+	if base > 0 {
+		version = histories[0].Version
+	}
+	setCount(2)
+	setCount(1)
+	histories = getHistories()
+	re.Len(histories, base+2)
+	re.Equal(version+2, histories[0].Version)
+	re.Len(histories[0].Changes, 1)
+	re.Equal(2, histories[0].Changes[0].Old.Rules[0].Count)
+	re.Equal(1, histories[0].Changes[0].New.Rules[0].Count)
+
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rollback", strconv.FormatUint(version+1, 10))
+	re.NoError(err)
+	re.Contains(string(output), "Rollback rules successfully.")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "show", "--group=pd", "--id=default", "--from_pd")
+	re.NoError(err)
+	var rule placement.Rule
+	re.NoError(json.Unmarshal(output, &rule), string(output))
+	re.Equal(2, rule.Count)
+	// The rollback is recorded as well.
+	histories = getHistories()
+	re.Len(histories, base+3)
+	re.Equal(2, histories[0].Changes[0].New.Rules[0].Count)
+
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rollback", strconv.FormatUint(version+10, 10))
+	re.NoError(err)
+	re.Contains(string(output), "not found")
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "placement-rules", "rollback", "x")
+	re.NoError(err)
+	re.Contains(string(output), "version should be a number")
+
+	// The changes of the default rule made by the replication config are recorded too.
+	setCount(3)
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "set", "max-replicas", "2")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+	histories = getHistories()
+	re.Len(histories, base+5)
+	re.Equal("replication-config", histories[0].Source)
+	re.Equal(2, histories[0].Changes[0].New.Rules[0].Count)
+	// Restore the replication config to be consistent with the default rule after the reset.
+	output, err = tests.ExecuteCommand(cmd, "-u", pdAddr, "config", "set", "max-replicas", "3")
+	re.NoError(err)
+	re.Contains(string(output), "Success!")
+}
+
 func checkLoadRuleBundle(re *require.Assertions, pdAddr string, fname string, expectValues []placement.GroupBundle) {
 	var bundles []placement.GroupBundle
 	cmd := ctl.GetRootCmd()
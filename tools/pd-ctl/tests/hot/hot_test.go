@@ -177,6 +177,7 @@ func (suite *hotTestSuite) checkHot(cluster *pdTests.TestCluster) {
 					utils.RegionWriteBytes:    0,
 					utils.RegionWriteKeys:     0,
 					utils.RegionWriteQueryNum: 0,
+					utils.RegionReadCPU:       0,
 				}
 				leader := &metapb.Peer{
 					Id:      100 + regionIDCounter,
@@ -488,6 +489,44 @@ func (suite *hotTestSuite) checkHistoryHotRegions(cluster *pdTests.TestCluster)
 	output, err = tests.ExecuteCommand(cmd, args...)
 	re.NoError(err)
 	re.Error(json.Unmarshal(output, &hotRegions))
+
+	// aggregate the history hot regions.
+	agg := storage.HistoryHotRegionsAggregation{}
+	args = []string{"-u", pdAddr, "hot", "history",
+		start, end,
+		"hot_region_type", "write",
+		"region_id", "1,2",
+		"--group-by", "region",
+		"--limit", "1",
+	}
+	output, err = tests.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.NoError(json.Unmarshal(output, &agg))
+	re.Equal(storage.HotRegionsGroupByRegion, agg.GroupBy)
+	re.Len(agg.Groups, 1)
+	re.Equal("2", agg.Groups[0].Key)
+	re.Equal("write", agg.Groups[0].HotRegionType)
+	args = []string{"-u", pdAddr, "hot", "history",
+		start, end,
+		"hot_region_type", "write",
+		"store_id", "1",
+		"--group-by", "store",
+		"--limit", "0",
+		"--step", "1m",
+	}
+	output, err = tests.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.NoError(json.Unmarshal(output, &agg))
+	re.Len(agg.Groups, 1)
+	re.Equal("1", agg.Groups[0].Key)
+	re.NotEmpty(agg.Groups[0].Timeline)
+	args = []string{"-u", pdAddr, "hot", "history",
+		start, end,
+		"--group-by", "zone",
+	}
+	output, err = tests.ExecuteCommand(cmd, args...)
+	re.NoError(err)
+	re.Contains(string(output), "invalid argument for group_by")
 }
 
 func (suite *hotTestSuite) TestBuckets() {
@@ -664,6 +664,7 @@ func (suite *schedulerTestSuite) checkHotRegionSchedulerConfig(cluster *pdTests.
 		"min-hot-byte-rate":       float64(100),
 		"min-hot-key-rate":        float64(10),
 		"min-hot-query-rate":      float64(10),
+		"min-hot-cpu-rate":        float64(1),
 		"src-tolerance-ratio":     1.05,
 		"dst-tolerance-ratio":     1.05,
 		"read-priorities":         []any{"byte", "key"},
@@ -674,6 +675,10 @@ func (suite *schedulerTestSuite) checkHotRegionSchedulerConfig(cluster *pdTests.
 		"split-thresholds":        0.2,
 		"history-sample-duration": "5m0s",
 		"history-sample-interval": "30s",
+		"forecast-model":          "none",
+		"forecast-period":         "24h0m0s",
+		"forecast-seasons":        float64(7),
+		"forecast-horizon":        "10m0s",
 	}
 	checkHotSchedulerConfig := func(expect map[string]any) {
 		testutil.Eventually(re, func() bool {
@@ -721,6 +726,17 @@ func (suite *schedulerTestSuite) checkHotRegionSchedulerConfig(cluster *pdTests.
 	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "read-priorities", "key,key,byte"}, nil)
 	re.Contains(echo, "Failed!")
 	checkHotSchedulerConfig(expected1)
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "read-priorities", "cpu,byte"}, nil)
+	re.Contains(echo, "Success!")
+	expected1["read-priorities"] = []any{"cpu", "byte"}
+	checkHotSchedulerConfig(expected1)
+	// cpu is only available for read.
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "write-leader-priorities", "cpu,byte"}, nil)
+	re.Contains(echo, "Failed!")
+	checkHotSchedulerConfig(expected1)
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "write-peer-priorities", "cpu,byte"}, nil)
+	re.Contains(echo, "Failed!")
+	checkHotSchedulerConfig(expected1)
 
 	// write-priorities is divided into write-leader-priorities and write-peer-priorities
 	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "write-priorities", "key,byte"}, nil)
@@ -764,6 +780,32 @@ func (suite *schedulerTestSuite) checkHotRegionSchedulerConfig(cluster *pdTests.
 	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "history-sample-interval", "0s"}, nil)
 	re.Contains(echo, "Success!")
 	checkHotSchedulerConfig(expected1)
+
+	expected1["forecast-model"] = "periodic"
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-model", "periodic"}, nil)
+	re.Contains(echo, "Success!")
+	checkHotSchedulerConfig(expected1)
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-model", "arima"}, nil)
+	re.Contains(echo, "Failed!")
+	checkHotSchedulerConfig(expected1)
+
+	expected1["forecast-period"] = "168h0m0s"
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-period", "168h"}, nil)
+	re.Contains(echo, "Success!")
+	checkHotSchedulerConfig(expected1)
+
+	expected1["forecast-seasons"] = float64(4)
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-seasons", "4"}, nil)
+	re.Contains(echo, "Success!")
+	checkHotSchedulerConfig(expected1)
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-seasons", "0"}, nil)
+	re.Contains(echo, "Failed!")
+	checkHotSchedulerConfig(expected1)
+
+	expected1["forecast-horizon"] = "30m0s"
+	echo = tests.MustExec(re, cmd, []string{"-u", pdAddr, "scheduler", "config", "balance-hot-region-scheduler", "set", "forecast-horizon", "30m"}, nil)
+	re.Contains(echo, "Success!")
+	checkHotSchedulerConfig(expected1)
 }
 
 func (suite *schedulerTestSuite) TestSchedulerDiagnostic() {